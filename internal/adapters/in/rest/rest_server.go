@@ -2,40 +2,57 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"fs-access-api/internal/app/config"
 	"fs-access-api/internal/app/ports"
+	"log"
+	"math"
+	"mime"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"fs-access-api/internal/adapters/in/rest/openapi" // generated
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 )
 
 type DefaultRestServer struct {
 	apis          ports.ApiServer
 	restCfg       config.HttpServerConfig
 	authenticator ports.Authenticator
+	accessPolicy  ports.AccessPolicy
 	actionMetrics ports.ActionMetrics
 	startTime     time.Time
+	// configFingerprint is the config.ProgramConfig Fingerprint answered by GetConfigFingerprint
+	configFingerprint string
+	// noCache holds the no-cache windows opened by the recent mutations, per resource
+	noCache noCacheWindows
 }
 
 // Enforce compile-time conformance to a generated interface
 var _ openapi.ServerInterface = (*DefaultRestServer)(nil)
 
-func NewRestServer(cfg config.HttpServerConfig, apiServer ports.ApiServer, authenticator ports.Authenticator, metrics ports.ActionMetrics) (*DefaultRestServer, error) {
+func NewRestServer(cfg config.HttpServerConfig, apiServer ports.ApiServer, authenticator ports.Authenticator, accessPolicy ports.AccessPolicy, metrics ports.ActionMetrics, configFingerprint string) (*DefaultRestServer, error) {
 	return &DefaultRestServer{
-		restCfg:       cfg,
-		apis:          apiServer,
-		authenticator: authenticator,
-		actionMetrics: metrics,
-		startTime:     time.Now().UTC(),
+		restCfg:           cfg,
+		apis:              apiServer,
+		authenticator:     authenticator,
+		accessPolicy:      accessPolicy,
+		actionMetrics:     metrics,
+		startTime:         time.Now().UTC(),
+		configFingerprint: configFingerprint,
 	}, nil
 }
 
-func (s *DefaultRestServer) Health(w http.ResponseWriter, _ *http.Request) {
+func (s *DefaultRestServer) Health(w http.ResponseWriter, r *http.Request) {
 	err := s.apis.HealthCheck()
 	if err == nil {
-		writeJSON(w, http.StatusOK, openapi.HealthStatusResponseBody{
+		writeJSON(w, r, http.StatusOK, openapi.HealthStatusResponseBody{
 			Banner:    s.restCfg.Banner,
 			Reason:    nil,
 			StartedAt: s.startTime,
@@ -44,9 +61,10 @@ func (s *DefaultRestServer) Health(w http.ResponseWriter, _ *http.Request) {
 		})
 		return
 	} else {
-		writeJSON(w, http.StatusServiceUnavailable, openapi.HealthStatusResponseBody{
+		SetRetryAfter(w, s.restCfg.RetryAfter)
+		writeJSON(w, r, http.StatusServiceUnavailable, openapi.HealthStatusResponseBody{
 			Banner:    s.restCfg.Banner,
-			Reason:    ptr(err.Error()),
+			Reason:    ptr(s.serverErrorMessage(r, err.Error())), // the database error only with error_detail: full
 			StartedAt: s.startTime,
 			Healthy:   false,
 			UptimeSec: int64(time.Since(s.startTime).Seconds()),
@@ -55,6 +73,90 @@ func (s *DefaultRestServer) Health(w http.ResponseWriter, _ *http.Request) {
 	}
 }
 
+// ReadinessProbe is implemented by the servers answering /readyz themselves, see app.BuildRouter.
+type ReadinessProbe interface {
+	Readyz(w http.ResponseWriter, r *http.Request)
+}
+
+var _ ReadinessProbe = (*DefaultRestServer)(nil)
+
+// readiness is the /readyz body, Failed names the dependency that doesn't respond.
+type readiness struct {
+	Status string `json:"status"`
+	Failed string `json:"failed,omitempty"`
+}
+
+// Readyz answers 503 while the account repository doesn't respond, so no traffic is routed to an
+// instance with a dead database connection; the error itself is only logged.
+func (s *DefaultRestServer) Readyz(w http.ResponseWriter, r *http.Request) {
+	if err := s.apis.HealthCheck(); err != nil {
+		log.Printf("not ready, account repository: %v", err)
+		SetRetryAfter(w, s.restCfg.RetryAfter)
+		writeJSON(w, r, http.StatusServiceUnavailable, readiness{Status: "unavailable", Failed: "account_repository"})
+		return
+	}
+	writeJSON(w, r, http.StatusOK, readiness{Status: "ready"})
+}
+
+func (s *DefaultRestServer) GetInfo(w http.ResponseWriter, r *http.Request) {
+	if err := s.authenticator.Verify(r); err != nil {
+		writeAuthError(w, err)
+		return
+	}
+	info, caps, err := s.apis.RepositoryInfo()
+	if err != nil {
+		s.writeServerError(w, r, "cannot get account repository info: "+err.Error())
+		return
+	}
+	writeJSON(w, r, http.StatusOK, openapi.InfoResponseBody{
+		AccountRepository: info,
+		Capabilities: openapi.RepoCapabilities{
+			SupportsTransactions: caps.SupportsTransactions,
+			SupportsRename:       caps.SupportsRename,
+			ReadOnly:             caps.ReadOnly,
+			SupportsMaintenance:  caps.SupportsMaintenance,
+		},
+	})
+}
+
+func (s *DefaultRestServer) GetConfigFingerprint(w http.ResponseWriter, r *http.Request) {
+	if err := s.authenticator.Verify(r); err != nil {
+		writeAuthError(w, err)
+		return
+	}
+	writeJSON(w, r, http.StatusOK, openapi.ConfigFingerprintResponseBody{Fingerprint: s.configFingerprint})
+}
+
+func (s *DefaultRestServer) GetStatus(w http.ResponseWriter, r *http.Request) {
+	maintenance := s.restCfg.ReadOnly
+	message := ""
+	if maintenance {
+		message = s.restCfg.MaintenanceMessage
+	}
+	writeJSON(w, r, http.StatusOK, openapi.StatusResponseBody{
+		Maintenance: maintenance,
+		ReadOnly:    maintenance || s.apis.Capabilities().ReadOnly,
+		Message:     message,
+	})
+}
+
+// MaintenanceMiddleware answers 503 to every mutation while http_server.read_only is set,
+// the body points clients at `GET /api/status`.
+func (s *DefaultRestServer) MaintenanceMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.restCfg.ReadOnly && isMutation(r) {
+			msg := "the API is in maintenance, see GET " + s.restCfg.BasePath + "/api/status"
+			if s.restCfg.MaintenanceMessage != "" {
+				msg += ": " + s.restCfg.MaintenanceMessage
+			}
+			SetRetryAfter(w, s.restCfg.RetryAfter)
+			writeError(w, http.StatusServiceUnavailable, msg)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // "Authz" endpoints: server_authz.go
 // "Crypto" endpoints: server_crypto.go
 // "Groups" endpoints: server_groups.go
@@ -62,26 +164,209 @@ func (s *DefaultRestServer) Health(w http.ResponseWriter, _ *http.Request) {
 
 // helpers:
 
-func writeJSON(w http.ResponseWriter, status int, v any) {
+// SetRetryAfter tells the client when to retry a 429 or 503 written next: in whole seconds, at least 1.
+func SetRetryAfter(w http.ResponseWriter, after time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(max(1, int(math.Ceil(after.Seconds())))))
+}
+
+// normalizeName trims surrounding whitespace and trailing dots off a user or group name
+// when http_server.normalize_input is set, so ' bob' and 'bob.' address 'bob'.
+func (s *DefaultRestServer) normalizeName(name string) string {
+	if !s.restCfg.NormalizeInput {
+		return name
+	}
+	return strings.TrimRightFunc(strings.TrimSpace(name), func(r rune) bool { return r == '.' || unicode.IsSpace(r) })
+}
+
+// writeJSON writes v compact, or indented when the request asks for it with ?pretty=true
+// or an X-Pretty: true header (a nil request stays compact).
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(v)
+	enc := json.NewEncoder(w)
+	if wantsPretty(r) {
+		enc.SetIndent("", "  ")
+	}
+	_ = enc.Encode(v)
+}
+
+func wantsPretty(r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+	for _, v := range []string{r.URL.Query().Get("pretty"), r.Header.Get("X-Pretty")} {
+		if pretty, err := strconv.ParseBool(v); err == nil && pretty {
+			return true
+		}
+	}
+	return false
 }
 
-func isJSON(r *http.Request) bool {
+func (s *DefaultRestServer) isJSON(r *http.Request) bool {
 	ct := r.Header.Get("Content-Type")
+	if s.restCfg.StrictContentType {
+		return isStrictJSON(ct)
+	}
 	// accept "application/json" with optional charset
 	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(ct)), "application/json")
 }
 
+// isStrictJSON accepts exactly "application/json", optionally with "charset=utf-8".
+func isStrictJSON(ct string) bool {
+	mediaType, params, err := mime.ParseMediaType(ct)
+	if err != nil || mediaType != "application/json" {
+		return false
+	}
+	for name, value := range params {
+		if name != "charset" || !strings.EqualFold(value, "utf-8") {
+			return false
+		}
+	}
+	return true
+}
+
+// acceptsJSON reports whether the Accept header allows a JSON response, a missing header accepts anything.
+func acceptsJSON(accept string) bool {
+	if strings.TrimSpace(accept) == "" {
+		return true
+	}
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+		if q, ok := params["q"]; ok {
+			if v, err := strconv.ParseFloat(q, 64); err != nil || v <= 0 {
+				continue
+			}
+		}
+		switch mediaType {
+		case "application/json", "application/*", "*/*":
+			return true
+		}
+	}
+	return false
+}
+
+// RequireAcceptJSON rejects requests whose Accept header can't be satisfied with JSON (406).
+func RequireAcceptJSON(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !acceptsJSON(r.Header.Get("Accept")) {
+			writeError(w, http.StatusNotAcceptable, "Accept must allow application/json")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// routeMethods are the methods probed to fill the Allow header.
+var routeMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions}
+
+// MethodNotAllowed answers 405 with an Allow header enumerating the methods the routes serve at the request path.
+func MethodNotAllowed(routes chi.Routes) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.RawPath // chi routes on the escaped path when there is one
+		if path == "" {
+			path = r.URL.Path
+		}
+		var allowed []string
+		for _, method := range routeMethods {
+			if routes.Match(chi.NewRouteContext(), method, path) {
+				allowed = append(allowed, method)
+			}
+		}
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not allowed, allowed: %s", r.Method, strings.Join(allowed, ", ")))
+	}
+}
+
+// writeEnsured answers an ensure operation with 201/200, the body carries the lint warnings
+// only when they're enabled and there are any, so the default responses stay bodiless.
+func (s *DefaultRestServer) writeEnsured(w http.ResponseWriter, r *http.Request, created bool, lint func() []string) {
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	if s.restCfg.ReturnWarnings {
+		if warnings := lint(); len(warnings) > 0 {
+			writeJSON(w, r, status, openapi.WarningsResponseBody{Warnings: warnings})
+			return
+		}
+	}
+	w.WriteHeader(status)
+}
+
 func writeError(w http.ResponseWriter, status int, msg string) {
-	writeJSON(w, status, openapi.Error{
+	writeJSON(w, nil, status, openapi.Error{
 		Code:    http.StatusText(status),
 		Message: msg,
 	})
 }
+
+// writeValidationError answers 422 with the input problem err describes, under its field when it's a ports.FieldError.
+func writeValidationError(w http.ResponseWriter, err error) {
+	entry := openapi.FieldError{Reason: err.Error()}
+	var fe *ports.FieldError
+	if errors.As(err, &fe) {
+		entry = openapi.FieldError{Field: ptr(fe.Field), Reason: fe.Reason}
+	}
+	writeValidation(w, err.Error(), entry)
+}
+
+// writeInvalidField answers 422 about a single field of the request, reason is the message too.
+func writeInvalidField(w http.ResponseWriter, field, reason string) {
+	writeValidation(w, reason, openapi.FieldError{Field: ptr(field), Reason: reason})
+}
+
+func writeValidation(w http.ResponseWriter, msg string, errs ...openapi.FieldError) {
+	writeJSON(w, nil, http.StatusUnprocessableEntity, openapi.ValidationError{
+		Code:    http.StatusText(http.StatusUnprocessableEntity),
+		Message: msg,
+		Errors:  errs,
+	})
+}
+
+// writeServerError answers 500 with http_server.error_detail: minimal (the default) tells the client only
+// the request id to look up, as msg may carry database internals; the full message is logged either way.
+func (s *DefaultRestServer) writeServerError(w http.ResponseWriter, r *http.Request, msg string) {
+	writeError(w, http.StatusInternalServerError, s.serverErrorMessage(r, msg))
+}
+
+// serverErrorMessage logs msg and returns what http_server.error_detail lets the client see of it.
+func (s *DefaultRestServer) serverErrorMessage(r *http.Request, msg string) string {
+	reqID := middleware.GetReqID(r.Context())
+	log.Printf("internal error (request id %q): %s", reqID, msg)
+	if s.restCfg.ErrorDetail == config.ErrorDetailFull {
+		return msg
+	}
+	if reqID == "" {
+		return "internal server error"
+	}
+	return "internal server error, request id: " + reqID
+}
+
 func writeAuthError(w http.ResponseWriter, err error) {
 	writeError(w, http.StatusUnauthorized, err.Error())
 }
 
+// checkIfMatch compares the If-Match header (a resource version, quotes optional, "*" matches any)
+// with the current version.
+func checkIfMatch(ifMatch *string, current uint64) error {
+	if ifMatch == nil {
+		return nil
+	}
+	tag := strings.Trim(strings.TrimSpace(*ifMatch), `"`)
+	if tag == "*" {
+		return nil
+	}
+	expected, err := strconv.ParseUint(tag, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: If-Match must be a resource version: %q", ports.ErrInvalidInput, *ifMatch)
+	}
+	if expected != current {
+		return ports.ErrVersionMismatch
+	}
+	return nil
+}
+
 func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,102 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fs-access-api/internal/adapters/in/rest/openapi" // generated
+	"fs-access-api/internal/app/ports"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ctxKey string
+
+const ctxKeyApiKey ctxKey = "api-key"
+
+// apiKeyFromContext returns the api key authenticated by AccessMiddleware.
+func apiKeyFromContext(ctx context.Context) string {
+	apiKey, _ := ctx.Value(ctxKeyApiKey).(string)
+	return apiKey
+}
+
+// AccessMiddleware authenticates secured operations up front, then enforces the key's scopes (403)
+// and rate limit (429). Public operations (`security: []` in the spec) pass through untouched.
+func (s *DefaultRestServer) AccessMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the generated wrapper sets the scopes context value only for secured operations
+		if _, secured := r.Context().Value(openapi.XApiKeyScopes).([]string); !secured {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err := s.authenticator.Verify(r); err != nil {
+			writeAuthError(w, err)
+			return
+		}
+		apiKey := r.Header.Get("X-Api-Key")
+		setPrincipal(r.Context(), apiKey)
+		pattern := strings.TrimPrefix(chi.RouteContext(r.Context()).RoutePattern(), s.restCfg.BasePath)
+		scope := requiredScope(r.Method, pattern)
+		if err := s.accessPolicy.Authorize(apiKey, scope); err != nil {
+			var rle *ports.RateLimitedError
+			switch {
+			case errors.As(err, &rle):
+				SetRetryAfter(w, rle.RetryAfter)
+				writeError(w, http.StatusTooManyRequests, err.Error())
+			case errors.Is(err, ports.ErrInsufficientScope):
+				writeError(w, http.StatusForbidden, "api key lacks the required scope: "+scope)
+			default:
+				s.writeServerError(w, r, err.Error())
+			}
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKeyApiKey, apiKey)))
+	})
+}
+
+// ReadOnlyKeysMiddleware answers 403 to any request other than GET/HEAD authenticated with one of
+// the given keys. It reads the key stored by AccessMiddleware, so it must be registered inside it
+// (earlier in the generated handler's middleware list).
+func ReadOnlyKeysMiddleware(keyIDs []string) openapi.MiddlewareFunc {
+	readOnly := make(map[string]struct{}, len(keyIDs))
+	for _, id := range keyIDs {
+		readOnly[id] = struct{}{}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				if _, ok := readOnly[apiKeyFromContext(r.Context())]; ok {
+					writeError(w, http.StatusForbidden, "api key is read-only")
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requiredScope maps an operation to its scope, unknown operations map to ""
+// which only keys without scope restrictions satisfy.
+func requiredScope(method, pattern string) string {
+	read := method == http.MethodGet || method == http.MethodHead
+	switch {
+	case strings.HasPrefix(pattern, "/api/authz/"), strings.HasSuffix(pattern, "/authz"):
+		return ports.ScopeAuthz
+	case strings.HasPrefix(pattern, "/api/storage/"):
+		return ports.ScopeStorage
+	case strings.HasPrefix(pattern, "/api/admin/"), pattern == "/api/info", strings.HasPrefix(pattern, "/api/config/"):
+		return ports.ScopeAdmin
+	case strings.HasPrefix(pattern, "/api/users"):
+		if read {
+			return ports.ScopeUsersRead
+		}
+		return ports.ScopeUsersWrite
+	case strings.HasPrefix(pattern, "/api/groups"):
+		if read {
+			return ports.ScopeGroupsRead
+		}
+		return ports.ScopeGroupsWrite
+	}
+	return ""
+}
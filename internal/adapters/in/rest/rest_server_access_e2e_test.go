@@ -0,0 +1,100 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+)
+
+var _ = Describe("Access scopes and rate limits REST E2E", Ordered, func() {
+	const roKeyID = "reader"
+	var (
+		ctx     = context.Background()
+		roCli   *openapi.ClientWithResponses
+		fullCli *openapi.ClientWithResponses
+	)
+
+	BeforeAll(func() {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.Security.Authenticator.AccessKeys[roKeyID] = config.AccessKey{
+				Secret: secretHex,
+				Scopes: []string{ports.ScopeUsersRead},
+				// three requests, then practically nothing
+				RateLimit: config.RateLimitConfig{RequestsPerSecond: 0.01, Burst: 3},
+			}
+		})
+		DeferCleanup(s.Close)
+		roCli = newHmacClient(s.URL, roKeyID, secretHex)
+		fullCli = newHmacClient(s.URL, apiKeyID, secretHex)
+	})
+
+	It("read-only key can read users -> 200", func() {
+		code, body := listUsers(ctx, roCli, nil)
+		mustStatus(code, body, http.StatusOK)
+	})
+
+	It("read-only key is denied a write -> 403", func() {
+		resp, err := roCli.DeleteUserWithResponse(ctx, "user-b1")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusForbidden)
+	})
+
+	It("read-only key is denied another resource -> 403", func() {
+		resp, err := roCli.ListGroupsWithResponse(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusForbidden)
+	})
+
+	It("read-only key hits its rate limit -> 429 + Retry-After", func() {
+		for i := 0; i < 2; i++ {
+			code, body := listUsers(ctx, roCli, nil)
+			mustStatus(code, body, http.StatusOK)
+		}
+		resp, err := roCli.ListUsersWithResponse(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusTooManyRequests)
+		Expect(resp.HTTPResponse.Header.Get("Retry-After")).NotTo(BeEmpty())
+	})
+
+	It("keys without scopes or limits keep full access", func() {
+		for i := 0; i < 5; i++ {
+			resp, err := fullCli.ListGroupsWithResponse(ctx, nil)
+			Expect(err).NotTo(HaveOccurred())
+			mustStatus(resp.StatusCode(), resp.Body, http.StatusOK)
+		}
+	})
+})
+
+var _ = Describe("Default rate limit REST E2E", func() {
+	ctx := context.Background()
+
+	It("limits the api routes of keys without their own limit, never the probes", func() {
+		s := newRoutedTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.Security.RateLimit = config.RateLimitConfig{RequestsPerSecond: 0.01, Burst: 2}
+		})
+		DeferCleanup(s.Close)
+		cli := newHmacClient(s.URL, apiKeyID, secretHex)
+		for i := 0; i < 2; i++ {
+			resp, err := cli.ListGroupsWithResponse(ctx, nil)
+			Expect(err).NotTo(HaveOccurred())
+			mustStatus(resp.StatusCode(), resp.Body, http.StatusOK)
+		}
+		resp, err := cli.ListGroupsWithResponse(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusTooManyRequests)
+		Expect(resp.HTTPResponse.Header.Get("Retry-After")).NotTo(BeEmpty())
+
+		for i := 0; i < 5; i++ {
+			probe, err := http.Get(s.URL + "/healthz")
+			Expect(err).NotTo(HaveOccurred())
+			_ = probe.Body.Close()
+			Expect(probe.StatusCode).To(Equal(http.StatusOK))
+		}
+	})
+})
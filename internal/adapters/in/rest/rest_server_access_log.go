@@ -0,0 +1,67 @@
+package rest
+
+import (
+	"context"
+	"fs-access-api/internal/app/config"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const ctxKeyPrincipal ctxKey = "principal"
+
+// principal is filled by AccessMiddleware with the api key it authenticated, for the access log
+// wrapping the router to read once the request is served.
+type principal struct {
+	apiKey string
+}
+
+func setPrincipal(ctx context.Context, apiKey string) {
+	if p, ok := ctx.Value(ctxKeyPrincipal).(*principal); ok {
+		p.apiKey = apiKey
+	}
+}
+
+// AccessLog writes one entry per served request in the configured format. The principal is the api key
+// AccessMiddleware authenticated, empty for public operations and requests failing authentication.
+func AccessLog(cfg config.AccessLogConfig, out io.Writer) func(http.Handler) http.Handler {
+	var handler slog.Handler = slog.NewTextHandler(out, nil)
+	if cfg.Format == config.AccessLogFormatJSON {
+		handler = slog.NewJSONHandler(out, nil)
+	}
+	logger := slog.New(handler)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			p := &principal{}
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), ctxKeyPrincipal, p)))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK // nothing written at all
+			}
+			clientIP := r.RemoteAddr
+			if host, _, err := net.SplitHostPort(clientIP); err == nil {
+				clientIP = host
+			}
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", status),
+				slog.Duration("latency", time.Since(start)),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.String("client_ip", clientIP),
+				slog.String("request_id", middleware.GetReqID(r.Context())),
+			}
+			if !cfg.OmitPrincipal {
+				attrs = append(attrs, slog.String("principal", p.apiKey))
+			}
+			logger.LogAttrs(r.Context(), slog.LevelInfo, "access", attrs...)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package rest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fs-access-api/internal/adapters/in/rest"
+	"fs-access-api/internal/app"
+	"fs-access-api/internal/app/config"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+)
+
+var _ = Describe("Access log REST E2E", func() {
+	ctx := context.Background()
+
+	// newLoggedServer serves the API behind the access log writing into the returned buffer.
+	newLoggedServer := func(logCfg config.AccessLogConfig) (*httptest.Server, *bytes.Buffer) {
+		cfg, rs := newTestRestServer(TestConfigPath, nil)
+		signer, err := app.BuildResponseSigner(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		out := &bytes.Buffer{}
+		r := chi.NewRouter()
+		r.Use(rest.AccessLog(logCfg, out))
+		_ = openapi.HandlerWithOptions(rs, openapi.ChiServerOptions{
+			BaseRouter: r,
+			Middlewares: []openapi.MiddlewareFunc{rs.MaintenanceMiddleware, rest.ReadOnlyKeysMiddleware(cfg.Security.ReadOnlyKeys), rest.ResponseSigningMiddleware(signer),
+				rs.AccessMiddleware, rs.CacheMiddleware},
+		})
+		s := httptest.NewServer(r)
+		DeferCleanup(s.Close)
+		return s, out
+	}
+
+	It("logs a json entry carrying the authenticated api key", func() {
+		s, out := newLoggedServer(config.AccessLogConfig{Enabled: true, Format: config.AccessLogFormatJSON})
+		cli := newHmacClient(s.URL, apiKeyID, secretHex)
+
+		res, err := cli.GetGroupWithResponse(ctx, "default")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+
+		var entry map[string]any
+		Expect(json.Unmarshal(out.Bytes(), &entry)).To(Succeed())
+		Expect(entry).To(HaveKeyWithValue("msg", "access"))
+		Expect(entry).To(HaveKeyWithValue("method", http.MethodGet))
+		Expect(entry).To(HaveKeyWithValue("path", "/api/groups/default"))
+		Expect(entry).To(HaveKeyWithValue("status", BeNumerically("==", http.StatusOK)))
+		Expect(entry).To(HaveKeyWithValue("bytes", BeNumerically("==", len(res.Body))))
+		Expect(entry).To(HaveKeyWithValue("client_ip", "127.0.0.1"))
+		Expect(entry).To(HaveKey("latency"))
+		Expect(entry).To(HaveKeyWithValue("principal", apiKeyID))
+	})
+
+	It("logs rejected requests without a principal", func() {
+		s, out := newLoggedServer(config.AccessLogConfig{Enabled: true, Format: config.AccessLogFormatText})
+
+		resp, err := http.Get(s.URL + "/api/groups/default")
+		Expect(err).NotTo(HaveOccurred())
+		_ = resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+
+		line := strings.TrimSpace(out.String())
+		Expect(line).To(ContainSubstring("msg=access"))
+		Expect(line).To(ContainSubstring("status=401"))
+		Expect(line).To(ContainSubstring(`principal=""`))
+	})
+
+	It("leaves the principal out with omit_principal", func() {
+		s, out := newLoggedServer(config.AccessLogConfig{Enabled: true, Format: config.AccessLogFormatText, OmitPrincipal: true})
+		cli := newHmacClient(s.URL, apiKeyID, secretHex)
+
+		res, err := cli.GetGroupWithResponse(ctx, "default")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		Expect(out.String()).To(ContainSubstring("status=200"))
+		Expect(out.String()).NotTo(ContainSubstring("principal"))
+	})
+})
@@ -32,7 +32,7 @@ var _ = Describe("Authentication variants", func() {
 		))
 		Expect(err).NotTo(HaveOccurred())
 
-		res, err := cli.ListUsersWithResponse(ctx)
+		res, err := cli.ListUsersWithResponse(ctx, nil)
 		Expect(err).NotTo(HaveOccurred())
 		mustStatus(res.StatusCode(), res.Body, http.StatusUnauthorized)
 	})
@@ -49,7 +49,7 @@ var _ = Describe("Authentication variants", func() {
 		))
 		Expect(err).NotTo(HaveOccurred())
 
-		res, err := cli.ListUsersWithResponse(ctx)
+		res, err := cli.ListUsersWithResponse(ctx, nil)
 		Expect(err).NotTo(HaveOccurred())
 		mustStatus(res.StatusCode(), res.Body, http.StatusUnauthorized)
 	})
@@ -65,7 +65,7 @@ var _ = Describe("Authentication variants", func() {
 			},
 		))
 		Expect(err).NotTo(HaveOccurred())
-		res, err := cli.ListUsersWithResponse(ctx)
+		res, err := cli.ListUsersWithResponse(ctx, nil)
 		Expect(err).NotTo(HaveOccurred())
 		mustStatus(res.StatusCode(), res.Body, http.StatusUnauthorized)
 	})
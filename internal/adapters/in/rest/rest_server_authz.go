@@ -1,16 +1,20 @@
 package rest
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"fs-access-api/internal/adapters/in/rest/openapi" // generated
 	"fs-access-api/internal/adapters/out/metrics"
 	"fs-access-api/internal/app/ports"
+	"log"
 	"net/http"
+	"strings"
 )
 
 func (s *DefaultRestServer) AuthzLookupUser(w http.ResponseWriter, r *http.Request, username openapi.UsernameParam) {
-	aa := metrics.NewAuthzAction("lookup", username)
+	username = s.normalizeName(username)
+	aa := metrics.NewAuthzAction("lookup", username, traceID(r))
 	if err := s.authenticator.Verify(r); err != nil {
 		s.actionMetrics.OnActionDone(aa.Done(ports.MAResultUnauthorizedApiClient))
 		writeAuthError(w, err) // 401
@@ -22,34 +26,84 @@ func (s *DefaultRestServer) AuthzLookupUser(w http.ResponseWriter, r *http.Reque
 
 	if err == nil {
 		if uai == nil {
-			writeError(w, http.StatusInternalServerError, "unexpected empty user info")
+			s.writeServerError(w, r, "unexpected empty user info")
 			return
 		}
 		w.Header().Set("X-FS-UID", fmt.Sprintf("%d", uai.UID))
 		w.Header().Set("X-FS-GID", fmt.Sprintf("%d", uai.GID))
 		w.Header().Set("X-FS-Dir", uai.AbsoluteHomeDir(rootPath))
+		if uai.Password != "" { // only kept with security.return_hash_in_lookup
+			log.Printf("AUDIT: password hash of user '%s' disclosed to api key '%s' (security.return_hash_in_lookup)", username, apiKeyFromContext(r.Context()))
+			w.Header().Set("X-FS-Passwd", uai.Password)
+		}
 		w.WriteHeader(http.StatusNoContent)
 		return
 	}
+	s.writeLookupError(w, r, err)
+}
 
+func (s *DefaultRestServer) GetUserAuthz(w http.ResponseWriter, r *http.Request, username openapi.UsernameParam) {
+	username = s.normalizeName(username)
+	aa := metrics.NewAuthzAction("lookup", username, traceID(r))
+	if err := s.authenticator.Verify(r); err != nil {
+		s.actionMetrics.OnActionDone(aa.Done(ports.MAResultUnauthorizedApiClient))
+		writeAuthError(w, err) // 401
+		return
+	}
+
+	uai, rootPath, err := s.apis.AuthzLookupUser(username)
+	s.actionMetrics.OnActionDone(aa.DoneFromError(err))
+
+	if err != nil {
+		s.writeLookupError(w, r, err)
+		return
+	}
+	if uai == nil {
+		s.writeServerError(w, r, "unexpected empty user info")
+		return
+	}
+	writeJSON(w, r, http.StatusOK, openapi.UserAuthzInfo{
+		Username:  uai.Username,
+		Uid:       uai.UID,
+		Groupname: uai.Groupname,
+		Gid:       uai.GID,
+		UserHome:  uai.UserHome,
+		GroupHome: uai.GroupHome,
+		HomeDir:   uai.AbsoluteHomeDir(rootPath),
+		Locked:    uai.Locked,
+	})
+}
+
+// traceID returns the trace id of the W3C traceparent header (version-traceid-parentid-flags),
+// "" when the request carries none or an invalid one.
+func traceID(r *http.Request) string {
+	parts := strings.Split(strings.TrimSpace(r.Header.Get("traceparent")), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return ""
+	}
+	if _, err := hex.DecodeString(parts[1]); err != nil || parts[1] == strings.Repeat("0", 32) {
+		return ""
+	}
+	return strings.ToLower(parts[1])
+}
+
+// writeLookupError maps lookup errors; locked users are indistinguishable from missing ones.
+func (s *DefaultRestServer) writeLookupError(w http.ResponseWriter, r *http.Request, err error) {
 	switch {
 	case errors.Is(err, ports.ErrNotFound):
 		writeError(w, http.StatusNotFound, "user not found")
-		return
 	case errors.Is(err, ports.ErrLockedUser):
 		writeError(w, http.StatusNotFound, "user not found")
-		return
 	case errors.Is(err, ports.ErrInvalidInput):
 		writeError(w, http.StatusBadRequest, err.Error())
-		return
 	default:
-		writeError(w, http.StatusInternalServerError, err.Error())
-		return
+		s.writeServerError(w, r, err.Error())
 	}
 }
 
 func (s *DefaultRestServer) AuthzAuthUser(w http.ResponseWriter, r *http.Request, username openapi.UsernameParam) {
-	aa := metrics.NewAuthzAction("auth", username)
+	username = s.normalizeName(username)
+	aa := metrics.NewAuthzAction("auth", username, traceID(r))
 
 	if err := s.authenticator.Verify(r); err != nil {
 		s.actionMetrics.OnActionDone(aa.Done(ports.MAResultUnauthorizedApiClient))
@@ -87,7 +141,7 @@ func (s *DefaultRestServer) AuthzAuthUser(w http.ResponseWriter, r *http.Request
 		writeError(w, http.StatusLocked, "user locked")
 		return
 	default:
-		writeError(w, http.StatusInternalServerError, err.Error())
+		s.writeServerError(w, r, err.Error())
 		return
 	}
 }
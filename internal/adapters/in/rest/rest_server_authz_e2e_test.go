@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
 )
 
 var _ = Describe("Authz REST E2E (smoke)", Ordered, func() {
@@ -49,5 +50,75 @@ var _ = Describe("Authz REST E2E (smoke)", Ordered, func() {
 		Expect(resp.HTTPResponse.Header.Get("X-FS-UID")).To(Equal("2001"))
 		Expect(resp.HTTPResponse.Header.Get("X-FS-GID")).To(Equal("4001"))
 		Expect(resp.HTTPResponse.Header.Get("X-FS-Dir")).To(HaveSuffix("/a"))
+		Expect(resp.HTTPResponse.Header.Values("X-FS-Passwd")).To(BeEmpty())
+	})
+
+	It("Lookup JSON: happy-path -> 200 + body", func() {
+		resp, err := authCli.GetUserAuthzWithResponse(ctx, "user-b1")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusOK)
+		Expect(resp.JSON200).NotTo(BeNil())
+		Expect(resp.JSON200.Username).To(Equal("user-b1"))
+		Expect(resp.JSON200.Uid).To(Equal(uint32(2005)))
+		Expect(resp.JSON200.Groupname).To(Equal("group-b"))
+		Expect(resp.JSON200.Gid).To(Equal(uint32(4002)))
+		Expect(resp.JSON200.GroupHome).To(Equal("b"))
+		Expect(resp.JSON200.UserHome).To(Equal("user-b1"))
+		Expect(resp.JSON200.HomeDir).To(HaveSuffix("/b/user-b1"))
+		Expect(resp.JSON200.Locked).To(BeFalse())
+		Expect(string(resp.Body)).NotTo(ContainSubstring("password"))
+	})
+
+	It("Lookup JSON: locked users (expired, disabled) -> 404 like the header variant", func() {
+		for _, name := range []string{"user-a1", "user-a2"} {
+			resp, err := authCli.GetUserAuthzWithResponse(ctx, name)
+			Expect(err).NotTo(HaveOccurred())
+			mustStatus(resp.StatusCode(), resp.Body, http.StatusNotFound)
+
+			hdr, err := authCli.AuthzLookupUserWithResponse(ctx, name)
+			Expect(err).NotTo(HaveOccurred())
+			mustStatus(hdr.StatusCode(), hdr.Body, http.StatusNotFound)
+		}
+	})
+
+	It("Lookup JSON: unknown user -> 404", func() {
+		resp, err := authCli.GetUserAuthzWithResponse(ctx, "nobody")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusNotFound)
+	})
+
+	It("Lookup JSON: API client not authenticated (bad HMAC) -> 401", func() {
+		resp, err := badAuthCli.GetUserAuthzWithResponse(ctx, "user-b1")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusUnauthorized)
+	})
+})
+
+var _ = Describe("Authz lookup with security.return_hash_in_lookup REST E2E", Ordered, func() {
+	var (
+		ctx     = context.Background()
+		authCli *openapi.ClientWithResponses
+	)
+
+	BeforeAll(func() {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.Security.ReturnHashInLookup = true
+		})
+		DeferCleanup(s.Close)
+		authCli = newHmacClient(s.URL, apiKeyID, secretHex)
+	})
+
+	It("Lookup: returns the stored hash in X-FS-Passwd", func() {
+		resp, err := authCli.AuthzLookupUserWithResponse(ctx, "operator-a")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusNoContent)
+		Expect(resp.HTTPResponse.Header.Get("X-FS-Passwd")).To(Equal("098f6bcd4621d373cade4e832627b4f6"))
+	})
+
+	It("Lookup JSON: never carries the hash", func() {
+		resp, err := authCli.GetUserAuthzWithResponse(ctx, "operator-a")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusOK)
+		Expect(string(resp.Body)).NotTo(ContainSubstring("098f6bcd4621d373cade4e832627b4f6"))
 	})
 })
@@ -0,0 +1,112 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+)
+
+var _ = Describe("Base path REST E2E", Ordered, func() {
+	const basePath = "/fsaa/v1"
+	var (
+		ctx     = context.Background()
+		baseURL string
+	)
+
+	BeforeAll(func() {
+		s := newRoutedTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.HttpServer.BasePath = basePath
+			cfg.Security.Authenticator.AccessKeys["reader"] = config.AccessKey{Secret: secretHex, Scopes: []string{ports.ScopeUsersRead}}
+		})
+		DeferCleanup(s.Close)
+		baseURL = s.URL
+	})
+
+	get := func(path string) int {
+		resp, err := http.Get(baseURL + path)
+		Expect(err).NotTo(HaveOccurred())
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	It("serves the API under the prefix with HMAC signed over the prefixed path -> 200", func() {
+		cli := newHmacClient(baseURL+basePath, apiKeyID, secretHex)
+		code, body := listUsers(ctx, cli, nil)
+		mustStatus(code, body, http.StatusOK)
+
+		group, err := cli.GetGroupWithResponse(ctx, "default")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(group.StatusCode(), group.Body, http.StatusOK)
+	})
+
+	It("rejects a signature computed without the prefix -> 401", func() {
+		cli := newHmacClient(baseURL, apiKeyID, secretHex) // signs /api/users
+		moveUnderPrefix := func(_ context.Context, req *http.Request) error {
+			req.URL.Path = basePath + req.URL.Path
+			return nil
+		}
+		users, err := cli.ListUsersWithResponse(ctx, nil, moveUnderPrefix)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(users.StatusCode(), users.Body, http.StatusUnauthorized)
+	})
+
+	It("matches scopes against the route without the prefix", func() {
+		cli := newHmacClient(baseURL+basePath, "reader", secretHex)
+		code, body := listUsers(ctx, cli, nil)
+		mustStatus(code, body, http.StatusOK)
+
+		group, err := cli.GetGroupWithResponse(ctx, "default")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(group.StatusCode(), group.Body, http.StatusForbidden)
+	})
+
+	It("points the Location of created resources under the prefix", func() {
+		cli := newHmacClient(baseURL+basePath, apiKeyID, secretHex)
+		group, err := cli.EnsureGroupWithResponse(ctx, "located", openapi.EnsureGroupRequestBody{Gid: 4300})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(group.StatusCode(), group.Body, http.StatusCreated)
+		Expect(group.HTTPResponse.Header.Get("Location")).To(Equal(basePath + "/api/groups/located"))
+
+		user, err := cli.EnsureUserWithResponse(ctx, "located", openapi.EnsureUserRequestBody{
+			Groupname: "located", Password: ptr("Secret#123"), PasswordIsHash: ptr(false),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(user.StatusCode(), user.Body, http.StatusCreated)
+		Expect(user.HTTPResponse.Header.Get("Location")).To(Equal(basePath + "/api/users/located"))
+
+		dir, err := cli.EnsureUserDirWithResponse(ctx, "located", "data")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(dir.StatusCode(), dir.Body, http.StatusCreated)
+		Expect(dir.HTTPResponse.Header.Get("Location")).To(Equal(basePath + "/api/users/located/directories/data"))
+
+		Expect(get(group.HTTPResponse.Header.Get("Location"))).To(Equal(http.StatusUnauthorized), "the Location is routed")
+	})
+
+	It("no longer serves the API at the root -> 404", func() {
+		Expect(get("/api/health")).To(Equal(http.StatusNotFound))
+		Expect(get("/openapi.yaml")).To(Equal(http.StatusNotFound))
+	})
+
+	It("serves docs under the prefix and probes at the root", func() {
+		Expect(get(basePath + "/api/health")).To(Equal(http.StatusOK))
+		Expect(get(basePath + "/openapi.yaml")).To(Equal(http.StatusOK))
+		Expect(get(basePath + "/docs/swagger")).To(Equal(http.StatusOK))
+		Expect(get(basePath + "/")).To(Equal(http.StatusOK))
+		Expect(get("/healthz")).To(Equal(http.StatusOK))
+		Expect(get("/readyz")).To(Equal(http.StatusOK))
+	})
+
+	It("public operations of the generated client work under the prefix", func() {
+		cli, err := openapi.NewClientWithResponses(baseURL + basePath)
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := cli.HealthWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusOK)
+	})
+})
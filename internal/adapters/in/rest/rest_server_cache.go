@@ -0,0 +1,146 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheMiddleware sets Cache-Control on successful responses when http_server.get_cache_ttl is set:
+// GETs may be cached for the TTL, except within one TTL after a successful mutation (PUT/PATCH/DELETE)
+// of the same resource where they answer no-cache so that clients revalidate; mutation responses are no-store.
+// The POST actions users:batch, users:delete, {username}:touch and {username}:expire count as mutations, the other POSTs don't.
+// The no-cache windows are kept by this instance only, mutations made through other instances don't open them.
+func (s *DefaultRestServer) CacheMiddleware(next http.Handler) http.Handler {
+	ttl := s.restCfg.GetCacheTTL
+	if ttl <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var value string
+		mutation := false
+		collection, item := s.cacheKeys(r.URL.Path)
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			if s.noCache.active(collection, item) {
+				value = "no-cache"
+			} else {
+				value = fmt.Sprintf("private, max-age=%d", int(ttl/time.Second))
+			}
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+			value, mutation = "no-store", true
+		case http.MethodPost:
+			if !isMutatingPost(r.URL.Path) { // authz, crypto, resolve don't change state
+				next.ServeHTTP(w, r)
+				return
+			}
+			value, mutation = "no-store", true
+		default:
+			next.ServeHTTP(w, r)
+			return
+		}
+		cw := &cacheControlWriter{ResponseWriter: w, value: value}
+		next.ServeHTTP(cw, r)
+		if mutation && cw.status >= 200 && cw.status < 300 {
+			s.noCache.open(time.Now().Add(ttl), collection, item)
+		}
+	})
+}
+
+// cacheKeys names the resources a request path addresses: its collection ("users") and the item
+// ("users/alice") below it, "users/*" for the actions on the whole collection (users:batch, users:delete).
+func (s *DefaultRestServer) cacheKeys(path string) (collection, item string) {
+	segments := strings.SplitN(strings.TrimPrefix(strings.TrimPrefix(path, s.restCfg.BasePath), "/api/"), "/", 3)
+	collection, _, action := strings.Cut(segments[0], ":")
+	if action {
+		return collection, collection + "/*"
+	}
+	if len(segments) > 1 && segments[1] != "" {
+		name, _, _ := strings.Cut(segments[1], ":")
+		return collection, collection + "/" + name
+	}
+	return collection, ""
+}
+
+// noCacheWindows tracks until when the resources mutated lately answer no-cache.
+type noCacheWindows struct {
+	mu    sync.Mutex
+	until map[string]time.Time
+}
+
+// open starts the window of a mutated item and of its collection, whose listing changed too.
+func (n *noCacheWindows) open(until time.Time, collection, item string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.until == nil {
+		n.until = make(map[string]time.Time)
+	}
+	now := time.Now()
+	for key, end := range n.until {
+		if !now.Before(end) {
+			delete(n.until, key)
+		}
+	}
+	n.until[collection] = until
+	if item != "" {
+		n.until[item] = until
+	}
+}
+
+// active tells whether a read of the item (of the collection listing when item is empty) is in a window.
+func (n *noCacheWindows) active(collection, item string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	keys := []string{collection}
+	if item != "" {
+		keys = []string{item, collection + "/*"}
+	}
+	now := time.Now()
+	for _, key := range keys {
+		if now.Before(n.until[key]) {
+			return true
+		}
+	}
+	return false
+}
+
+// isMutation tells requests changing state: PUT, PATCH, DELETE and the mutating POST actions.
+func isMutation(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return isMutatingPost(r.URL.Path)
+	}
+	return false
+}
+
+func isMutatingPost(path string) bool {
+	return strings.HasSuffix(path, ":batch") || strings.HasSuffix(path, ":delete") || strings.HasSuffix(path, ":touch") || strings.HasSuffix(path, ":expire")
+}
+
+// cacheControlWriter adds the Cache-Control header to 2xx responses only.
+type cacheControlWriter struct {
+	http.ResponseWriter
+	value  string
+	status int
+}
+
+func (w *cacheControlWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+		if status >= 200 && status < 300 {
+			w.Header().Set("Cache-Control", w.value)
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *cacheControlWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
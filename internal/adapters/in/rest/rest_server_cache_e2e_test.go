@@ -0,0 +1,100 @@
+package rest_test
+
+import (
+	"context"
+	"fs-access-api/internal/app/config"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+)
+
+var _ = Describe("GET cache REST E2E", Ordered, func() {
+	var (
+		ctx = context.Background()
+		cli *openapi.ClientWithResponses
+	)
+
+	const user = "cached"
+
+	BeforeAll(func() {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.HttpServer.GetCacheTTL = time.Second
+		})
+		DeferCleanup(s.Close)
+		cli = newHmacClient(s.URL, apiKeyID, secretHex)
+
+		ens, err := cli.EnsureUserWithResponse(ctx, user, openapi.EnsureUserRequestBody{
+			Groupname:      "default",
+			Password:       ptr("Secr3t!Secr3t!"),
+			PasswordIsHash: ptr(false),
+			Description:    ptr("before"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ens.StatusCode(), ens.Body, http.StatusCreated)
+		Expect(ens.HTTPResponse.Header.Get("Cache-Control")).To(Equal("no-store"))
+	})
+
+	It("sends max-age on GETs once the mutation window has passed", func() {
+		Eventually(func() string {
+			get, err := cli.GetUserWithResponse(ctx, user, nil)
+			Expect(err).NotTo(HaveOccurred())
+			return get.HTTPResponse.Header.Get("Cache-Control")
+		}).WithTimeout(3 * time.Second).WithPolling(50 * time.Millisecond).Should(Equal("private, max-age=1"))
+	})
+
+	It("doesn't mark errors as cacheable", func() {
+		get, err := cli.GetUserWithResponse(ctx, "no-such-user", nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(get.StatusCode(), get.Body, http.StatusNotFound)
+		Expect(get.HTTPResponse.Header.Get("Cache-Control")).To(BeEmpty())
+	})
+
+	It("invalidates cached reads on a mutation and answers no-cache right after it", func() {
+		get, err := cli.GetUserWithResponse(ctx, user, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*get.JSON200.Description).To(Equal("before")) // now served from the server-side cache
+
+		upd, err := cli.SetUserDescriptionWithResponse(ctx, user, nil, openapi.SetDescriptionRequestBody{Description: ptr("after")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(upd.StatusCode(), upd.Body, http.StatusNoContent)
+
+		get, err = cli.GetUserWithResponse(ctx, user, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*get.JSON200.Description).To(Equal("after"))
+		Expect(get.HTTPResponse.Header.Get("Cache-Control")).To(Equal("no-cache"))
+
+		list, err := cli.ListUsers(ctx, nil) // the unparsed response, only its headers matter
+		Expect(err).NotTo(HaveOccurred())
+		defer list.Body.Close()
+		Expect(list.StatusCode).To(Equal(http.StatusOK))
+		Expect(list.Header.Get("Cache-Control")).To(Equal("no-cache"), "the listing changed too")
+	})
+
+	It("keeps the other resources cacheable after a mutation", func() {
+		upd, err := cli.SetUserDescriptionWithResponse(ctx, user, nil, openapi.SetDescriptionRequestBody{Description: ptr("again")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(upd.StatusCode(), upd.Body, http.StatusNoContent)
+
+		get, err := cli.GetUserWithResponse(ctx, "operator-a", nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(get.StatusCode(), get.Body, http.StatusOK)
+		Expect(get.HTTPResponse.Header.Get("Cache-Control")).To(Equal("private, max-age=1"))
+	})
+
+	It("answers no-cache for every user after a batch", func() {
+		res, err := cli.EnsureUsersBatchWithResponse(ctx, openapi.EnsureUsersBatchRequestBody{
+			{Username: "cached-batch", Groupname: "default", Password: ptr("Secr3t!Secr3t!"), PasswordIsHash: ptr(false)},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+
+		get, err := cli.GetUserWithResponse(ctx, "operator-a", nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(get.StatusCode(), get.Body, http.StatusOK)
+		Expect(get.HTTPResponse.Header.Get("Cache-Control")).To(Equal("no-cache"))
+	})
+})
@@ -0,0 +1,59 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+)
+
+var _ = Describe("Content-Type REST E2E", Ordered, func() {
+	const body = `{"algorithm": "raw-md5", "plaintext": "password"}`
+	var (
+		ctx        = context.Background()
+		strictCli  *openapi.ClientWithResponses
+		lenientCli *openapi.ClientWithResponses
+	)
+
+	BeforeAll(func() {
+		strict := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.HttpServer.StrictContentType = true
+		})
+		DeferCleanup(strict.Close)
+		lenient := newTestServerFromConfig(TestConfigPath)
+		DeferCleanup(lenient.Close)
+		strictCli = newHmacClient(strict.URL, apiKeyID, secretHex)
+		lenientCli = newHmacClient(lenient.URL, apiKeyID, secretHex)
+	})
+
+	DescribeTable("strict mode",
+		func(contentType string, status int) {
+			resp, err := strictCli.ComputeHashWithBodyWithResponse(ctx, contentType, strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			mustStatus(resp.StatusCode(), resp.Body, status)
+		},
+		Entry("application/json", "application/json", http.StatusOK),
+		Entry("charset=utf-8", "application/json; charset=utf-8", http.StatusOK),
+		Entry("charset=UTF-8", "application/json;charset=UTF-8", http.StatusOK),
+		Entry("other charset", "application/json; charset=latin1", http.StatusUnsupportedMediaType),
+		Entry("text/json", "text/json", http.StatusUnsupportedMediaType),
+		Entry("json suffix", "application/jsonx", http.StatusUnsupportedMediaType),
+		Entry("missing", "", http.StatusUnsupportedMediaType),
+	)
+
+	DescribeTable("lenient mode (default)",
+		func(contentType string, status int) {
+			resp, err := lenientCli.ComputeHashWithBodyWithResponse(ctx, contentType, strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			mustStatus(resp.StatusCode(), resp.Body, status)
+		},
+		Entry("application/json", "application/json", http.StatusOK),
+		Entry("other charset", "application/json; charset=latin1", http.StatusOK),
+		Entry("text/json", "text/json", http.StatusUnsupportedMediaType),
+	)
+})
@@ -3,19 +3,20 @@ package rest
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"fs-access-api/internal/adapters/in/rest/openapi" // generated
 	"fs-access-api/internal/app/ports"
 	"net/http"
 )
 
-func (s *DefaultRestServer) GenerateSecret(w http.ResponseWriter, _ *http.Request, params openapi.GenerateSecretParams) {
+func (s *DefaultRestServer) GenerateSecret(w http.ResponseWriter, r *http.Request, params openapi.GenerateSecretParams) {
 	size, secret, err := s.apis.GenerateSecret(params.Size)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		s.writeServerError(w, r, err.Error())
 		return
 	}
-	writeJSON(w, http.StatusOK, openapi.GenerateSecretResponseBody{
+	writeJSON(w, r, http.StatusOK, openapi.GenerateSecretResponseBody{
 		Hex:       hex.EncodeToString(secret),
 		SizeBytes: size,
 	})
@@ -23,7 +24,7 @@ func (s *DefaultRestServer) GenerateSecret(w http.ResponseWriter, _ *http.Reques
 }
 
 func (s *DefaultRestServer) ComputeHash(w http.ResponseWriter, r *http.Request) {
-	if !isJSON(r) {
+	if !s.isJSON(r) {
 		writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
 		return
 	}
@@ -35,29 +36,34 @@ func (s *DefaultRestServer) ComputeHash(w http.ResponseWriter, r *http.Request)
 	}
 
 	if in.Plaintext == nil {
-		writeError(w, http.StatusBadRequest, "empty plaintext")
+		writeInvalidField(w, "plaintext", "empty plaintext")
 		return
 	}
 
 	alg, err := ports.ParseHashAlgo(string(in.Algorithm))
 	if err != nil {
-		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid algorithm: '%s'", in.Algorithm))
+		writeInvalidField(w, "algorithm", fmt.Sprintf("invalid algorithm: '%s'", in.Algorithm))
+		return
 	}
 
 	hash, err := s.apis.ComputeHash(*in.Plaintext, alg, in.Rounds, in.SaltLen)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
+		writeValidationError(w, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, openapi.ComputeHashResponseBody{
+	// echo the parameters the hasher actually used, read back from the produced hash
+	rounds, saltLen := ports.CryptHashParams(hash)
+	writeJSON(w, r, http.StatusOK, openapi.ComputeHashResponseBody{
 		Algorithm: in.Algorithm,
 		Hash:      hash,
+		Rounds:    rounds,
+		SaltLen:   saltLen,
 	})
 	return
 }
 
 func (s *DefaultRestServer) VerifyHash(w http.ResponseWriter, r *http.Request) {
-	if !isJSON(r) {
+	if !s.isJSON(r) {
 		writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
 		return
 	}
@@ -68,11 +74,15 @@ func (s *DefaultRestServer) VerifyHash(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if in.Plaintext == nil {
-		writeError(w, http.StatusBadRequest, "empty plaintext password")
+		writeInvalidField(w, "plaintext", "empty plaintext password")
 		return
 	}
 
 	verified, algorithm, err := s.apis.VerifyHash(in.Hash, *in.Plaintext)
+	if errors.Is(err, ports.ErrInvalidInput) {
+		writeValidationError(w, err)
+		return
+	}
 
 	response := openapi.VerifyHashResponseBody{
 		Verified:          verified,
@@ -84,6 +94,28 @@ func (s *DefaultRestServer) VerifyHash(w http.ResponseWriter, r *http.Request) {
 		response.Error = &errMsg
 	}
 
-	writeJSON(w, http.StatusOK, response)
+	writeJSON(w, r, http.StatusOK, response)
 	return
 }
+
+func (s *DefaultRestServer) HashAudit(w http.ResponseWriter, r *http.Request) {
+	if err := s.authenticator.Verify(r); err != nil {
+		writeAuthError(w, err)
+		return
+	}
+	audit, err := s.apis.AuditPasswordHashes()
+	if err != nil {
+		s.writeServerError(w, r, "cannot audit password hashes: "+err.Error())
+		return
+	}
+	byAlgorithm := make(map[string]int, len(audit.ByAlgorithm))
+	for alg, n := range audit.ByAlgorithm {
+		byAlgorithm[string(alg)] = n
+	}
+	writeJSON(w, r, http.StatusOK, openapi.HashAuditResponseBody{
+		Total:        audit.Total,
+		ByAlgorithm:  byAlgorithm,
+		MinAlgorithm: string(audit.MinAlgorithm),
+		WeakUsers:    audit.WeakUsers,
+	})
+}
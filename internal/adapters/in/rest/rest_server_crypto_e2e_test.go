@@ -8,6 +8,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
 )
 
 var _ = Describe("Crypto REST E2E (smoke)", func() {
@@ -39,7 +41,41 @@ var _ = Describe("Crypto REST E2E (smoke)", func() {
 		Expect(res.JSON200.Hash).To(HavePrefix("$5$rounds=5000$"))
 	})
 
-	It("POST /api/hash: invalid rounds (<1000) -> 400", func() {
+	It("POST /api/hash: omitted rounds/salt -> defaults echoed", func() {
+		res, err := pub.ComputeHashWithResponse(ctx, openapi.ComputeHashRequestBody{
+			Algorithm: openapi.CryptSha512,
+			Plaintext: ptr("secret"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		Expect(res.JSON200.Rounds).To(HaveValue(Equal(5000)))
+		Expect(res.JSON200.SaltLen).To(HaveValue(Equal(16)))
+	})
+
+	It("POST /api/hash: crypt-md5 -> truncated salt, no rounds", func() {
+		res, err := pub.ComputeHashWithResponse(ctx, openapi.ComputeHashRequestBody{
+			Algorithm: openapi.CryptMd5,
+			SaltLen:   ptr(16),
+			Plaintext: ptr("secret"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		Expect(res.JSON200.Rounds).To(BeNil())
+		Expect(res.JSON200.SaltLen).To(HaveValue(Equal(8)))
+	})
+
+	It("POST /api/hash: raw digest -> no parameters", func() {
+		res, err := pub.ComputeHashWithResponse(ctx, openapi.ComputeHashRequestBody{
+			Algorithm: openapi.RawSha256,
+			Plaintext: ptr("secret"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		Expect(res.JSON200.Rounds).To(BeNil())
+		Expect(res.JSON200.SaltLen).To(BeNil())
+	})
+
+	It("POST /api/hash: invalid rounds (<1000) -> 422", func() {
 		body := openapi.ComputeHashRequestBody{
 			Algorithm: openapi.CryptSha512,
 			Rounds:    ptr(999),
@@ -48,7 +84,7 @@ var _ = Describe("Crypto REST E2E (smoke)", func() {
 		}
 		res, err := pub.ComputeHashWithResponse(ctx, body)
 		Expect(err).NotTo(HaveOccurred())
-		mustStatus(res.StatusCode(), res.Body, http.StatusBadRequest)
+		mustStatus(res.StatusCode(), res.Body, http.StatusUnprocessableEntity)
 	})
 
 	It("POST /api/verify: good and bad password", func() {
@@ -73,6 +109,16 @@ var _ = Describe("Crypto REST E2E (smoke)", func() {
 		Expect(bad.JSON200.Verified).To(BeFalse())
 	})
 
+	It("POST /api/verify: refuses an argon2id cost above the configured one -> 422", func() {
+		res, err := pub.VerifyHashWithResponse(ctx, openapi.VerifyHashRequestBody{
+			Hash:      "$argon2id$v=19$m=1048576,t=100,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+			Plaintext: ptr("password"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusUnprocessableEntity)
+		Expect(res.JSON422.Message).To(ContainSubstring("exceeds the configured"))
+	})
+
 	It("GET /api/secret: explicit size and default=32", func() {
 		r16, _ := pub.GenerateSecretWithResponse(ctx, &openapi.GenerateSecretParams{Size: ptr(16)})
 		mustStatus(r16.StatusCode(), r16.Body, http.StatusOK)
@@ -83,3 +129,51 @@ var _ = Describe("Crypto REST E2E (smoke)", func() {
 		Expect(rDef.JSON200.SizeBytes).To(Equal(32))
 	})
 })
+
+var _ = Describe("Hash audit REST E2E", Ordered, func() {
+	var (
+		ctx     = context.Background()
+		srvURL  string
+		hmacCli *openapi.ClientWithResponses
+	)
+
+	BeforeAll(func() {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.Security.Authenticator.AccessKeys["reader"] = config.AccessKey{Secret: secretHex, Scopes: []string{ports.ScopeUsersRead}}
+			cfg.Security.Authenticator.AccessKeys["operator"] = config.AccessKey{Secret: secretHex, Scopes: []string{ports.ScopeAdmin}}
+		})
+		DeferCleanup(s.Close)
+		srvURL = s.URL
+		hmacCli = newHmacClient(srvURL, apiKeyID, secretHex)
+	})
+
+	It("GET /api/admin/hash-audit reports the raw-md5 seed users as weak, without hashes", func() {
+		res, err := hmacCli.HashAuditWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		Expect(res.JSON200.MinAlgorithm).To(Equal("crypt-sha256"))
+		Expect(res.JSON200.ByAlgorithm).To(HaveKey("raw-md5"))
+		Expect(res.JSON200.WeakUsers).To(ContainElement("operator-a"))
+		Expect(string(res.Body)).NotTo(ContainSubstring("098f6bcd4621d373cade4e832627b4f6"))
+	})
+
+	It("requires the admin scope -> 403 without it", func() {
+		res, err := newHmacClient(srvURL, "reader", secretHex).HashAuditWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusForbidden)
+
+		res, err = newHmacClient(srvURL, "operator", secretHex).HashAuditWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+	})
+
+	It("leaves the public crypto routes scope-free", func() {
+		for _, keyID := range []string{"reader", "operator"} {
+			res, err := newHmacClient(srvURL, keyID, secretHex).ComputeHashWithResponse(ctx, openapi.ComputeHashRequestBody{
+				Algorithm: openapi.CryptSha256, Plaintext: ptr("secret"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		}
+	})
+})
@@ -0,0 +1,114 @@
+package rest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/adapters/out/metrics"
+	"fs-access-api/internal/app"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+)
+
+const dbInternals = "sql: no such table: fsaa_users_v2"
+
+// brokenListApis fails ListUsers, the updates and the health check the way a broken database would.
+type brokenListApis struct {
+	ports.ApiServer
+}
+
+func (brokenListApis) ListUsers() ([]ports.UserInfo, error) {
+	return nil, errors.New(dbInternals)
+}
+
+func (brokenListApis) UpdateUser(string, func(ports.UserInfo) (ports.UserInfo, error)) error {
+	return errors.New(dbInternals)
+}
+
+func (brokenListApis) UpdateGroup(string, func(ports.GroupInfo) (ports.GroupInfo, error)) error {
+	return errors.New(dbInternals)
+}
+
+func (brokenListApis) HealthCheck() error {
+	return errors.New(dbInternals)
+}
+
+func newBrokenListServer(errorDetail string) *httptest.Server {
+	cfg, _ := newTestRestServer(TestConfigPath, func(cfg *config.ProgramConfig) {
+		cfg.HttpServer.ErrorDetail = errorDetail
+	})
+	apis, err := app.BuildApiServer(context.Background(), cfg, true)
+	Expect(err).NotTo(HaveOccurred())
+	rs, err := app.BuildRestServerFor(cfg, brokenListApis{apis}, &metrics.FakeActionMetrics{})
+	Expect(err).NotTo(HaveOccurred())
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	_ = openapi.HandlerWithOptions(rs, openapi.ChiServerOptions{BaseRouter: r})
+	return httptest.NewServer(r)
+}
+
+var _ = Describe("Error detail REST E2E", func() {
+	ctx := context.Background()
+
+	It("minimal hides the database error behind the request id", func() {
+		s := newBrokenListServer(config.ErrorDetailMinimal)
+		DeferCleanup(s.Close)
+		res, err := newHmacClient(s.URL, apiKeyID, secretHex).ListUsersWithResponse(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusInternalServerError)
+		Expect(res.JSON500).NotTo(BeNil())
+		Expect(res.JSON500.Message).NotTo(ContainSubstring("no such table"))
+		Expect(res.JSON500.Message).To(MatchRegexp(`request id: \S+`))
+	})
+
+	It("minimal hides the database error of the Set* routes behind the request id", func() {
+		s := newBrokenListServer(config.ErrorDetailMinimal)
+		DeferCleanup(s.Close)
+		cli := newHmacClient(s.URL, apiKeyID, secretHex)
+
+		user, err := cli.SetUserDescriptionWithResponse(ctx, "operator-a", nil, openapi.SetDescriptionRequestBody{Description: ptr("x")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(user.StatusCode(), user.Body, http.StatusInternalServerError)
+		Expect(user.JSON500.Message).NotTo(ContainSubstring("no such table"))
+		Expect(user.JSON500.Message).To(MatchRegexp(`request id: \S+`))
+
+		group, err := cli.SetGroupDescriptionWithResponse(ctx, "group-a", nil, openapi.SetDescriptionRequestBody{Description: ptr("x")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(group.StatusCode(), group.Body, http.StatusInternalServerError)
+		Expect(group.JSON500.Message).NotTo(ContainSubstring("no such table"))
+		Expect(group.JSON500.Message).To(MatchRegexp(`request id: \S+`))
+	})
+
+	It("minimal keeps the database error out of the health status", func() {
+		s := newBrokenListServer(config.ErrorDetailMinimal)
+		DeferCleanup(s.Close)
+		res, err := newHmacClient(s.URL, apiKeyID, secretHex).HealthWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusServiceUnavailable)
+		Expect(*res.JSON503.Reason).NotTo(ContainSubstring("no such table"))
+		Expect(*res.JSON503.Reason).To(MatchRegexp(`request id: \S+`))
+	})
+
+	It("full returns the database error", func() {
+		s := newBrokenListServer(config.ErrorDetailFull)
+		DeferCleanup(s.Close)
+		res, err := newHmacClient(s.URL, apiKeyID, secretHex).ListUsersWithResponse(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusInternalServerError)
+		Expect(res.JSON500).NotTo(BeNil())
+		Expect(res.JSON500.Message).To(ContainSubstring(dbInternals))
+
+		health, err := newHmacClient(s.URL, apiKeyID, secretHex).HealthWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(health.StatusCode(), health.Body, http.StatusServiceUnavailable)
+		Expect(*health.JSON503.Reason).To(ContainSubstring(dbInternals))
+	})
+})
@@ -10,28 +10,33 @@ import (
 	"net/url"
 )
 
-func (s *DefaultRestServer) ListGroups(w http.ResponseWriter, r *http.Request) {
+func (s *DefaultRestServer) ListGroups(w http.ResponseWriter, r *http.Request, params openapi.ListGroupsParams) {
 	if err := s.authenticator.Verify(r); err != nil {
 		writeAuthError(w, err)
 		return
 	}
-	items, err := s.apis.ListGroups()
+	list := s.apis.ListGroups
+	if params.Empty != nil && *params.Empty {
+		list = s.apis.ListEmptyGroups
+	}
+	items, err := list()
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "cannot list groups: "+err.Error())
+		s.writeServerError(w, r, "cannot list groups: "+err.Error())
 		return
 	}
-	writeJSON(w, http.StatusOK, items)
+	writeJSON(w, r, http.StatusOK, items)
 	return
 }
 
 func (s *DefaultRestServer) EnsureGroup(w http.ResponseWriter, r *http.Request, name openapi.GroupnameParam) {
+	name = s.normalizeName(name)
 	// Auth
 	if err := s.authenticator.Verify(r); err != nil {
 		writeAuthError(w, err)
 		return
 	}
 	// Content-Type
-	if !isJSON(r) {
+	if !s.isJSON(r) {
 		writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
 		return
 	}
@@ -54,31 +59,39 @@ func (s *DefaultRestServer) EnsureGroup(w http.ResponseWriter, r *http.Request,
 		GID:         in.Gid,
 		Description: in.Description,
 		Home:        home,
+		QuotaBytes:  in.QuotaBytes,
 	}
 
 	_, created, err := s.apis.EnsureGroup(gReq)
 	if err != nil {
 		if errors.Is(err, ports.ErrConflict) {
-			writeJSON(w, http.StatusConflict, openapi.Conflict{
+			msg := "Group exists with different attributes"
+			if err != ports.ErrConflict { // e.g. an overlapping home, the error tells why
+				msg = err.Error()
+			}
+			writeJSON(w, r, http.StatusConflict, openapi.Conflict{
 				Code:    "GROUP_CONFLICT",
-				Message: "Group exists with different attributes",
+				Message: msg,
 			})
 			return
+		} else if errors.Is(err, ports.ErrInvalidInput) {
+			writeValidationError(w, err)
+			return
+		} else if errors.Is(err, ports.ErrUnsupportedAction) {
+			writeError(w, http.StatusNotImplemented, err.Error())
+			return
 		} else {
-			writeError(w, http.StatusInternalServerError, fmt.Sprintf("cannot ensure group: %v", err))
+			s.writeServerError(w, r, fmt.Sprintf("cannot ensure group: %v", err))
 			return
 		}
 	}
 
-	w.Header().Set("Location", fmt.Sprintf("/api/groups/%s", url.PathEscape(name)))
-	if created {
-		w.WriteHeader(http.StatusCreated)
-	} else {
-		w.WriteHeader(http.StatusOK)
-	}
+	w.Header().Set("Location", fmt.Sprintf("%s/api/groups/%s", s.restCfg.BasePath, url.PathEscape(name)))
+	s.writeEnsured(w, r, created, func() []string { return s.apis.LintGroup(gReq) })
 }
 
 func (s *DefaultRestServer) GetGroup(w http.ResponseWriter, r *http.Request, name openapi.GroupnameParam) {
+	name = s.normalizeName(name)
 	if err := s.authenticator.Verify(r); err != nil {
 		writeAuthError(w, err)
 		return
@@ -89,20 +102,21 @@ func (s *DefaultRestServer) GetGroup(w http.ResponseWriter, r *http.Request, nam
 			writeError(w, http.StatusNotFound, "group not found")
 			return
 		} else {
-			writeError(w, http.StatusInternalServerError, err.Error())
+			s.writeServerError(w, r, err.Error())
 			return
 		}
 	}
-	writeJSON(w, http.StatusOK, g)
+	writeJSON(w, r, http.StatusOK, g)
 	return
 }
 
-func (s *DefaultRestServer) SetGroupDescription(w http.ResponseWriter, r *http.Request, name openapi.GroupnameParam) {
+func (s *DefaultRestServer) SetGroupDescription(w http.ResponseWriter, r *http.Request, name openapi.GroupnameParam, params openapi.SetGroupDescriptionParams) {
+	name = s.normalizeName(name)
 	if err := s.authenticator.Verify(r); err != nil {
 		writeAuthError(w, err)
 		return
 	}
-	if !isJSON(r) {
+	if !s.isJSON(r) {
 		writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
 		return
 	}
@@ -114,16 +128,31 @@ func (s *DefaultRestServer) SetGroupDescription(w http.ResponseWriter, r *http.R
 	}
 
 	err := s.apis.UpdateGroup(name, func(group ports.GroupInfo) (ports.GroupInfo, error) {
+		if err := checkIfMatch(params.IfMatch, group.Version); err != nil {
+			return group, err
+		}
 		group.Description = in.Description
 		return group, nil
 	})
 
 	if err != nil {
-		if errors.Is(err, ports.ErrNotFound) {
+		if errors.Is(err, ports.ErrVersionMismatch) {
+			writeError(w, http.StatusPreconditionFailed, "group version mismatch")
+			return
+		} else if errors.Is(err, ports.ErrNotFound) {
 			writeError(w, http.StatusNotFound, "group not found")
 			return
+		} else if errors.Is(err, ports.ErrConflict) {
+			writeError(w, http.StatusConflict, err.Error())
+			return
+		} else if errors.Is(err, ports.ErrInvalidInput) {
+			writeValidationError(w, err)
+			return
+		} else if errors.Is(err, ports.ErrUnsupportedAction) {
+			writeError(w, http.StatusNotImplemented, err.Error())
+			return
 		} else {
-			writeError(w, http.StatusBadRequest, err.Error())
+			s.writeServerError(w, r, fmt.Sprintf("cannot update group %q: %v", name, err))
 			return
 		}
 	}
@@ -132,13 +161,26 @@ func (s *DefaultRestServer) SetGroupDescription(w http.ResponseWriter, r *http.R
 
 }
 
-func (s *DefaultRestServer) DeleteGroup(w http.ResponseWriter, r *http.Request, name openapi.GroupnameParam) {
+func (s *DefaultRestServer) DeleteGroup(w http.ResponseWriter, r *http.Request, name openapi.GroupnameParam, params openapi.DeleteGroupParams) {
+	name = s.normalizeName(name)
 	if err := s.authenticator.Verify(r); err != nil {
 		writeAuthError(w, err)
 		return
 	}
-	err := s.apis.DeleteGroup(name)
+	err := s.apis.DeleteGroup(name, params.Purge != nil && *params.Purge)
 	if err != nil {
+		if errors.Is(err, ports.ErrUnsupportedAction) {
+			writeError(w, http.StatusNotImplemented, err.Error())
+			return
+		}
+		if errors.Is(err, ports.ErrConflict) {
+			writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		if !errors.Is(err, ports.ErrNotFound) {
+			s.writeServerError(w, r, err.Error())
+			return
+		}
 		writeError(w, http.StatusNotFound, err.Error())
 		return
 	}
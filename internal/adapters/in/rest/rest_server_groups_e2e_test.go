@@ -2,6 +2,7 @@ package rest_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -44,7 +45,7 @@ var _ = Describe("Groups REST E2E (smoke)", func() {
 		Expect(get.JSON200.Gid).To(Equal(uint32(4001)))
 
 		// delete
-		del, err := cli.DeleteGroupWithResponse(ctx, group)
+		del, err := cli.DeleteGroupWithResponse(ctx, group, nil)
 		Expect(err).NotTo(HaveOccurred())
 		mustStatus(del.StatusCode(), del.Body, http.StatusNoContent, http.StatusOK)
 
@@ -53,4 +54,41 @@ var _ = Describe("Groups REST E2E (smoke)", func() {
 		Expect(err).NotTo(HaveOccurred())
 		mustStatus(get2.StatusCode(), get2.Body, http.StatusNotFound)
 	})
+
+	It("?empty=true lists only the groups without members", func() {
+		ens, err := cli.EnsureGroupWithResponse(ctx, "spare", openapi.EnsureGroupRequestBody{Gid: 4200})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ens.StatusCode(), ens.Body, http.StatusCreated)
+
+		code, body := listUsers(ctx, cli, nil)
+		mustStatus(code, body, http.StatusOK)
+		populated := map[string]bool{}
+		var list []openapi.UserInfo
+		Expect(json.Unmarshal(body, &list)).To(Succeed())
+		for _, u := range list {
+			populated[u.Groupname] = true
+		}
+		Expect(populated).To(HaveKey("group-a"))
+
+		all, err := cli.ListGroupsWithResponse(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(all.StatusCode(), all.Body, http.StatusOK)
+		var expected []string
+		for _, g := range *all.JSON200 {
+			if !populated[g.Groupname] {
+				expected = append(expected, g.Groupname)
+			}
+		}
+
+		empty, err := cli.ListGroupsWithResponse(ctx, &openapi.ListGroupsParams{Empty: ptr(true)})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(empty.StatusCode(), empty.Body, http.StatusOK)
+		var names []string
+		for _, g := range *empty.JSON200 {
+			names = append(names, g.Groupname)
+		}
+		Expect(names).To(ContainElement("spare"))
+		Expect(names).To(Equal(expected))
+		Expect(len(names)).To(BeNumerically("<", len(*all.JSON200)))
+	})
 })
@@ -0,0 +1,36 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/app/config"
+)
+
+var _ = Describe("Deleting a group with members REST E2E", func() {
+	ctx := context.Background()
+
+	DescribeTable("keeps the group -> 409",
+		func(repoType string) {
+			s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+				cfg.AccountRepository.Type = repoType
+			})
+			DeferCleanup(s.Close)
+			cli := newHmacClient(s.URL, apiKeyID, secretHex)
+
+			del, err := cli.DeleteGroupWithResponse(ctx, "group-a", nil)
+			Expect(err).NotTo(HaveOccurred())
+			mustStatus(del.StatusCode(), del.Body, http.StatusConflict)
+			Expect(string(del.Body)).To(ContainSubstring("group not empty"))
+
+			get, err := cli.GetGroupWithResponse(ctx, "group-a")
+			Expect(err).NotTo(HaveOccurred())
+			mustStatus(get.StatusCode(), get.Body, http.StatusOK)
+		},
+		Entry("sqlite", "sqlite"),
+		Entry("inmem", "inmem"),
+	)
+})
@@ -0,0 +1,65 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+)
+
+var _ = Describe("Group purge REST E2E", func() {
+	var (
+		ctx = context.Background()
+		cli *openapi.ClientWithResponses
+	)
+
+	BeforeEach(func() {
+		s := newTestServerFromConfig(TestConfigPath)
+		cli = newHmacClient(s.URL, apiKeyID, secretHex)
+		DeferCleanup(s.Close)
+	})
+
+	It("deletes the group and removes its empty home", func() {
+		ens, err := cli.EnsureGroupWithResponse(ctx, "purge-empty", openapi.EnsureGroupRequestBody{Gid: 4101})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ens.StatusCode(), ens.Body, http.StatusCreated)
+
+		del, err := cli.DeleteGroupWithResponse(ctx, "purge-empty", &openapi.DeleteGroupParams{Purge: ptr(true)})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(del.StatusCode(), del.Body, http.StatusNoContent)
+
+		get, err := cli.GetGroupWithResponse(ctx, "purge-empty")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(get.StatusCode(), get.Body, http.StatusNotFound)
+	})
+
+	It("refuses to remove a home left with a former member's files", func() {
+		ens, err := cli.EnsureGroupWithResponse(ctx, "purge-busy", openapi.EnsureGroupRequestBody{Gid: 4102})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ens.StatusCode(), ens.Body, http.StatusCreated)
+		ensU, err := cli.EnsureUserWithResponse(ctx, "purge-busy-user", openapi.EnsureUserRequestBody{
+			Groupname: "purge-busy", Home: ptr("u"), Password: ptr("Secr3t!"), PasswordIsHash: ptr(false),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ensU.StatusCode(), ensU.Body, http.StatusCreated)
+		delU, err := cli.DeleteUserWithResponse(ctx, "purge-busy-user")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(delU.StatusCode(), delU.Body, http.StatusNoContent)
+
+		del, err := cli.DeleteGroupWithResponse(ctx, "purge-busy", &openapi.DeleteGroupParams{Purge: ptr(true)})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(del.StatusCode(), del.Body, http.StatusConflict)
+		Expect(string(del.Body)).To(ContainSubstring("not empty"))
+
+		get, err := cli.GetGroupWithResponse(ctx, "purge-busy")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(get.StatusCode(), get.Body, http.StatusOK)
+
+		del, err = cli.DeleteGroupWithResponse(ctx, "purge-busy", nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(del.StatusCode(), del.Body, http.StatusNoContent)
+	})
+})
@@ -6,9 +6,11 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"fs-access-api/internal/adapters/in/rest"
 	"fs-access-api/internal/adapters/out/metrics"
 	"fs-access-api/internal/app"
 	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -47,6 +49,56 @@ func mustStatus(code int, body []byte, allowed ...int) {
 // --- Seedable server ---
 
 func newTestServerFromConfig(configPath string) *httptest.Server {
+	return newTestServerFromConfigWith(configPath, nil)
+}
+
+// newTestServerFromConfigWith lets a test adjust the loaded config before the server is built.
+func newTestServerFromConfigWith(configPath string, mutate func(cfg *config.ProgramConfig)) *httptest.Server {
+	cfg, rs := newTestRestServer(configPath, mutate)
+	return serveTestRestServer(cfg, rs)
+}
+
+// newTestServerWrappingApi serves the api server built from the config through wrap, to inject its failures.
+func newTestServerWrappingApi(configPath string, wrap func(apis ports.ApiServer) ports.ApiServer) *httptest.Server {
+	cfg := loadTestConfig(configPath, nil)
+	apis, err := app.BuildApiServer(context.Background(), cfg, true)
+	Expect(err).NotTo(HaveOccurred())
+	rs, err := app.BuildRestServerFor(cfg, wrap(apis), &metrics.FakeActionMetrics{})
+	Expect(err).NotTo(HaveOccurred())
+	return serveTestRestServer(cfg, rs)
+}
+
+func serveTestRestServer(cfg *config.ProgramConfig, rs *rest.DefaultRestServer) *httptest.Server {
+	signer, err := app.BuildResponseSigner(cfg)
+	Expect(err).NotTo(HaveOccurred())
+	r := chi.NewRouter()
+	_ = openapi.HandlerWithOptions(rs, openapi.ChiServerOptions{
+		BaseRouter: r,
+		Middlewares: []openapi.MiddlewareFunc{rs.MaintenanceMiddleware, rest.ReadOnlyKeysMiddleware(cfg.Security.ReadOnlyKeys), rest.ResponseSigningMiddleware(signer),
+			rs.AccessMiddleware, rs.CacheMiddleware},
+	})
+	return httptest.NewServer(r)
+}
+
+// newRoutedTestServerFromConfigWith serves the full application router (base path, probes, docs) like main.go does.
+func newRoutedTestServerFromConfigWith(configPath string, mutate func(cfg *config.ProgramConfig)) *httptest.Server {
+	cfg, rs := newTestRestServer(configPath, mutate)
+	signer, err := app.BuildResponseSigner(cfg)
+	Expect(err).NotTo(HaveOccurred())
+	r := app.BuildRouter(cfg.HttpServer, rs, rs.MaintenanceMiddleware, rest.ReadOnlyKeysMiddleware(cfg.Security.ReadOnlyKeys), rest.ResponseSigningMiddleware(signer),
+		rs.AccessMiddleware, rs.CacheMiddleware)
+	return httptest.NewServer(r)
+}
+
+func newTestRestServer(configPath string, mutate func(cfg *config.ProgramConfig)) (*config.ProgramConfig, *rest.DefaultRestServer) {
+	cfg := loadTestConfig(configPath, mutate)
+	rs, err := app.BuildRestServer(cfg, true, &metrics.FakeActionMetrics{})
+	Expect(err).NotTo(HaveOccurred())
+	return cfg, rs
+}
+
+// loadTestConfig loads the config with its storage and database under a fresh temp dir.
+func loadTestConfig(configPath string, mutate func(cfg *config.ProgramConfig)) *config.ProgramConfig {
 	data, err := os.ReadFile(configPath)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -60,15 +112,13 @@ func newTestServerFromConfig(configPath string) *httptest.Server {
 	cfg, err := config.LoadConfigString(dataStr)
 	Expect(err).NotTo(HaveOccurred())
 
-	err = os.MkdirAll(cfg.Storage.HomesBaseDir, 0755)
-	Expect(err).NotTo(HaveOccurred())
+	if mutate != nil {
+		mutate(cfg)
+	}
 
-	rs, err := app.BuildRestServer(cfg, true, &metrics.FakeActionMetrics{})
+	err = os.MkdirAll(cfg.Storage.HomesBaseDir, 0755)
 	Expect(err).NotTo(HaveOccurred())
-
-	r := chi.NewRouter()
-	_ = openapi.HandlerFromMux(rs, r)
-	return httptest.NewServer(r)
+	return cfg
 }
 
 // Bearer client
@@ -121,3 +171,13 @@ func newHmacClient(baseURL, apiKeyID, secretHex string) *openapi.ClientWithRespo
 	Expect(err).NotTo(HaveOccurred())
 	return cli
 }
+
+// listUsers reads the raw ListUsers answer, the generated response parser can't decode its oneOf 200.
+func listUsers(ctx context.Context, cli *openapi.ClientWithResponses, params *openapi.ListUsersParams) (int, []byte) {
+	res, err := cli.ListUsers(ctx, params)
+	Expect(err).NotTo(HaveOccurred())
+	defer func() { _ = res.Body.Close() }()
+	body, err := io.ReadAll(res.Body)
+	Expect(err).NotTo(HaveOccurred())
+	return res.StatusCode, body
+}
@@ -0,0 +1,42 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+)
+
+var _ = Describe("Home drift REST E2E", Ordered, func() {
+	var (
+		ctx    = context.Background()
+		srvURL string
+	)
+
+	BeforeAll(func() {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.Security.Authenticator.AccessKeys["reader"] = config.AccessKey{Secret: secretHex, Scopes: []string{ports.ScopeUsersRead}}
+		})
+		DeferCleanup(s.Close)
+		srvURL = s.URL
+	})
+
+	It("GET /api/admin/home-drift answers an empty report before the first check", func() {
+		res, err := newHmacClient(srvURL, apiKeyID, secretHex).GetHomeDriftWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		Expect(res.JSON200.CheckedAt).To(BeNil())
+		Expect(res.JSON200.Drifts).To(Equal([]openapi.HomeDrift{}))
+	})
+
+	It("requires the admin scope -> 403 without it", func() {
+		res, err := newHmacClient(srvURL, "reader", secretHex).GetHomeDriftWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusForbidden)
+	})
+})
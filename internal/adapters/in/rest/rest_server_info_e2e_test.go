@@ -0,0 +1,64 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+)
+
+var _ = Describe("Info REST E2E", Ordered, func() {
+	var (
+		ctx     = context.Background()
+		srvURL  string
+		hmacCli *openapi.ClientWithResponses
+	)
+
+	BeforeAll(func() {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.Security.Authenticator.AccessKeys["reader"] = config.AccessKey{Secret: secretHex, Scopes: []string{ports.ScopeUsersRead}}
+			cfg.Security.Authenticator.AccessKeys["operator"] = config.AccessKey{Secret: secretHex, Scopes: []string{ports.ScopeAdmin}}
+		})
+		DeferCleanup(s.Close)
+		srvURL = s.URL
+		hmacCli = newHmacClient(srvURL, apiKeyID, secretHex)
+	})
+
+	It("GET /api/info describes the SQLite backend and its capabilities", func() {
+		res, err := hmacCli.GetInfoWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		Expect(res.JSON200.AccountRepository).To(ContainSubstring("SQLite"))
+		Expect(res.JSON200.Capabilities).To(Equal(openapi.RepoCapabilities{SupportsTransactions: true}))
+	})
+
+	It("requires the admin scope -> 403 without it", func() {
+		res, err := newHmacClient(srvURL, "reader", secretHex).GetInfoWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusForbidden)
+		Expect(string(res.Body)).To(ContainSubstring("required scope: admin"))
+
+		res, err = newHmacClient(srvURL, "operator", secretHex).GetInfoWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+	})
+
+	It("GET /api/config/fingerprint answers the fingerprint of the effective config", func() {
+		res, err := hmacCli.GetConfigFingerprintWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		Expect(res.JSON200.Fingerprint).To(MatchRegexp(`^[0-9a-f]{64}$`))
+
+		denied, err := newHmacClient(srvURL, "reader", secretHex).GetConfigFingerprintWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(denied.StatusCode(), denied.Body, http.StatusForbidden)
+		granted, err := newHmacClient(srvURL, "operator", secretHex).GetConfigFingerprintWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(granted.StatusCode(), granted.Body, http.StatusOK)
+	})
+})
@@ -0,0 +1,49 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+)
+
+var _ = Describe("Method not allowed REST E2E", Ordered, func() {
+	var baseURL string
+
+	BeforeAll(func() {
+		s := newRoutedTestServerFromConfigWith(TestConfigPath, nil)
+		DeferCleanup(s.Close)
+		baseURL = s.URL
+	})
+
+	do := func(method, path string) *http.Response {
+		req, err := http.NewRequest(method, baseURL+path, nil)
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(resp.Body.Close)
+		return resp
+	}
+
+	DescribeTable("answers 405 with the methods registered for the route",
+		func(method, path, allow string) {
+			resp := do(method, path)
+			Expect(resp.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+			Expect(resp.Header.Values("Allow")).To(Equal([]string{allow}))
+
+			var body openapi.Error
+			Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+			Expect(body.Code).To(Equal("Method Not Allowed"))
+		},
+		Entry("a user", http.MethodPatch, "/api/users/operator-a", "GET, PUT, DELETE"),
+		Entry("the users collection", http.MethodDelete, "/api/users", "GET"),
+		Entry("a user directory", http.MethodGet, "/api/users/operator-a/directories/_test", "PUT, DELETE"),
+	)
+
+	It("still answers 404 for unknown paths", func() {
+		Expect(do(http.MethodPatch, "/api/nothing-here").StatusCode).To(Equal(http.StatusNotFound))
+	})
+})
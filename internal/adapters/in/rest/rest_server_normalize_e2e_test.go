@@ -0,0 +1,66 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+)
+
+var _ = Describe("Input normalization REST E2E", func() {
+	var ctx = context.Background()
+
+	newClient := func(normalize bool) *openapi.ClientWithResponses {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.HttpServer.NormalizeInput = normalize
+		})
+		DeferCleanup(s.Close)
+		return newHmacClient(s.URL, apiKeyID, secretHex)
+	}
+
+	It("a whitespace-padded username is stored trimmed and matches later lookups", func() {
+		cli := newClient(true)
+		resp, err := cli.EnsureUserWithResponse(ctx, " bob ", openapi.EnsureUserRequestBody{
+			Groupname: " default\t",
+			Home:      ptr("bob"),
+			Password:  ptr(" padded passphrase "),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusCreated)
+
+		for _, name := range []string{"bob", " bob", "bob.", "bob. "} {
+			got, err := cli.GetUserWithResponse(ctx, name, nil)
+			Expect(err).NotTo(HaveOccurred())
+			mustStatus(got.StatusCode(), got.Body, http.StatusOK)
+			Expect(got.JSON200.Username).To(Equal("bob"))
+			Expect(got.JSON200.Groupname).To(Equal("default"))
+		}
+
+		// the password keeps its padding
+		ok, err := cli.AuthzAuthUserWithFormdataBodyWithResponse(ctx, " bob", openapi.AuthzAuthUserFormdataRequestBody{Password: " padded passphrase "})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ok.StatusCode(), ok.Body, http.StatusNoContent)
+		bad, err := cli.AuthzAuthUserWithFormdataBodyWithResponse(ctx, "bob", openapi.AuthzAuthUserFormdataRequestBody{Password: "padded passphrase"})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(bad.StatusCode(), bad.Body, http.StatusForbidden)
+	})
+
+	It("group names in paths are normalized too", func() {
+		cli := newClient(true)
+		got, err := cli.GetGroupWithResponse(ctx, "group-a. ")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(got.StatusCode(), got.Body, http.StatusOK)
+		Expect(got.JSON200.Groupname).To(Equal("group-a"))
+	})
+
+	It("names are taken as sent when disabled (default)", func() {
+		cli := newClient(false)
+		got, err := cli.GetUserWithResponse(ctx, "operator-a ", nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(got.StatusCode(), got.Body, http.StatusNotFound)
+	})
+})
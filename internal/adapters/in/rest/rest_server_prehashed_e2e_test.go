@@ -0,0 +1,56 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+)
+
+var _ = Describe("Prehashed passwords REST E2E", func() {
+	var (
+		ctx = context.Background()
+		cli *openapi.ClientWithResponses
+	)
+
+	BeforeEach(func() {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.Security.RequirePrehashedPasswords = true
+		})
+		DeferCleanup(s.Close)
+		cli = newHmacClient(s.URL, apiKeyID, secretHex)
+	})
+
+	It("refuses a plaintext password with 400 pointing at the hash endpoint", func() {
+		res, err := cli.EnsureUserWithResponse(ctx, "mallory", openapi.EnsureUserRequestBody{Groupname: "default", Password: ptr("Corr3ct-Horse")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusBadRequest)
+		Expect(string(res.Body)).To(ContainSubstring("/api/crypto/hash"))
+
+		set, err := cli.SetUserPasswordWithResponse(ctx, "operator-a", nil, openapi.SetUserPasswordRequestBody{Password: ptr("Corr3ct-Horse")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(set.StatusCode(), set.Body, http.StatusBadRequest)
+	})
+
+	It("accepts a prehashed password", func() {
+		hash, err := cli.ComputeHashWithResponse(ctx, openapi.ComputeHashRequestBody{Algorithm: openapi.CryptSha512, Plaintext: ptr("Corr3ct-Horse")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(hash.StatusCode(), hash.Body, http.StatusOK)
+
+		res, err := cli.EnsureUserWithResponse(ctx, "mallory", openapi.EnsureUserRequestBody{
+			Groupname: "default", Password: ptr(hash.JSON200.Hash), PasswordIsHash: ptr(true),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusCreated)
+
+		set, err := cli.SetUserPasswordWithResponse(ctx, "mallory", nil, openapi.SetUserPasswordRequestBody{
+			Password: ptr(hash.JSON200.Hash), PasswordIsHash: ptr(true),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(set.StatusCode(), set.Body, http.StatusNoContent)
+	})
+})
@@ -0,0 +1,50 @@
+package rest_test
+
+import (
+	"io"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pretty JSON REST E2E", Ordered, func() {
+	var baseURL string
+
+	BeforeAll(func() {
+		s := newRoutedTestServerFromConfigWith(TestConfigPath, nil)
+		DeferCleanup(s.Close)
+		baseURL = s.URL
+	})
+
+	get := func(path string, header http.Header) string {
+		req, err := http.NewRequest(http.MethodGet, baseURL+path, nil)
+		Expect(err).NotTo(HaveOccurred())
+		for k, vs := range header {
+			req.Header[k] = vs
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return string(body)
+	}
+
+	It("stays compact by default", func() {
+		Expect(get("/api/health", nil)).To(MatchRegexp(`^\{"[^\n]*\}\n$`))
+	})
+
+	It("indents with ?pretty=true", func() {
+		Expect(get("/api/health?pretty=true", nil)).To(HavePrefix("{\n  \"banner\": "))
+	})
+
+	It("indents with X-Pretty: true", func() {
+		Expect(get("/api/health", http.Header{"X-Pretty": {"true"}})).To(HavePrefix("{\n  \""))
+	})
+
+	It("ignores values that aren't true", func() {
+		Expect(get("/api/health?pretty=no", nil)).NotTo(ContainSubstring("\n  "))
+	})
+})
@@ -0,0 +1,62 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+)
+
+var _ = Describe("Read-only keys REST E2E", Ordered, func() {
+	const monitorKeyID = "monitor"
+	var (
+		ctx        = context.Background()
+		monitorCli *openapi.ClientWithResponses
+	)
+
+	BeforeAll(func() {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.Security.Authenticator.AccessKeys[monitorKeyID] = config.AccessKey{Secret: secretHex}
+			cfg.Security.ReadOnlyKeys = []string{monitorKeyID}
+		})
+		DeferCleanup(s.Close)
+		monitorCli = newHmacClient(s.URL, monitorKeyID, secretHex)
+	})
+
+	It("read-only key can GET -> 200", func() {
+		code, body := listUsers(ctx, monitorCli, nil)
+		mustStatus(code, body, http.StatusOK)
+
+		groups, err := monitorCli.GetGroupWithResponse(ctx, "default")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(groups.StatusCode(), groups.Body, http.StatusOK)
+	})
+
+	It("read-only key can't POST -> 403", func() {
+		resp, err := monitorCli.AuthzAuthUserWithFormdataBodyWithResponse(ctx, "user-b1", openapi.AuthzAuthUserFormdataRequestBody{
+			Password: "Secr3t!",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusForbidden)
+	})
+
+	It("read-only key can't DELETE -> 403, nothing is deleted", func() {
+		resp, err := monitorCli.DeleteGroupWithResponse(ctx, "group-a", nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusForbidden)
+
+		get, err := monitorCli.GetGroupWithResponse(ctx, "group-a")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(get.StatusCode(), get.Body, http.StatusOK)
+	})
+
+	It("public operations are unaffected", func() {
+		resp, err := monitorCli.HealthWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusOK)
+	})
+})
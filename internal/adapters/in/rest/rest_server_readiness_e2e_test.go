@@ -0,0 +1,74 @@
+package rest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/out/metrics"
+	"fs-access-api/internal/app"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+)
+
+// unhealthyApis fails HealthCheck the way an unreachable database would.
+type unhealthyApis struct {
+	ports.ApiServer
+}
+
+func (unhealthyApis) HealthCheck() error {
+	return errors.New("dial tcp 10.0.0.5:3306: connect: connection refused")
+}
+
+var _ = Describe("Readiness probe REST E2E", func() {
+	get := func(path string, wrap func(ports.ApiServer) ports.ApiServer) (int, string, http.Header) {
+		cfg, _ := newTestRestServer(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.HttpServer.RetryAfter = 1500 * time.Millisecond
+		})
+		apis, err := app.BuildApiServer(context.Background(), cfg, true)
+		Expect(err).NotTo(HaveOccurred())
+		rs, err := app.BuildRestServerFor(cfg, wrap(apis), &metrics.FakeActionMetrics{})
+		Expect(err).NotTo(HaveOccurred())
+		s := httptest.NewServer(app.BuildRouter(cfg.HttpServer, rs))
+		DeferCleanup(s.Close)
+		res, err := http.Get(s.URL + path)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = res.Body.Close() }()
+		body, err := io.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return res.StatusCode, string(body), res.Header
+	}
+	readyz := func(wrap func(ports.ApiServer) ports.ApiServer) (int, string) {
+		code, body, _ := get("/readyz", wrap)
+		return code, body
+	}
+
+	It("is ready while the account repository responds", func() {
+		code, body := readyz(func(apis ports.ApiServer) ports.ApiServer { return apis })
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(MatchJSON(`{"status": "ready"}`))
+	})
+
+	It("answers 503 naming the failed dependency when the account repository doesn't respond", func() {
+		code, body := readyz(func(apis ports.ApiServer) ports.ApiServer { return unhealthyApis{apis} })
+		Expect(code).To(Equal(http.StatusServiceUnavailable))
+		Expect(body).To(MatchJSON(`{"status": "unavailable", "failed": "account_repository"}`))
+	})
+
+	It("tells the client when to retry an unavailable dependency, rounded up to whole seconds", func() {
+		unhealthy := func(apis ports.ApiServer) ports.ApiServer { return unhealthyApis{apis} }
+		for _, path := range []string{"/readyz", "/api/health"} {
+			code, _, header := get(path, unhealthy)
+			Expect(code).To(Equal(http.StatusServiceUnavailable), path)
+			Expect(header.Get("Retry-After")).To(Equal("2"), path)
+		}
+		_, _, header := get("/readyz", func(apis ports.ApiServer) ports.ApiServer { return apis })
+		Expect(header.Get("Retry-After")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,63 @@
+package rest
+
+import (
+	"bytes"
+	"fs-access-api/internal/adapters/in/rest/openapi" // generated
+	"fs-access-api/internal/app/ports"
+	"net/http"
+	"time"
+)
+
+const (
+	hdrResponseSignature = "X-Response-Signature"
+	hdrResponseTimestamp = "X-Response-Timestamp"
+)
+
+// ResponseSigningMiddleware buffers the responses to authenticated requests and signs them with the
+// signer (security.sign_responses), a nil signer disables it. It reads the key stored by AccessMiddleware,
+// so it must be registered inside it (earlier in the generated handler's middleware list).
+func ResponseSigningMiddleware(signer ports.ResponseSigner) openapi.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if signer == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if apiKeyFromContext(r.Context()) == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			bw := &bufferedResponseWriter{ResponseWriter: w}
+			next.ServeHTTP(bw, r)
+			if bw.status == 0 {
+				bw.status = http.StatusOK
+			}
+			ts := time.Now().UTC().Format(time.RFC3339)
+			if sig, ok := signer.SignResponse(r, ts, bw.body.Bytes()); ok {
+				w.Header().Set(hdrResponseTimestamp, ts)
+				w.Header().Set(hdrResponseSignature, sig)
+			}
+			w.WriteHeader(bw.status)
+			_, _ = w.Write(bw.body.Bytes())
+		})
+	}
+}
+
+// bufferedResponseWriter holds the status and body back until the response is signed.
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
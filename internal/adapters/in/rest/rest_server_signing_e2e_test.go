@@ -0,0 +1,88 @@
+package rest_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/app/config"
+)
+
+var _ = Describe("Response signing REST E2E", func() {
+	ctx := context.Background()
+
+	verify := func(res *http.Response) []byte {
+		body, err := io.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		ts := res.Header.Get("X-Response-Timestamp")
+		Expect(ts).NotTo(BeEmpty())
+		key, _ := hex.DecodeString(secretHex)
+		m := hmac.New(sha256.New, key)
+		_, _ = m.Write([]byte(ts + "\n" + sha256Hex(body)))
+		Expect(res.Header.Get("X-Response-Signature")).To(Equal(hex.EncodeToString(m.Sum(nil))))
+		return body
+	}
+
+	Context("with security.sign_responses", Ordered, func() {
+		var srvURL string
+
+		BeforeAll(func() {
+			s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+				cfg.Security.SignResponses = true
+			})
+			DeferCleanup(s.Close)
+			srvURL = s.URL
+		})
+
+		It("signs the responses to HMAC requests with the same key", func() {
+			res, err := newHmacClient(srvURL, apiKeyID, secretHex).ListUsers(ctx, nil)
+			Expect(err).NotTo(HaveOccurred())
+			defer res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			Expect(string(verify(res))).To(ContainSubstring("operator-a"))
+		})
+
+		It("signs error responses too", func() {
+			res, err := newHmacClient(srvURL, apiKeyID, secretHex).GetUser(ctx, "nobody", nil)
+			Expect(err).NotTo(HaveOccurred())
+			defer res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+			verify(res)
+		})
+
+		It("does not sign Bearer, unauthenticated or public responses", func() {
+			res, err := newBearerClient(srvURL, apiKeyID, secretHex).ListUsers(ctx, nil)
+			Expect(err).NotTo(HaveOccurred())
+			res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			Expect(res.Header.Get("X-Response-Signature")).To(BeEmpty())
+
+			res, err = newHmacClient(srvURL, apiKeyID, "00"+secretHex[2:]).ListUsers(ctx, nil)
+			Expect(err).NotTo(HaveOccurred())
+			res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusUnauthorized))
+			Expect(res.Header.Get("X-Response-Signature")).To(BeEmpty())
+
+			res, err = http.Get(srvURL + "/api/health")
+			Expect(err).NotTo(HaveOccurred())
+			res.Body.Close()
+			Expect(res.Header.Get("X-Response-Signature")).To(BeEmpty())
+		})
+	})
+
+	It("does not sign by default", func() {
+		s := newTestServerFromConfig(TestConfigPath)
+		DeferCleanup(s.Close)
+		res, err := newHmacClient(s.URL, apiKeyID, secretHex).ListUsers(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+		res.Body.Close()
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(res.Header.Get("X-Response-Signature")).To(BeEmpty())
+	})
+})
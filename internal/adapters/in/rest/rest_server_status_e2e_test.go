@@ -0,0 +1,69 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+)
+
+var _ = Describe("Status REST E2E", func() {
+	ctx := context.Background()
+
+	newClient := func(mutate func(cfg *config.ProgramConfig)) *openapi.ClientWithResponses {
+		s := newTestServerFromConfigWith(TestConfigPath, mutate)
+		DeferCleanup(s.Close)
+		return newHmacClient(s.URL, apiKeyID, secretHex)
+	}
+
+	It("reports a writable API outside maintenance", func() {
+		cli := newClient(nil)
+		st, err := cli.GetStatusWithResponse(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(st.StatusCode(), st.Body, http.StatusOK)
+		Expect(*st.JSON200).To(Equal(openapi.StatusResponseBody{Maintenance: false, ReadOnly: false, Message: ""}))
+	})
+
+	Context("with http_server.read_only", func() {
+		var cli *openapi.ClientWithResponses
+
+		BeforeEach(func() {
+			cli = newClient(func(cfg *config.ProgramConfig) {
+				cfg.HttpServer.ReadOnly = true
+				cfg.HttpServer.MaintenanceMessage = "database migration until 14:00 UTC"
+			})
+		})
+
+		It("reports the maintenance and its message", func() {
+			st, err := cli.GetStatusWithResponse(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			mustStatus(st.StatusCode(), st.Body, http.StatusOK)
+			Expect(*st.JSON200).To(Equal(openapi.StatusResponseBody{
+				Maintenance: true, ReadOnly: true, Message: "database migration until 14:00 UTC",
+			}))
+		})
+
+		It("answers mutations with 503 pointing at the status", func() {
+			ens, err := cli.EnsureGroupWithResponse(ctx, "maint-group", openapi.EnsureGroupRequestBody{Gid: 4201})
+			Expect(err).NotTo(HaveOccurred())
+			mustStatus(ens.StatusCode(), ens.Body, http.StatusServiceUnavailable)
+			Expect(string(ens.Body)).To(ContainSubstring("GET /api/status"))
+			Expect(string(ens.Body)).To(ContainSubstring("database migration until 14:00 UTC"))
+			Expect(ens.HTTPResponse.Header.Get("Retry-After")).To(Equal("5"), "the http_server.retry_after default")
+
+			touch, err := cli.TouchUserWithResponse(ctx, "alice")
+			Expect(err).NotTo(HaveOccurred())
+			mustStatus(touch.StatusCode(), touch.Body, http.StatusServiceUnavailable)
+		})
+
+		It("keeps serving reads", func() {
+			list, err := cli.ListGroupsWithResponse(ctx, nil)
+			Expect(err).NotTo(HaveOccurred())
+			mustStatus(list.StatusCode(), list.Body, http.StatusOK)
+		})
+	})
+})
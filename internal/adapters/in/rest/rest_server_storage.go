@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fs-access-api/internal/adapters/in/rest/openapi" // generated
+	"fs-access-api/internal/app/ports"
+	"net/http"
+)
+
+func (s *DefaultRestServer) ResolveHomePath(w http.ResponseWriter, r *http.Request) {
+	if err := s.authenticator.Verify(r); err != nil {
+		writeAuthError(w, err)
+		return
+	}
+	if !s.isJSON(r) {
+		writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+		return
+	}
+
+	var in openapi.ResolveHomePathRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json body")
+		return
+	}
+
+	path, err := s.apis.ResolveHomePath(in.HomesBaseDir, in.GroupHome, in.UserHome, in.TopDir)
+	out := openapi.ResolveHomePathResponseBody{Path: path, Valid: err == nil}
+	if err != nil {
+		if !errors.Is(err, ports.ErrInvalidInput) {
+			s.writeServerError(w, r, err.Error())
+			return
+		}
+		out.Reason = ptr(err.Error())
+	}
+	writeJSON(w, r, http.StatusOK, out)
+}
+
+func (s *DefaultRestServer) GetHomeDrift(w http.ResponseWriter, r *http.Request) {
+	if err := s.authenticator.Verify(r); err != nil {
+		writeAuthError(w, err)
+		return
+	}
+	report := s.apis.LastHomeDriftReport()
+	out := openapi.HomeDriftResponseBody{
+		CheckedAt:    report.CheckedAt,
+		UsersChecked: report.UsersChecked,
+		Errors:       report.Errors,
+		Drifts:       make([]openapi.HomeDrift, 0, len(report.Drifts)),
+	}
+	for _, d := range report.Drifts {
+		drift := openapi.HomeDrift{Username: d.Username, Path: d.Path, Kind: openapi.HomeDriftKind(d.Kind)}
+		if d.Kind != ports.HomeDriftMissing {
+			drift.Expected, drift.Actual = ptr(d.Expected), ptr(d.Actual)
+		}
+		out.Drifts = append(out.Drifts, drift)
+	}
+	writeJSON(w, r, http.StatusOK, out)
+}
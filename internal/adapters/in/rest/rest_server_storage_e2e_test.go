@@ -0,0 +1,59 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+)
+
+var _ = Describe("Storage REST E2E (smoke)", Ordered, func() {
+	var (
+		ctx        = context.Background()
+		authCli    *openapi.ClientWithResponses
+		badAuthCli *openapi.ClientWithResponses
+	)
+
+	BeforeAll(func() {
+		s := newTestServerFromConfig(TestConfigPath)
+		authCli = newHmacClient(s.URL, apiKeyID, secretHex)
+		badAuthCli = newHmacClient(s.URL, apiKeyID, secretHex+"0123")
+		DeferCleanup(s.Close)
+	})
+
+	It("Resolve: happy-path -> 200 + valid path", func() {
+		resp, err := authCli.ResolveHomePathWithResponse(ctx, openapi.ResolveHomePathRequestBody{
+			HomesBaseDir: ptr("/srv/homes"),
+			GroupHome:    "grpA",
+			UserHome:     "alice",
+			TopDir:       ptr("_test"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusOK)
+		Expect(resp.JSON200.Valid).To(BeTrue())
+		Expect(resp.JSON200.Path).To(Equal("/srv/homes/grpA/alice/_test"))
+		Expect(resp.JSON200.Reason).To(BeNil())
+	})
+
+	It("Resolve: escaping user home -> 200 + invalid", func() {
+		resp, err := authCli.ResolveHomePathWithResponse(ctx, openapi.ResolveHomePathRequestBody{
+			HomesBaseDir: ptr("/srv/homes"),
+			GroupHome:    "grpA",
+			UserHome:     "../../escape",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusOK)
+		Expect(resp.JSON200.Valid).To(BeFalse())
+		Expect(resp.JSON200.Path).To(Equal("/srv/escape"))
+		Expect(*resp.JSON200.Reason).To(ContainSubstring(" escapes "))
+	})
+
+	It("Resolve: not authenticated -> 401", func() {
+		resp, err := badAuthCli.ResolveHomePathWithResponse(ctx, openapi.ResolveHomePathRequestBody{GroupHome: "g", UserHome: "u"})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusUnauthorized)
+	})
+})
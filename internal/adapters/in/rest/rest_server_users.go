@@ -6,31 +6,71 @@ import (
 	"fmt"
 	"fs-access-api/internal/adapters/in/rest/openapi" // generated
 	"fs-access-api/internal/app/ports"
+	iofs "io/fs"
 	"net/http"
 	"net/url"
 	"strings"
 )
 
-func (s *DefaultRestServer) ListUsers(w http.ResponseWriter, r *http.Request) {
+func (s *DefaultRestServer) ListUsers(w http.ResponseWriter, r *http.Request, params openapi.ListUsersParams) {
 	if err := s.authenticator.Verify(r); err != nil {
 		writeAuthError(w, err)
 		return
 	}
+	if params.Offset != nil || params.Limit != nil || params.Sort != nil {
+		s.listUsersPage(w, r, params)
+		return
+	}
 	items, err := s.apis.ListUsers()
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "cannot list users: "+err.Error())
+		s.writeServerError(w, r, "cannot list users: "+err.Error())
 		return
 	}
-	writeJSON(w, http.StatusOK, items)
+	writeJSON(w, r, http.StatusOK, items)
 	return
 }
 
+// userPage is the openapi.UserPage of the domain users, Offset and Limit are the ones applied.
+type userPage struct {
+	Items  []ports.UserInfo `json:"items"`
+	Total  int              `json:"total"`
+	Offset int              `json:"offset"`
+	Limit  int              `json:"limit"`
+}
+
+// listUsersPage answers ListUsers asked for a page, a missing limit takes http_server.default_page_size.
+func (s *DefaultRestServer) listUsersPage(w http.ResponseWriter, r *http.Request, params openapi.ListUsersParams) {
+	offset, sort := 0, ""
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	if params.Sort != nil {
+		sort = *params.Sort
+	}
+	if params.Limit != nil && *params.Limit < 1 {
+		writeInvalidField(w, "limit", "limit must be at least 1")
+		return
+	}
+	limit := s.restCfg.EffectivePageSize(params.Limit)
+	items, total, err := s.apis.ListUsersPage(offset, limit, sort)
+	if err != nil {
+		if errors.Is(err, ports.ErrInvalidInput) {
+			writeValidationError(w, err)
+			return
+		}
+		s.writeServerError(w, r, "cannot list users: "+err.Error())
+		return
+	}
+	writeJSON(w, r, http.StatusOK, userPage{Items: items, Total: total, Offset: offset, Limit: limit})
+}
+
 func (s *DefaultRestServer) EnsureUser(w http.ResponseWriter, r *http.Request, name openapi.UsernameParam) {
+	name = s.normalizeName(name)
 	if err := s.authenticator.Verify(r); err != nil {
 		writeAuthError(w, err)
 		return
 	}
-	if !isJSON(r) {
+	if !s.isJSON(r) {
 		writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
 		return
 	}
@@ -41,10 +81,59 @@ func (s *DefaultRestServer) EnsureUser(w http.ResponseWriter, r *http.Request, n
 		return
 	}
 	if in.Password == nil || len(strings.TrimSpace(*in.Password)) == 0 {
-		writeError(w, http.StatusBadRequest, "password is required")
+		writeInvalidField(w, "password", "password is required")
 		return
 	}
 
+	ru := s.ensuredUser(name, in)
+	_, created, err := s.apis.EnsureUser(ru)
+	if err != nil {
+		if errors.Is(err, ports.ErrHomeTaken) {
+			writeJSON(w, r, http.StatusConflict, openapi.Conflict{
+				Code:    "HOME_CONFLICT",
+				Message: "User home resolves to the home of another user",
+			})
+			return
+		} else if errors.Is(err, ports.ErrConflict) {
+			writeJSON(w, r, http.StatusConflict, openapi.Conflict{
+				Code:    "USER_CONFLICT",
+				Message: "User exists with different attributes",
+			})
+			return
+		} else if errors.Is(err, ports.ErrUIDTaken) {
+			writeJSON(w, r, http.StatusConflict, openapi.Conflict{
+				Code:    "UID_CONFLICT",
+				Message: "User UID is already used by another user",
+			})
+			return
+		} else if errors.Is(err, ports.ErrAlreadyExists) {
+			writeJSON(w, r, http.StatusConflict, openapi.Conflict{
+				Code:    "USER_CONFLICT",
+				Message: "User was created concurrently",
+			})
+			return
+		} else if errors.Is(err, ports.ErrPlaintextPassword) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		} else if errors.Is(err, ports.ErrInvalidInput) {
+			writeValidationError(w, err)
+			return
+		} else if errors.Is(err, ports.ErrUnsupportedAction) {
+			writeError(w, http.StatusNotImplemented, err.Error())
+			return
+		} else {
+			s.writeServerError(w, r, fmt.Sprintf("cannot ensure user: %v", err))
+			return
+		}
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("%s/api/users/%s", s.restCfg.BasePath, url.PathEscape(name)))
+	s.writeEnsured(w, r, created, func() []string { return s.apis.LintUser(ru) })
+
+}
+
+// ensuredUser builds the user EnsureUser asks for, in.Password must be set.
+func (s *DefaultRestServer) ensuredUser(name string, in openapi.EnsureUserRequestBody) ports.UserInfo {
 	home := name
 	if in.Home != nil {
 		home = *in.Home
@@ -53,72 +142,163 @@ func (s *DefaultRestServer) EnsureUser(w http.ResponseWriter, r *http.Request, n
 	if in.Disabled != nil {
 		disabled = *in.Disabled
 	}
-
-	ru := ports.UserInfo{
+	return ports.UserInfo{
 		Username:       name,
 		UID:            0,
-		Groupname:      in.Groupname,
+		Groupname:      s.normalizeName(in.Groupname),
 		Password:       *in.Password,
 		PasswordIsHash: in.PasswordIsHash != nil && *in.PasswordIsHash,
 		Description:    in.Description,
+		Email:          in.Email,
 		Home:           home,
 		Expiration:     in.Expiration,
 		Disabled:       disabled,
 	}
+}
 
-	_, created, err := s.apis.EnsureUser(ru)
-	if err != nil {
-		if errors.Is(err, ports.ErrConflict) {
-			writeJSON(w, http.StatusConflict, openapi.Conflict{
-				Code:    "USER_CONFLICT",
-				Message: "User exists with different attributes",
-			})
-			return
-		} else {
-			writeError(w, http.StatusInternalServerError, fmt.Sprintf("cannot ensure user: %v", err))
-			return
+// EnsureUsersBatch ensures the users one by one like EnsureUser, a failed item doesn't stop the batch
+// nor undo the ones before it: each result carries the status EnsureUser would have answered.
+func (s *DefaultRestServer) EnsureUsersBatch(w http.ResponseWriter, r *http.Request) {
+	if err := s.authenticator.Verify(r); err != nil {
+		writeAuthError(w, err)
+		return
+	}
+	if !s.isJSON(r) {
+		writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+		return
+	}
+
+	var in openapi.EnsureUsersBatchRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json body")
+		return
+	}
+	if len(in) > s.restCfg.MaxBatchSize {
+		msg := fmt.Sprintf("batch of %d users exceeds http_server.max_batch_size (%d)", len(in), s.restCfg.MaxBatchSize)
+		writeValidation(w, msg, openapi.FieldError{Reason: msg})
+		return
+	}
+
+	results := make(openapi.EnsureUsersBatchResponseBody, 0, len(in))
+	var failures []openapi.FieldError
+	serverFailure := ""
+	for i, item := range in {
+		res := s.ensureBatchItem(r, item)
+		results = append(results, res)
+		if res.Error != nil {
+			failures = append(failures, openapi.FieldError{Field: ptr(fmt.Sprintf("[%d]", i)), Reason: fmt.Sprintf("user %q: %s", res.Username, *res.Error)})
+			if res.Status >= http.StatusInternalServerError && serverFailure == "" {
+				serverFailure = *res.Error
+			}
 		}
 	}
+	// 200 when every item succeeded, 207 when only some did; when none did, 500 if an item met a
+	// server error (systemic failure), 422 listing the item failures otherwise
+	switch {
+	case len(failures) == 0:
+		writeJSON(w, r, http.StatusOK, results)
+	case len(failures) < len(in):
+		writeJSON(w, r, http.StatusMultiStatus, results)
+	case serverFailure != "":
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("all %d users of the batch failed: %s", len(in), serverFailure))
+	default:
+		writeValidation(w, fmt.Sprintf("all %d users of the batch failed", len(in)), failures...)
+	}
+}
 
-	w.Header().Set("Location", fmt.Sprintf("/api/users/%s", url.PathEscape(name)))
-	if created {
-		w.WriteHeader(http.StatusCreated)
-	} else {
-		w.WriteHeader(http.StatusOK)
+func (s *DefaultRestServer) ensureBatchItem(r *http.Request, item openapi.EnsureUsersBatchItem) openapi.EnsureUsersBatchResult {
+	name := s.normalizeName(item.Username)
+	failed := func(status int, msg string) openapi.EnsureUsersBatchResult {
+		return openapi.EnsureUsersBatchResult{Username: name, Status: status, Error: ptr(msg)}
+	}
+	if item.Password == nil || len(strings.TrimSpace(*item.Password)) == 0 {
+		return failed(http.StatusUnprocessableEntity, "password is required")
 	}
 
+	_, created, err := s.apis.EnsureUser(s.ensuredUser(name, openapi.EnsureUserRequestBody{
+		Description:    item.Description,
+		Disabled:       item.Disabled,
+		Email:          item.Email,
+		Expiration:     item.Expiration,
+		Groupname:      item.Groupname,
+		Home:           item.Home,
+		Password:       item.Password,
+		PasswordIsHash: item.PasswordIsHash,
+	}))
+	switch {
+	case err == nil && created:
+		return openapi.EnsureUsersBatchResult{Username: name, Status: http.StatusCreated, Created: true}
+	case err == nil:
+		return openapi.EnsureUsersBatchResult{Username: name, Status: http.StatusOK}
+	case errors.Is(err, ports.ErrHomeTaken):
+		return failed(http.StatusConflict, "User home resolves to the home of another user")
+	case errors.Is(err, ports.ErrConflict):
+		return failed(http.StatusConflict, "User exists with different attributes")
+	case errors.Is(err, ports.ErrUIDTaken):
+		return failed(http.StatusConflict, "User UID is already used by another user")
+	case errors.Is(err, ports.ErrAlreadyExists):
+		return failed(http.StatusConflict, "User was created concurrently")
+	case errors.Is(err, ports.ErrPlaintextPassword):
+		return failed(http.StatusBadRequest, err.Error())
+	case errors.Is(err, ports.ErrInvalidInput):
+		return failed(http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, ports.ErrUnsupportedAction):
+		return failed(http.StatusNotImplemented, err.Error())
+	default:
+		return failed(http.StatusInternalServerError, s.serverErrorMessage(r, fmt.Sprintf("cannot ensure user %q: %v", name, err)))
+	}
+}
+
+// userResponse is the GetUser body, embedding the primary group on ?expand=group.
+type userResponse struct {
+	ports.UserInfo
+	Group *ports.GroupInfo `json:"group,omitempty"`
 }
 
-func (s *DefaultRestServer) GetUser(w http.ResponseWriter, r *http.Request, name openapi.UsernameParam) {
+func (s *DefaultRestServer) GetUser(w http.ResponseWriter, r *http.Request, name openapi.UsernameParam, params openapi.GetUserParams) {
+	name = s.normalizeName(name)
 	if err := s.authenticator.Verify(r); err != nil {
 		writeAuthError(w, err)
 		return
 	}
+	if params.Expand != nil && *params.Expand != openapi.Group {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("cannot expand %q, only %q is supported", *params.Expand, openapi.Group))
+		return
+	}
 	u, err := s.apis.GetUser(name)
 	if err != nil {
 		if errors.Is(err, ports.ErrNotFound) {
 			writeError(w, http.StatusNotFound, "user not found")
 			return
 		} else {
-			writeError(w, http.StatusInternalServerError, err.Error())
+			s.writeServerError(w, r, err.Error())
+			return
+		}
+	}
+	out := userResponse{UserInfo: u}
+	if params.Expand != nil {
+		g, err := s.apis.GetGroup(u.Groupname)
+		if err != nil {
+			s.writeServerError(w, r, fmt.Sprintf("cannot read group %q of user %q: %v", u.Groupname, u.Username, err))
 			return
 		}
+		out.Group = &g
 	}
-	writeJSON(w, http.StatusOK, u)
+	writeJSON(w, r, http.StatusOK, out)
 	return
 }
 
-func (s *DefaultRestServer) SetUserDescription(w http.ResponseWriter, r *http.Request, name openapi.UsernameParam) {
-	handleUserAttributesUpdate[openapi.SetDescriptionRequestBody](s, w, r, name, func(u ports.UserInfo, in openapi.SetDescriptionRequestBody) (ports.UserInfo, error) {
+func (s *DefaultRestServer) SetUserDescription(w http.ResponseWriter, r *http.Request, name openapi.UsernameParam, params openapi.SetUserDescriptionParams) {
+	handleUserAttributesUpdate[openapi.SetDescriptionRequestBody](s, w, r, name, params.IfMatch, func(u ports.UserInfo, in openapi.SetDescriptionRequestBody) (ports.UserInfo, error) {
 		u.Description = in.Description
 		return u, nil
 	})
 }
 
-func (s *DefaultRestServer) SetUserPassword(w http.ResponseWriter, r *http.Request, name openapi.UsernameParam) {
-	handleUserAttributesUpdate[openapi.SetUserPasswordRequestBody](s, w, r, name, func(u ports.UserInfo, in openapi.SetUserPasswordRequestBody) (ports.UserInfo, error) {
+func (s *DefaultRestServer) SetUserPassword(w http.ResponseWriter, r *http.Request, name openapi.UsernameParam, params openapi.SetUserPasswordParams) {
+	handleUserAttributesUpdate[openapi.SetUserPasswordRequestBody](s, w, r, name, params.IfMatch, func(u ports.UserInfo, in openapi.SetUserPasswordRequestBody) (ports.UserInfo, error) {
 		if in.Password == nil || len(strings.TrimSpace(*in.Password)) == 0 {
-			return u, fmt.Errorf("password is required")
+			return u, ports.InvalidField("password", "password is required")
 		}
 		u.Password = *in.Password
 		u.PasswordIsHash = in.PasswordIsHash != nil && *in.PasswordIsHash
@@ -126,21 +306,29 @@ func (s *DefaultRestServer) SetUserPassword(w http.ResponseWriter, r *http.Reque
 	})
 }
 
-func (s *DefaultRestServer) SetUserExpiration(w http.ResponseWriter, r *http.Request, name openapi.UsernameParam) {
-	handleUserAttributesUpdate[openapi.SetUserExpirationRequestBody](s, w, r, name, func(u ports.UserInfo, in openapi.SetUserExpirationRequestBody) (ports.UserInfo, error) {
+func (s *DefaultRestServer) SetUserEmail(w http.ResponseWriter, r *http.Request, name openapi.UsernameParam, params openapi.SetUserEmailParams) {
+	handleUserAttributesUpdate[openapi.SetUserEmailRequestBody](s, w, r, name, params.IfMatch, func(u ports.UserInfo, in openapi.SetUserEmailRequestBody) (ports.UserInfo, error) {
+		u.Email = in.Email
+		return u, nil
+	})
+}
+
+func (s *DefaultRestServer) SetUserExpiration(w http.ResponseWriter, r *http.Request, name openapi.UsernameParam, params openapi.SetUserExpirationParams) {
+	handleUserAttributesUpdate[openapi.SetUserExpirationRequestBody](s, w, r, name, params.IfMatch, func(u ports.UserInfo, in openapi.SetUserExpirationRequestBody) (ports.UserInfo, error) {
 		u.Expiration = in.Expiration
 		return u, nil
 	})
 }
 
-func (s *DefaultRestServer) SetUserDisabled(w http.ResponseWriter, r *http.Request, name openapi.UsernameParam) {
-	handleUserAttributesUpdate[openapi.SetUserDisabledRequestBody](s, w, r, name, func(u ports.UserInfo, in openapi.SetUserDisabledRequestBody) (ports.UserInfo, error) {
+func (s *DefaultRestServer) SetUserDisabled(w http.ResponseWriter, r *http.Request, name openapi.UsernameParam, params openapi.SetUserDisabledParams) {
+	handleUserAttributesUpdate[openapi.SetUserDisabledRequestBody](s, w, r, name, params.IfMatch, func(u ports.UserInfo, in openapi.SetUserDisabledRequestBody) (ports.UserInfo, error) {
 		u.Disabled = in.Disabled
 		return u, nil
 	})
 }
 
 func (s *DefaultRestServer) DeleteUser(w http.ResponseWriter, r *http.Request, name openapi.UsernameParam) {
+	name = s.normalizeName(name)
 	if err := s.authenticator.Verify(r); err != nil {
 		writeAuthError(w, err)
 		return
@@ -153,7 +341,11 @@ func (s *DefaultRestServer) DeleteUser(w http.ResponseWriter, r *http.Request, n
 			writeError(w, http.StatusNotFound, "user not found")
 			return
 		}
-		writeError(w, http.StatusInternalServerError, err.Error())
+		if errors.Is(err, ports.ErrUnsupportedAction) {
+			writeError(w, http.StatusNotImplemented, err.Error())
+			return
+		}
+		s.writeServerError(w, r, err.Error())
 		return
 	}
 
@@ -161,24 +353,176 @@ func (s *DefaultRestServer) DeleteUser(w http.ResponseWriter, r *http.Request, n
 	return
 }
 
-func (s *DefaultRestServer) ListUserDirs(w http.ResponseWriter, r *http.Request, username openapi.UsernameParam) {
+func (s *DefaultRestServer) TouchUser(w http.ResponseWriter, r *http.Request, name openapi.UsernameParam) {
+	name = s.normalizeName(name)
+	if err := s.authenticator.Verify(r); err != nil {
+		writeAuthError(w, err)
+		return
+	}
+	u, err := s.apis.TouchUser(name)
+	if err != nil {
+		switch {
+		case errors.Is(err, ports.ErrNotFound):
+			writeError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, ports.ErrInvalidInput):
+			writeValidationError(w, err)
+		case errors.Is(err, ports.ErrUnsupportedAction):
+			writeError(w, http.StatusNotImplemented, err.Error())
+		default:
+			s.writeServerError(w, r, "cannot touch user: "+err.Error())
+		}
+		return
+	}
+	writeJSON(w, r, http.StatusOK, u)
+}
+
+func (s *DefaultRestServer) ExpireUser(w http.ResponseWriter, r *http.Request, name openapi.UsernameParam) {
+	name = s.normalizeName(name)
+	if err := s.authenticator.Verify(r); err != nil {
+		writeAuthError(w, err)
+		return
+	}
+	if err := s.apis.ExpireUser(name); err != nil {
+		switch {
+		case errors.Is(err, ports.ErrVersionMismatch):
+			writeError(w, http.StatusPreconditionFailed, "user version mismatch")
+		case errors.Is(err, ports.ErrNotFound):
+			writeError(w, http.StatusNotFound, "user not found")
+		case errors.Is(err, ports.ErrUnsupportedAction):
+			writeError(w, http.StatusNotImplemented, err.Error())
+		default:
+			s.writeServerError(w, r, "cannot expire user: "+err.Error())
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *DefaultRestServer) DeleteUsers(w http.ResponseWriter, r *http.Request) {
+	if err := s.authenticator.Verify(r); err != nil {
+		writeAuthError(w, err)
+		return
+	}
+	if !s.isJSON(r) {
+		writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+		return
+	}
+
+	var in openapi.DeleteUsersRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json body")
+		return
+	}
+	if !in.Confirm {
+		writeInvalidField(w, "confirm", "confirm must be true")
+		return
+	}
+	filter := ports.UserFilter{Groupname: in.Groupname, ExpiredBefore: in.ExpiredBefore}
+	if filter.Groupname != nil {
+		filter.Groupname = ptr(s.normalizeName(*filter.Groupname))
+	}
+	if in.Usernames != nil {
+		for _, u := range *in.Usernames {
+			filter.Usernames = append(filter.Usernames, s.normalizeName(u))
+		}
+	}
+
+	deleted, affected, purgeFailed, err := s.apis.DeleteUsers(filter, in.Purge != nil && *in.Purge)
+	if err != nil {
+		switch {
+		case errors.Is(err, ports.ErrInvalidInput):
+			writeValidationError(w, err)
+		case errors.Is(err, ports.ErrConflict):
+			writeError(w, http.StatusConflict, err.Error())
+		case errors.Is(err, ports.ErrUnsupportedAction):
+			writeError(w, http.StatusNotImplemented, err.Error())
+		default:
+			s.writeServerError(w, r, err.Error())
+		}
+		return
+	}
+	writeJSON(w, r, http.StatusOK, openapi.DeleteUsersResponseBody{
+		Count:       affected,
+		Deleted:     deleted,
+		PurgeFailed: purgeFailed,
+	})
+}
+
+func (s *DefaultRestServer) ListUserDirs(w http.ResponseWriter, r *http.Request, username openapi.UsernameParam, params openapi.ListUserDirsParams) {
+	username = s.normalizeName(username)
 	if err := s.authenticator.Verify(r); err != nil {
 		writeAuthError(w, err)
 		return
 	}
-	dirs, err := s.apis.ListUserDirs(username)
+	if params.Detail != nil && *params.Detail {
+		s.listUserDirsDetailed(w, r, username)
+		return
+	}
+	dirs, truncated, err := s.apis.ListUserDirs(username)
 	if err != nil {
 		if errors.Is(err, ports.ErrNotFound) {
 			writeError(w, http.StatusNotFound, "user not found")
 			return
 		}
-		writeError(w, http.StatusInternalServerError, err.Error())
+		s.writeServerError(w, r, err.Error())
 		return
 	}
-	writeJSON(w, http.StatusOK, dirs)
+	setTruncated(w, truncated)
+	writeJSON(w, r, http.StatusOK, dirs)
+}
+
+// setTruncated flags a listing cut at storage.max_dir_entries with the X-Truncated header.
+func setTruncated(w http.ResponseWriter, truncated bool) {
+	if truncated {
+		w.Header().Set("X-Truncated", "true")
+	}
+}
+
+func (s *DefaultRestServer) listUserDirsDetailed(w http.ResponseWriter, r *http.Request, username string) {
+	dirs, truncated, err := s.apis.ListUserDirsDetailed(username)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		s.writeServerError(w, r, err.Error())
+		return
+	}
+	out := make([]openapi.DirInfo, 0, len(dirs))
+	for _, d := range dirs {
+		di := openapi.DirInfo{
+			Name: d.Name,
+			Mode: fmt.Sprintf("%o", octalMode(d.Mode)),
+			Uid:  d.UID,
+			Gid:  d.GID,
+		}
+		if !d.ModTime.IsZero() {
+			di.ModTime = ptr(d.ModTime.UTC())
+		}
+		out = append(out, di)
+	}
+	setTruncated(w, truncated)
+	writeJSON(w, r, http.StatusOK, out)
+}
+
+// octalMode renders the permission bits the chmod(1) way, mapping Go's setuid/setgid/sticky flags
+// (filesystems may also keep them as raw 07000 bits).
+func octalMode(mode iofs.FileMode) uint32 {
+	octal := uint32(mode & 0o7777)
+	if mode&iofs.ModeSetuid != 0 {
+		octal |= 0o4000
+	}
+	if mode&iofs.ModeSetgid != 0 {
+		octal |= 0o2000
+	}
+	if mode&iofs.ModeSticky != 0 {
+		octal |= 0o1000
+	}
+	return octal
 }
 
 func (s *DefaultRestServer) DeleteUserDir(w http.ResponseWriter, r *http.Request, username openapi.UsernameParam, dirname openapi.DirnameParam) {
+	username = s.normalizeName(username)
 	if err := s.authenticator.Verify(r); err != nil {
 		writeAuthError(w, err)
 		return
@@ -189,7 +533,7 @@ func (s *DefaultRestServer) DeleteUserDir(w http.ResponseWriter, r *http.Request
 			writeError(w, http.StatusNotFound, "user or directory not found")
 			return
 		}
-		writeError(w, http.StatusInternalServerError, err.Error())
+		s.writeServerError(w, r, err.Error())
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -197,6 +541,7 @@ func (s *DefaultRestServer) DeleteUserDir(w http.ResponseWriter, r *http.Request
 }
 
 func (s *DefaultRestServer) EnsureUserDir(w http.ResponseWriter, r *http.Request, username openapi.UsernameParam, dirname openapi.DirnameParam) {
+	username = s.normalizeName(username)
 	if err := s.authenticator.Verify(r); err != nil {
 		writeAuthError(w, err)
 		return
@@ -207,11 +552,19 @@ func (s *DefaultRestServer) EnsureUserDir(w http.ResponseWriter, r *http.Request
 			writeError(w, http.StatusNotFound, "user not found")
 			return
 		}
-		writeError(w, http.StatusInternalServerError, err.Error())
+		if errors.Is(err, ports.ErrInvalidInput) {
+			writeValidationError(w, err)
+			return
+		}
+		if errors.Is(err, ports.ErrQuotaExceeded) || errors.Is(err, ports.ErrInsufficientStorage) {
+			writeError(w, http.StatusInsufficientStorage, err.Error())
+			return
+		}
+		s.writeServerError(w, r, err.Error())
 		return
 	}
 
-	w.Header().Set("Location", fmt.Sprintf("/api/users/%s/directories/%s", url.PathEscape(username), url.PathEscape(dirname)))
+	w.Header().Set("Location", fmt.Sprintf("%s/api/users/%s/directories/%s", s.restCfg.BasePath, url.PathEscape(username), url.PathEscape(dirname)))
 	if created {
 		w.WriteHeader(http.StatusCreated)
 	} else {
@@ -219,12 +572,49 @@ func (s *DefaultRestServer) EnsureUserDir(w http.ResponseWriter, r *http.Request
 	}
 }
 
-func handleUserAttributesUpdate[T any](s *DefaultRestServer, w http.ResponseWriter, r *http.Request, name string, mutate func(u ports.UserInfo, in T) (ports.UserInfo, error)) {
+func (s *DefaultRestServer) GetUserEffectivePolicy(w http.ResponseWriter, r *http.Request, username openapi.UsernameParam) {
+	username = s.normalizeName(username)
 	if err := s.authenticator.Verify(r); err != nil {
 		writeAuthError(w, err)
 		return
 	}
-	if !isJSON(r) {
+	p, err := s.apis.EffectiveUserPolicy(username)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		s.writeServerError(w, r, err.Error())
+		return
+	}
+	out := openapi.EffectiveUserPolicy{
+		Username:              p.Username,
+		Groupname:             p.Groupname,
+		HomeDir:               p.HomeDir,
+		TopDirs:               make([]openapi.EffectiveTopDir, 0, len(p.TopDirs)),
+		GroupQuotaBytes:       p.GroupQuotaBytes,
+		GroupQuotaEnforced:    p.GroupQuotaEnforced,
+		Locked:                p.Locked,
+		Expiration:            p.Expiration,
+		ExpirationGracePeriod: p.ExpirationGracePeriod.String(),
+		PasswordPolicy: openapi.EffectivePasswordPolicy{
+			ForbidUsernameInPassword: p.ForbidUsernameInPassword,
+			DefaultAlgorithm:         p.PasswordAlgorithm,
+		},
+	}
+	for _, d := range p.TopDirs {
+		out.TopDirs = append(out.TopDirs, openapi.EffectiveTopDir{Name: d.Name, Mode: fmt.Sprintf("%o", octalMode(d.Mode))})
+	}
+	writeJSON(w, r, http.StatusOK, out)
+}
+
+func handleUserAttributesUpdate[T any](s *DefaultRestServer, w http.ResponseWriter, r *http.Request, name string, ifMatch *string, mutate func(u ports.UserInfo, in T) (ports.UserInfo, error)) {
+	name = s.normalizeName(name)
+	if err := s.authenticator.Verify(r); err != nil {
+		writeAuthError(w, err)
+		return
+	}
+	if !s.isJSON(r) {
 		writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
 		return
 	}
@@ -235,15 +625,30 @@ func handleUserAttributesUpdate[T any](s *DefaultRestServer, w http.ResponseWrit
 		return
 	}
 	err := s.apis.UpdateUser(name, func(u ports.UserInfo) (ports.UserInfo, error) {
+		if err := checkIfMatch(ifMatch, u.Version); err != nil {
+			return u, err
+		}
 		return mutate(u, in)
 	})
 	if err != nil {
-		if errors.Is(err, ports.ErrNotFound) {
+		if errors.Is(err, ports.ErrVersionMismatch) {
+			writeError(w, http.StatusPreconditionFailed, "user version mismatch")
+			return
+		} else if errors.Is(err, ports.ErrNotFound) {
 			writeError(w, http.StatusNotFound, "user not found")
 			return
-		} else {
+		} else if errors.Is(err, ports.ErrPlaintextPassword) {
 			writeError(w, http.StatusBadRequest, err.Error())
 			return
+		} else if errors.Is(err, ports.ErrInvalidInput) {
+			writeValidationError(w, err)
+			return
+		} else if errors.Is(err, ports.ErrUnsupportedAction) {
+			writeError(w, http.StatusNotImplemented, err.Error())
+			return
+		} else {
+			s.writeServerError(w, r, fmt.Sprintf("cannot update user %q: %v", name, err))
+			return
 		}
 	}
 
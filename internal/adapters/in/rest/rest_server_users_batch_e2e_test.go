@@ -0,0 +1,176 @@
+package rest_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+)
+
+var _ = Describe("Batch user import REST E2E", func() {
+	ctx := context.Background()
+
+	newClient := func() *openapi.ClientWithResponses {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.HttpServer.MaxBatchSize = 3
+		})
+		DeferCleanup(s.Close)
+		return newHmacClient(s.URL, apiKeyID, secretHex)
+	}
+	item := func(username, groupname string, password *string) openapi.EnsureUsersBatchItem {
+		return openapi.EnsureUsersBatchItem{Username: username, Groupname: groupname, Password: password, PasswordIsHash: ptr(false)}
+	}
+
+	It("ensures every item and reports each failure without stopping -> 207", func() {
+		hmacCli := newClient()
+		res, err := hmacCli.EnsureUsersBatchWithResponse(ctx, openapi.EnsureUsersBatchRequestBody{
+			item("user-new", "group-a", ptr("Secret#123")),
+			item("user-nopass", "group-a", nil),
+			item("user-new2", "group-a", ptr("Secret#123")),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusMultiStatus)
+		Expect(*res.JSON207).To(HaveLen(3))
+
+		results := *res.JSON207
+		Expect(results[0]).To(Equal(openapi.EnsureUsersBatchResult{Username: "user-new", Status: http.StatusCreated, Created: true}))
+		Expect(results[1].Status).To(Equal(http.StatusUnprocessableEntity))
+		Expect(*results[1].Error).To(ContainSubstring("password is required"))
+		Expect(results[2].Status).To(Equal(http.StatusCreated))
+
+		get, err := hmacCli.GetUserWithResponse(ctx, "user-new2", nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(get.StatusCode(), get.Body, http.StatusOK)
+	})
+
+	It("reports the status EnsureUser would answer for existing users", func() {
+		hmacCli := newClient()
+		batch := openapi.EnsureUsersBatchRequestBody{item("user-twice", "group-a", ptr("Secret#123"))}
+		res, err := hmacCli.EnsureUsersBatchWithResponse(ctx, batch)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		Expect((*res.JSON200)[0].Status).To(Equal(http.StatusCreated))
+
+		batch = append(batch, item("user-twice", "group-b", ptr("Secret#123")))
+		res, err = hmacCli.EnsureUsersBatchWithResponse(ctx, batch)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusMultiStatus)
+		Expect((*res.JSON207)[0]).To(Equal(openapi.EnsureUsersBatchResult{Username: "user-twice", Status: http.StatusOK}))
+		Expect((*res.JSON207)[1].Status).To(Equal(http.StatusConflict))
+		Expect((*res.JSON207)[1].Created).To(BeFalse())
+	})
+
+	It("answers 200 when every item succeeded", func() {
+		hmacCli := newClient()
+		res, err := hmacCli.EnsureUsersBatchWithResponse(ctx, openapi.EnsureUsersBatchRequestBody{
+			item("user-ok1", "group-a", ptr("Secret#123")),
+			item("user-ok2", "group-a", ptr("Secret#123")),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		Expect(*res.JSON200).To(HaveEach(HaveField("Status", http.StatusCreated)))
+	})
+
+	It("answers 422 listing the failures when every item failed", func() {
+		hmacCli := newClient()
+		res, err := hmacCli.EnsureUsersBatchWithResponse(ctx, openapi.EnsureUsersBatchRequestBody{
+			item("user-nopass", "group-a", nil),
+			item("operator-a", "group-b", ptr("Secret#123")),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusUnprocessableEntity)
+		Expect(res.JSON422.Message).To(Equal("all 2 users of the batch failed"))
+		Expect(res.JSON422.Errors).To(HaveLen(2))
+		Expect(*res.JSON422.Errors[0].Field).To(Equal("[0]"))
+		Expect(res.JSON422.Errors[0].Reason).To(ContainSubstring(`user "user-nopass": password is required`))
+		Expect(*res.JSON422.Errors[1].Field).To(Equal("[1]"))
+	})
+
+	It("refuses a batch over http_server.max_batch_size -> 422", func() {
+		hmacCli := newClient()
+		batch := openapi.EnsureUsersBatchRequestBody{}
+		for _, name := range []string{"u1", "u2", "u3", "u4"} {
+			batch = append(batch, item(name, "group-a", ptr("Secret#123")))
+		}
+		res, err := hmacCli.EnsureUsersBatchWithResponse(ctx, batch)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusUnprocessableEntity)
+		Expect(res.JSON422.Message).To(ContainSubstring("max_batch_size (3)"))
+
+		get, err := hmacCli.GetUserWithResponse(ctx, "u1", nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(get.StatusCode(), get.Body, http.StatusNotFound)
+	})
+})
+
+// uidRaceApi fails every user creation like a UID taken concurrently by another instance.
+type uidRaceApi struct{ ports.ApiServer }
+
+func (uidRaceApi) EnsureUser(user ports.UserInfo) (ports.UserInfo, bool, error) {
+	return ports.UserInfo{}, false, fmt.Errorf("%w: UID 2100", ports.ErrUIDTaken)
+}
+
+var _ = Describe("UID collisions REST E2E", func() {
+	ctx := context.Background()
+
+	It("answers 409 with a UID specific message, alone and in a batch", func() {
+		s := newTestServerWrappingApi(TestConfigPath, func(apis ports.ApiServer) ports.ApiServer { return uidRaceApi{apis} })
+		DeferCleanup(s.Close)
+		hmacCli := newHmacClient(s.URL, apiKeyID, secretHex)
+
+		ens, err := hmacCli.EnsureUserWithResponse(ctx, "raced", openapi.EnsureUserRequestBody{
+			Groupname: "group-a", Password: ptr("Secret#123"), PasswordIsHash: ptr(false),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ens.StatusCode(), ens.Body, http.StatusConflict)
+		Expect(ens.JSON409.Code).To(Equal("UID_CONFLICT"))
+		Expect(ens.JSON409.Message).To(ContainSubstring("UID"))
+
+		res, err := hmacCli.EnsureUsersBatchWithResponse(ctx, openapi.EnsureUsersBatchRequestBody{
+			{Username: "raced", Groupname: "group-a", Password: ptr("Secret#123"), PasswordIsHash: ptr(false)},
+			{Username: "raced-nopass", Groupname: "group-a"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusUnprocessableEntity)
+		Expect(res.JSON422.Errors[0].Reason).To(ContainSubstring("UID"))
+	})
+})
+
+var _ = Describe("Globally unique homes REST E2E", func() {
+	ctx := context.Background()
+
+	It("answers 409 with a home specific message, alone and in a batch", func() {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.Storage.EnforceGloballyUniqueHome = true
+		})
+		DeferCleanup(s.Close)
+		hmacCli := newHmacClient(s.URL, apiKeyID, secretHex)
+		// the home of group "nested" is the home of user-a1 (group-a, home "a")
+		ensG, err := hmacCli.EnsureGroupWithResponse(ctx, "nested", openapi.EnsureGroupRequestBody{Gid: 4200, Home: ptr("a/user-a1")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ensG.StatusCode(), ensG.Body, http.StatusCreated)
+
+		ens, err := hmacCli.EnsureUserWithResponse(ctx, "squatter", openapi.EnsureUserRequestBody{
+			Groupname: "nested", Home: ptr("."), Password: ptr("Secret#123"), PasswordIsHash: ptr(false),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ens.StatusCode(), ens.Body, http.StatusConflict)
+		Expect(ens.JSON409.Code).To(Equal("HOME_CONFLICT"))
+
+		res, err := hmacCli.EnsureUsersBatchWithResponse(ctx, openapi.EnsureUsersBatchRequestBody{
+			{Username: "squatter", Groupname: "nested", Home: ptr("."), Password: ptr("Secret#123"), PasswordIsHash: ptr(false)},
+			{Username: "tenant", Groupname: "nested", Home: ptr("tenant"), Password: ptr("Secret#123"), PasswordIsHash: ptr(false)},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusMultiStatus)
+		Expect((*res.JSON207)[0].Status).To(Equal(http.StatusConflict))
+		Expect(*(*res.JSON207)[0].Error).To(ContainSubstring("home of another user"))
+		Expect((*res.JSON207)[1].Status).To(Equal(http.StatusCreated))
+	})
+})
@@ -0,0 +1,80 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+)
+
+var _ = Describe("Bulk user deletion REST E2E", Ordered, func() {
+	var (
+		ctx     = context.Background()
+		hmacCli *openapi.ClientWithResponses
+	)
+
+	BeforeAll(func() {
+		s := newTestServerFromConfig(TestConfigPath)
+		DeferCleanup(s.Close)
+		hmacCli = newHmacClient(s.URL, apiKeyID, secretHex)
+	})
+
+	usernames := func() []string {
+		code, body := listUsers(ctx, hmacCli, nil)
+		mustStatus(code, body, http.StatusOK)
+		var names []string
+		var list []openapi.UserInfo
+		Expect(json.Unmarshal(body, &list)).To(Succeed())
+		for _, u := range list {
+			names = append(names, u.Username)
+		}
+		return names
+	}
+
+	It("refuses to delete without confirm -> 422", func() {
+		res, err := hmacCli.DeleteUsersWithResponse(ctx, openapi.DeleteUsersRequestBody{Groupname: ptr("group-b")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusUnprocessableEntity)
+		Expect(usernames()).To(ContainElement("user-b1"))
+	})
+
+	It("refuses an empty filter -> 422", func() {
+		res, err := hmacCli.DeleteUsersWithResponse(ctx, openapi.DeleteUsersRequestBody{Confirm: true, Usernames: &[]string{}})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusUnprocessableEntity)
+		Expect(usernames()).To(HaveLen(7))
+	})
+
+	It("deletes expired users only -> 200", func() {
+		res, err := hmacCli.DeleteUsersWithResponse(ctx, openapi.DeleteUsersRequestBody{Confirm: true, ExpiredBefore: ptr(time.Now())})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		Expect(res.JSON200.Deleted).To(Equal([]string{"user-a1"}))
+		Expect(usernames()).NotTo(ContainElement("user-a1"))
+	})
+
+	It("deletes a whole group and purges the homes not shared with it -> 200", func() {
+		res, err := hmacCli.DeleteUsersWithResponse(ctx, openapi.DeleteUsersRequestBody{Confirm: true, Groupname: ptr("group-b"), Purge: ptr(true)})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		Expect(res.JSON200.Count).To(Equal(4))
+		Expect(res.JSON200.Deleted).To(ConsistOf("operator-b", "user-b1", "user-b2", "user-b3"))
+		Expect(res.JSON200.PurgeFailed).To(Equal([]string{"operator-b"})) // home "." is the group home
+		Expect(usernames()).To(ConsistOf("operator-a", "user-a2"))
+	})
+
+	It("intersects the criteria", func() {
+		res, err := hmacCli.DeleteUsersWithResponse(ctx, openapi.DeleteUsersRequestBody{
+			Confirm: true, Groupname: ptr("group-b"), Usernames: &[]string{"operator-a"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		Expect(res.JSON200.Count).To(BeZero())
+		Expect(usernames()).To(ContainElement("operator-a"))
+	})
+})
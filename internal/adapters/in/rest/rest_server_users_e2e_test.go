@@ -2,12 +2,16 @@ package rest_test
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
 )
 
 var _ = Describe("Users REST E2E (smoke)", Ordered, func() {
@@ -60,7 +64,7 @@ var _ = Describe("Users REST E2E (smoke)", Ordered, func() {
 	})
 
 	It("3) disable -> locked; enable -> ok", func() {
-		d1, err := cli.SetUserDisabledWithResponse(ctx, user, openapi.SetUserDisabledRequestBody{Disabled: true})
+		d1, err := cli.SetUserDisabledWithResponse(ctx, user, nil, openapi.SetUserDisabledRequestBody{Disabled: true})
 		Expect(err).NotTo(HaveOccurred())
 		mustStatus(d1.StatusCode(), d1.Body, http.StatusNoContent)
 
@@ -70,7 +74,7 @@ var _ = Describe("Users REST E2E (smoke)", Ordered, func() {
 		Expect(err).NotTo(HaveOccurred())
 		mustStatus(locked.StatusCode(), locked.Body, http.StatusLocked)
 
-		d2, err := cli.SetUserDisabledWithResponse(ctx, user, openapi.SetUserDisabledRequestBody{Disabled: false})
+		d2, err := cli.SetUserDisabledWithResponse(ctx, user, nil, openapi.SetUserDisabledRequestBody{Disabled: false})
 		Expect(err).NotTo(HaveOccurred())
 		mustStatus(d2.StatusCode(), d2.Body, http.StatusNoContent)
 
@@ -81,13 +85,107 @@ var _ = Describe("Users REST E2E (smoke)", Ordered, func() {
 		mustStatus(ok.StatusCode(), ok.Body, http.StatusNoContent)
 	})
 
-	It("4) delete -> get 404", func() {
+	It("4) If-Match: stale version -> 412; current version -> 204 and incremented", func() {
+		get, err := cli.GetUserWithResponse(ctx, user, nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(get.StatusCode(), get.Body, http.StatusOK)
+		Expect(get.JSON200.Version).NotTo(BeNil())
+		version := *get.JSON200.Version
+
+		stale, err := cli.SetUserDescriptionWithResponse(ctx, user,
+			&openapi.SetUserDescriptionParams{IfMatch: ptr(fmt.Sprintf(`"%d"`, version-1))},
+			openapi.SetDescriptionRequestBody{Description: ptr("stale")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(stale.StatusCode(), stale.Body, http.StatusPreconditionFailed)
+
+		fresh, err := cli.SetUserDescriptionWithResponse(ctx, user,
+			&openapi.SetUserDescriptionParams{IfMatch: ptr(fmt.Sprintf(`"%d"`, version))},
+			openapi.SetDescriptionRequestBody{Description: ptr("fresh")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(fresh.StatusCode(), fresh.Body, http.StatusNoContent)
+
+		get, err = cli.GetUserWithResponse(ctx, user, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*get.JSON200.Version).To(Equal(version + 1))
+		Expect(*get.JSON200.Description).To(Equal("fresh"))
+	})
+
+	It("5) list dirs with detail=true -> mode and ownership", func() {
+		resp, err := cli.ListUserDirs(ctx, user, &openapi.ListUserDirsParams{Detail: ptr(true)})
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = resp.Body.Close() }()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var dirs []openapi.DirInfo
+		Expect(json.NewDecoder(resp.Body).Decode(&dirs)).To(Succeed())
+		Expect(dirs).To(HaveLen(1))
+		Expect(dirs[0].Name).To(Equal("_test"))
+		Expect(dirs[0].Mode).To(Equal("2770"))
+	})
+
+	It("6) delete -> get 404", func() {
 		del, err := cli.DeleteUserWithResponse(ctx, user)
 		Expect(err).NotTo(HaveOccurred())
 		mustStatus(del.StatusCode(), del.Body, http.StatusNoContent, http.StatusOK)
 
-		get, err := cli.GetUserWithResponse(ctx, user)
+		get, err := cli.GetUserWithResponse(ctx, user, nil)
 		Expect(err).NotTo(HaveOccurred())
 		mustStatus(get.StatusCode(), get.Body, http.StatusNotFound)
 	})
 })
+
+var _ = Describe("User directories with empty default top dirs REST E2E", Ordered, func() {
+	var (
+		ctx = context.Background()
+		cli *openapi.ClientWithResponses
+	)
+
+	BeforeAll(func() {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.Storage.DefaultUserTopDirs = []config.TopDirConfig{}
+		})
+		DeferCleanup(s.Close)
+		cli = newHmacClient(s.URL, apiKeyID, secretHex)
+
+		ens, err := cli.EnsureUserWithResponse(ctx, "bare", openapi.EnsureUserRequestBody{
+			Groupname: "default", Home: ptr("bare-home"), Password: ptr("Secr3t!"), PasswordIsHash: ptr(false),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ens.StatusCode(), ens.Body, http.StatusCreated)
+	})
+
+	// the generated response parser can't decode the oneOf array, read the raw body
+	listDirs := func(params *openapi.ListUserDirsParams) (int, string) {
+		res, err := cli.ListUserDirs(ctx, "bare", params)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = res.Body.Close() }()
+		body, err := io.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return res.StatusCode, string(body)
+	}
+
+	It("lists an existing user without dirs as 200 []", func() {
+		for _, params := range []*openapi.ListUserDirsParams{nil, {Detail: ptr(true)}} {
+			code, body := listDirs(params)
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(body).To(MatchJSON(`[]`))
+		}
+	})
+
+	It("answers 404 for an unknown user", func() {
+		res, err := cli.ListUserDirs(ctx, "nobody", nil)
+		Expect(err).NotTo(HaveOccurred())
+		_ = res.Body.Close()
+		Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+	})
+
+	It("ensures a dir in the bare home", func() {
+		ens, err := cli.EnsureUserDirWithResponse(ctx, "bare", "data")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ens.StatusCode(), ens.Body, http.StatusCreated)
+
+		code, body := listDirs(nil)
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(MatchJSON(`["data"]`))
+	})
+})
@@ -0,0 +1,73 @@
+package rest_test
+
+import (
+	"context"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+)
+
+var _ = Describe("User effective policy REST E2E", func() {
+	var (
+		ctx          = context.Background()
+		cli          *openapi.ClientWithResponses
+		homesBaseDir string
+	)
+
+	BeforeEach(func() {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			public, noSetgid := fs.FileMode(0o755), false
+			cfg.Storage.DefaultUserTopDirs = []config.TopDirConfig{{Name: "_test"}, {Name: "public", Mode: &public, Setgid: &noSetgid}}
+			cfg.Storage.EnforceGroupQuotas = true
+			cfg.Security.PasswordPolicy.ForbidUsernameInPassword = true
+			homesBaseDir = cfg.Storage.HomesBaseDir
+		})
+		DeferCleanup(s.Close)
+		cli = newHmacClient(s.URL, apiKeyID, secretHex)
+	})
+
+	It("resolves the home, top dirs and quota from the configuration and the user's group", func() {
+		grp, err := cli.EnsureGroupWithResponse(ctx, "proj", openapi.EnsureGroupRequestBody{Gid: 4100, Home: ptr("projects/proj"), QuotaBytes: ptr(uint64(1000))})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(grp.StatusCode(), grp.Body, http.StatusCreated)
+		usr, err := cli.EnsureUserWithResponse(ctx, "carol", openapi.EnsureUserRequestBody{Groupname: "proj", Home: ptr("carol"), Password: ptr("Secr3t!pass")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(usr.StatusCode(), usr.Body, http.StatusCreated)
+
+		res, err := cli.GetUserEffectivePolicyWithResponse(ctx, "carol")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		p := res.JSON200
+		Expect(p.Groupname).To(Equal("proj"))
+		Expect(p.HomeDir).To(Equal(filepath.Join(homesBaseDir, "projects", "proj", "carol")))
+		Expect(p.TopDirs).To(Equal([]openapi.EffectiveTopDir{{Name: "_test", Mode: "2770"}, {Name: "public", Mode: "755"}}))
+		Expect(p.GroupQuotaBytes).To(Equal(ptr(uint64(1000))))
+		Expect(p.GroupQuotaEnforced).To(BeTrue())
+		Expect(p.Locked).To(BeFalse())
+		Expect(p.PasswordPolicy.ForbidUsernameInPassword).To(BeTrue())
+		Expect(p.PasswordPolicy.DefaultAlgorithm).To(Equal("crypt-sha256"))
+	})
+
+	It("reports no quota for a group without one, and the lock of an expired user", func() {
+		res, err := cli.GetUserEffectivePolicyWithResponse(ctx, "user-a1")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusOK)
+		Expect(res.JSON200.HomeDir).To(Equal(filepath.Join(homesBaseDir, "a", "user-a1")))
+		Expect(res.JSON200.GroupQuotaBytes).To(BeNil())
+		Expect(res.JSON200.GroupQuotaEnforced).To(BeFalse())
+		Expect(res.JSON200.Locked).To(BeTrue())
+		Expect(res.JSON200.Expiration).NotTo(BeNil())
+	})
+
+	It("answers 404 for an unknown user", func() {
+		res, err := cli.GetUserEffectivePolicyWithResponse(ctx, "nobody")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusNotFound)
+	})
+})
@@ -0,0 +1,69 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+)
+
+var _ = Describe("Users email REST E2E", Ordered, func() {
+	var (
+		ctx  = context.Background()
+		cli  *openapi.ClientWithResponses
+		user = "mailed"
+	)
+
+	BeforeAll(func() {
+		s := newTestServerFromConfig(TestConfigPath)
+		DeferCleanup(s.Close)
+		cli = newHmacClient(s.URL, apiKeyID, secretHex)
+	})
+
+	It("round-trips the email given on ensure", func() {
+		ens, err := cli.EnsureUserWithResponse(ctx, user, openapi.EnsureUserRequestBody{
+			Groupname: "default", Password: ptr("Secr3t!"), PasswordIsHash: ptr(false), Email: ptr("mailed@example.com"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ens.StatusCode(), ens.Body, http.StatusCreated)
+
+		get, err := cli.GetUserWithResponse(ctx, user, nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(get.StatusCode(), get.Body, http.StatusOK)
+		Expect(get.JSON200.Email).To(HaveValue(Equal("mailed@example.com")))
+	})
+
+	It("changes and clears the email", func() {
+		set, err := cli.SetUserEmailWithResponse(ctx, user, nil, openapi.SetUserEmailRequestBody{Email: ptr("new@example.org")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(set.StatusCode(), set.Body, http.StatusNoContent)
+		get, err := cli.GetUserWithResponse(ctx, user, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(get.JSON200.Email).To(HaveValue(Equal("new@example.org")))
+
+		set, err = cli.SetUserEmailWithResponse(ctx, user, nil, openapi.SetUserEmailRequestBody{Email: nil})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(set.StatusCode(), set.Body, http.StatusNoContent)
+		get, err = cli.GetUserWithResponse(ctx, user, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(get.JSON200.Email).To(BeNil())
+	})
+
+	It("rejects an invalid email with 422, like the other validation failures", func() {
+		set, err := cli.SetUserEmailWithResponse(ctx, user, nil, openapi.SetUserEmailRequestBody{Email: ptr("not-an-email")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(set.StatusCode(), set.Body, http.StatusUnprocessableEntity)
+
+		ens, err := cli.EnsureUserWithResponse(ctx, "badly-mailed", openapi.EnsureUserRequestBody{
+			Groupname: "default", Password: ptr("Secr3t!"), PasswordIsHash: ptr(false), Email: ptr("nobody@"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ens.StatusCode(), ens.Body, http.StatusUnprocessableEntity)
+		get, err := cli.GetUserWithResponse(ctx, "badly-mailed", nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(get.StatusCode(), get.Body, http.StatusNotFound)
+	})
+})
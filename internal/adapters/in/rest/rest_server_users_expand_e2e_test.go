@@ -0,0 +1,61 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+)
+
+var _ = Describe("GetUser expand REST E2E", Ordered, func() {
+	var (
+		ctx = context.Background()
+		cli *openapi.ClientWithResponses
+	)
+
+	BeforeAll(func() {
+		s := newTestServerFromConfig(TestConfigPath)
+		cli = newHmacClient(s.URL, apiKeyID, secretHex)
+		DeferCleanup(s.Close)
+
+		ens, err := cli.EnsureUserWithResponse(ctx, "carol", openapi.EnsureUserRequestBody{
+			Groupname: "default", Home: ptr("carol-home"), Password: ptr("Secr3t!"), PasswordIsHash: ptr(false),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ens.StatusCode(), ens.Body, http.StatusCreated)
+	})
+
+	It("omits the group by default", func() {
+		get, err := cli.GetUserWithResponse(ctx, "carol", nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(get.StatusCode(), get.Body, http.StatusOK)
+		Expect(get.JSON200.Groupname).To(Equal("default"))
+		Expect(get.JSON200.Group).To(BeNil())
+	})
+
+	It("embeds the primary group with ?expand=group", func() {
+		grp, err := cli.GetGroupWithResponse(ctx, "default")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(grp.StatusCode(), grp.Body, http.StatusOK)
+
+		expand := openapi.Group
+		get, err := cli.GetUserWithResponse(ctx, "carol", &openapi.GetUserParams{Expand: &expand})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(get.StatusCode(), get.Body, http.StatusOK)
+		Expect(get.JSON200.Username).To(Equal("carol"))
+		Expect(get.JSON200.Group).NotTo(BeNil())
+		Expect(get.JSON200.Group.Groupname).To(Equal("default"))
+		Expect(get.JSON200.Group.Gid).To(Equal(grp.JSON200.Gid))
+		Expect(get.JSON200.Group.Home).To(Equal(grp.JSON200.Home))
+	})
+
+	It("rejects unknown expansions", func() {
+		expand := openapi.GetUserParamsExpand("owner")
+		get, err := cli.GetUserWithResponse(ctx, "carol", &openapi.GetUserParams{Expand: &expand})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(get.StatusCode(), get.Body, http.StatusBadRequest)
+	})
+})
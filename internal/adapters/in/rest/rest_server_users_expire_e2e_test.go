@@ -0,0 +1,72 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/ports"
+)
+
+// churningUserApi reports every expiration as raced by a concurrent change.
+type churningUserApi struct {
+	ports.ApiServer
+}
+
+func (churningUserApi) ExpireUser(string) error {
+	return ports.ErrVersionMismatch
+}
+
+var _ = Describe("Expire user REST E2E", func() {
+	var (
+		ctx = context.Background()
+		cli *openapi.ClientWithResponses
+	)
+
+	BeforeEach(func() {
+		s := newTestServerFromConfig(TestConfigPath)
+		DeferCleanup(s.Close)
+		cli = newHmacClient(s.URL, apiKeyID, secretHex)
+	})
+
+	It("locks the user at once and records the expiration -> 204", func() {
+		auth, err := cli.AuthzAuthUserWithFormdataBodyWithResponse(ctx, "operator-a", openapi.AuthzAuthUserFormdataRequestBody{Password: "test"})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(auth.StatusCode(), auth.Body, http.StatusNoContent)
+
+		exp, err := cli.ExpireUserWithResponse(ctx, "operator-a")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(exp.StatusCode(), exp.Body, http.StatusNoContent)
+
+		auth, err = cli.AuthzAuthUserWithFormdataBodyWithResponse(ctx, "operator-a", openapi.AuthzAuthUserFormdataRequestBody{Password: "test"})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(auth.StatusCode(), auth.Body, http.StatusLocked)
+
+		u, err := cli.GetUserWithResponse(ctx, "operator-a", nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(u.StatusCode(), u.Body, http.StatusOK)
+		Expect(u.JSON200.Expiration).NotTo(BeNil())
+		Expect(*u.JSON200.Expiration).To(BeTemporally("<=", time.Now()))
+		Expect(u.JSON200.Disabled).To(BeFalse())
+	})
+
+	It("answers 404 for an unknown user", func() {
+		exp, err := cli.ExpireUserWithResponse(ctx, "nobody")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(exp.StatusCode(), exp.Body, http.StatusNotFound)
+	})
+	It("answers 412 when the user keeps changing under the expiration", func() {
+		s := newTestServerWrappingApi(TestConfigPath, func(apis ports.ApiServer) ports.ApiServer { return churningUserApi{apis} })
+		DeferCleanup(s.Close)
+		churned := newHmacClient(s.URL, apiKeyID, secretHex)
+
+		exp, err := churned.ExpireUserWithResponse(ctx, "operator-a")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(exp.StatusCode(), exp.Body, http.StatusPreconditionFailed)
+		Expect(exp.JSON412).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,84 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+)
+
+var _ = Describe("Users page REST E2E", func() {
+	var (
+		ctx     = context.Background()
+		hmacCli *openapi.ClientWithResponses
+	)
+
+	BeforeEach(func() {
+		s := newTestServerFromConfig(TestConfigPath)
+		DeferCleanup(s.Close)
+		hmacCli = newHmacClient(s.URL, apiKeyID, secretHex)
+	})
+
+	It("returns a page with the total when asked for one -> 200", func() {
+		code, body := listUsers(ctx, hmacCli, &openapi.ListUsersParams{Offset: ptr(1), Limit: ptr(2), Sort: ptr("-username")})
+		mustStatus(code, body, http.StatusOK)
+		var page openapi.UserPage
+		Expect(json.Unmarshal(body, &page)).To(Succeed())
+		Expect(page.Total).To(Equal(7))
+		Expect(page.Items).To(HaveLen(2))
+		Expect(page.Items[0].Username > page.Items[1].Username).To(BeTrue())
+		Expect(page.Offset).To(Equal(1))
+		Expect(page.Limit).To(Equal(2))
+	})
+
+	It("reports the limit clamped to max_page_size or defaulted to default_page_size", func() {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.HttpServer.DefaultPageSize = 2
+			cfg.HttpServer.MaxPageSize = 3
+		})
+		DeferCleanup(s.Close)
+		cli := newHmacClient(s.URL, apiKeyID, secretHex)
+
+		code, body := listUsers(ctx, cli, &openapi.ListUsersParams{Limit: ptr(5000)})
+		mustStatus(code, body, http.StatusOK)
+		var page openapi.UserPage
+		Expect(json.Unmarshal(body, &page)).To(Succeed())
+		Expect(page.Limit).To(Equal(3))
+		Expect(page.Offset).To(Equal(0))
+		Expect(page.Items).To(HaveLen(3))
+
+		code, body = listUsers(ctx, cli, &openapi.ListUsersParams{Offset: ptr(4)})
+		mustStatus(code, body, http.StatusOK)
+		page = openapi.UserPage{}
+		Expect(json.Unmarshal(body, &page)).To(Succeed())
+		Expect(page.Limit).To(Equal(2))
+		Expect(page.Offset).To(Equal(4))
+		Expect(page.Items).To(HaveLen(2))
+	})
+
+	It("keeps returning the plain array without page parameters -> 200", func() {
+		code, body := listUsers(ctx, hmacCli, nil)
+		mustStatus(code, body, http.StatusOK)
+		var list []openapi.UserInfo
+		Expect(json.Unmarshal(body, &list)).To(Succeed())
+		Expect(list).To(HaveLen(7))
+	})
+
+	It("rejects an unknown sort key -> 422", func() {
+		res, err := hmacCli.ListUsersWithResponse(ctx, &openapi.ListUsersParams{Sort: ptr("email")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusUnprocessableEntity)
+		Expect(res.JSON422).NotTo(BeNil())
+	})
+
+	It("rejects a zero limit -> 422", func() {
+		res, err := hmacCli.ListUsersWithResponse(ctx, &openapi.ListUsersParams{Limit: ptr(0)})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusUnprocessableEntity)
+	})
+})
@@ -0,0 +1,97 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+)
+
+var _ = Describe("Users touch REST E2E", Ordered, func() {
+	var (
+		ctx       = context.Background()
+		cli       *openapi.ClientWithResponses
+		user      = "touched"
+		createdAt time.Time
+		version   openapi.Version
+	)
+
+	BeforeAll(func() {
+		s := newTestServerFromConfig(TestConfigPath)
+		DeferCleanup(s.Close)
+		cli = newHmacClient(s.URL, apiKeyID, secretHex)
+
+		ens, err := cli.EnsureUserWithResponse(ctx, user, openapi.EnsureUserRequestBody{
+			Groupname: "default", Home: ptr("touched-home"), Password: ptr("Secr3t!"), PasswordIsHash: ptr(false),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ens.StatusCode(), ens.Body, http.StatusCreated)
+
+		get, err := cli.GetUserWithResponse(ctx, user, nil)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(get.StatusCode(), get.Body, http.StatusOK)
+		Expect(get.JSON200.UpdatedAt).NotTo(BeNil())
+		createdAt, version = *get.JSON200.UpdatedAt, *get.JSON200.Version
+	})
+
+	It("recreates a missing default top dir and refreshes updated_at only", func() {
+		del, err := cli.DeleteUserDirWithResponse(ctx, user, "_test")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(del.StatusCode(), del.Body, http.StatusNoContent, http.StatusOK)
+
+		touch, err := cli.TouchUserWithResponse(ctx, user)
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(touch.StatusCode(), touch.Body, http.StatusOK)
+		Expect(*touch.JSON200.UpdatedAt).To(BeTemporally(">", createdAt))
+		Expect(*touch.JSON200.Version).To(Equal(version))
+		Expect(touch.JSON200.Groupname).To(Equal("default"))
+
+		res, err := cli.ListUserDirs(ctx, user, nil)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = res.Body.Close() }()
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		var dirs []string
+		Expect(json.NewDecoder(res.Body).Decode(&dirs)).To(Succeed())
+		Expect(dirs).To(ConsistOf("_test"))
+	})
+
+	It("answers 404 for an unknown user", func() {
+		touch, err := cli.TouchUserWithResponse(ctx, "nobody")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(touch.StatusCode(), touch.Body, http.StatusNotFound)
+	})
+})
+
+var _ = Describe("Users touch REST E2E, home refused by the storage policies", func() {
+	ctx := context.Background()
+
+	It("answers 422 for a stored home the storage now refuses", func() {
+		var dbPath, homesBaseDir string
+		first := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			dbPath, homesBaseDir = cfg.AccountRepository.Sqlite.DbFilePath, cfg.Storage.HomesBaseDir
+		})
+		ens, err := newHmacClient(first.URL, apiKeyID, secretHex).EnsureUserWithResponse(ctx, "flat", openapi.EnsureUserRequestBody{
+			Groupname: "default", Home: ptr("."), Password: ptr("Secr3t!"), PasswordIsHash: ptr(false),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(ens.StatusCode(), ens.Body, http.StatusCreated)
+		first.Close()
+
+		// the same database, now requiring user homes below their group home
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.AccountRepository.Sqlite.DbFilePath, cfg.Storage.HomesBaseDir = dbPath, homesBaseDir
+			cfg.Storage.RequireUserHomeSubdir = true
+		})
+		DeferCleanup(s.Close)
+		touch, err := newHmacClient(s.URL, apiKeyID, secretHex).TouchUserWithResponse(ctx, "flat")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(touch.StatusCode(), touch.Body, http.StatusUnprocessableEntity)
+		Expect(touch.JSON422.Message).To(ContainSubstring("resolves to the group home"))
+	})
+})
@@ -0,0 +1,95 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+)
+
+var _ = Describe("Malformed vs invalid bodies REST E2E", func() {
+	var (
+		ctx = context.Background()
+		cli *openapi.ClientWithResponses
+	)
+
+	BeforeEach(func() {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.Security.PasswordPolicy.ForbidUsernameInPassword = true
+		})
+		DeferCleanup(s.Close)
+		cli = newHmacClient(s.URL, apiKeyID, secretHex)
+	})
+
+	It("answers a body that isn't JSON with 400", func() {
+		res, err := cli.EnsureUserWithBodyWithResponse(ctx, "mallory", "application/json", strings.NewReader(`{"groupname": "default",`))
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusBadRequest)
+	})
+
+	It("answers a password violating the policy with 422", func() {
+		res, err := cli.EnsureUserWithResponse(ctx, "mallory", openapi.EnsureUserRequestBody{Groupname: "default", Password: ptr("Mallory123")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusUnprocessableEntity)
+		Expect(string(res.Body)).To(ContainSubstring("weak password"))
+
+		set, err := cli.SetUserPasswordWithResponse(ctx, "operator-a", nil, openapi.SetUserPasswordRequestBody{Password: ptr("operator-a")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(set.StatusCode(), set.Body, http.StatusUnprocessableEntity)
+	})
+
+	It("answers a missing password with 422", func() {
+		res, err := cli.EnsureUserWithResponse(ctx, "mallory", openapi.EnsureUserRequestBody{Groupname: "default"})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusUnprocessableEntity)
+
+		set, err := cli.SetUserPasswordWithResponse(ctx, "operator-a", nil, openapi.SetUserPasswordRequestBody{Password: ptr(" ")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(set.StatusCode(), set.Body, http.StatusUnprocessableEntity)
+	})
+
+	It("answers a top dir name outside storage.top_dir_name_pattern with 422", func() {
+		for _, name := range []string{"my dir", "dir~", strings.Repeat("d", 65)} {
+			res, err := cli.EnsureUserDirWithResponse(ctx, "operator-a", name)
+			Expect(err).NotTo(HaveOccurred())
+			mustStatus(res.StatusCode(), res.Body, http.StatusUnprocessableEntity)
+		}
+		res, err := cli.EnsureUserDirWithResponse(ctx, "operator-a", "uploads_2.0")
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusCreated)
+	})
+
+	It("lists the field at fault in the 422 body", func() {
+		res, err := cli.EnsureUserWithResponse(ctx, "mallory", openapi.EnsureUserRequestBody{Groupname: "default"})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusUnprocessableEntity)
+		Expect(res.JSON422.Errors).To(Equal([]openapi.FieldError{{Field: ptr("password"), Reason: "password is required"}}))
+
+		res, err = cli.EnsureUserWithResponse(ctx, "mallory", openapi.EnsureUserRequestBody{
+			Groupname: "default", Password: ptr("S3cure-and-long"), Email: ptr("mallory@")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusUnprocessableEntity)
+		Expect(res.JSON422.Message).To(Equal(`invalid input: email "mallory@" is not a valid address`))
+		Expect(res.JSON422.Errors).To(Equal([]openapi.FieldError{{Field: ptr("email"), Reason: `email "mallory@" is not a valid address`}}))
+
+		hash, err := cli.ComputeHashWithResponse(ctx, openapi.ComputeHashRequestBody{Algorithm: "crypt-nope", Plaintext: ptr("x")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(hash.StatusCode(), hash.Body, http.StatusUnprocessableEntity)
+		Expect(hash.JSON422.Errors).To(HaveLen(1))
+		Expect(hash.JSON422.Errors[0].Field).To(Equal(ptr("algorithm")))
+	})
+
+	It("keeps a reason without a field for problems not tied to one", func() {
+		res, err := cli.ComputeHashWithResponse(ctx, openapi.ComputeHashRequestBody{Algorithm: openapi.CryptSha256, Plaintext: ptr("x"), SaltLen: ptr(32)})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(res.StatusCode(), res.Body, http.StatusUnprocessableEntity)
+		Expect(res.JSON422.Errors).To(HaveLen(1))
+		Expect(res.JSON422.Errors[0].Field).To(BeNil())
+		Expect(res.JSON422.Errors[0].Reason).To(Equal(res.JSON422.Message))
+	})
+})
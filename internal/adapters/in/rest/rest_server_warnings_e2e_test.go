@@ -0,0 +1,69 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app/config"
+)
+
+var _ = Describe("Validation warnings REST E2E", func() {
+	var ctx = context.Background()
+
+	newClient := func(returnWarnings bool) *openapi.ClientWithResponses {
+		s := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.HttpServer.ReturnWarnings = returnWarnings
+		})
+		DeferCleanup(s.Close)
+		return newHmacClient(s.URL, apiKeyID, secretHex)
+	}
+
+	It("borderline user input -> 201 + warnings", func() {
+		cli := newClient(true)
+		resp, err := cli.EnsureUserWithResponse(ctx, "warned", openapi.EnsureUserRequestBody{
+			Groupname: "default",
+			Home:      ptr("warned"),
+			Password:  ptr("short1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusCreated)
+		Expect(resp.JSON201).NotTo(BeNil())
+		Expect(resp.JSON201.Warnings).To(ConsistOf(ContainSubstring("password has 6 characters")))
+	})
+
+	It("clean user input -> 201 without a body", func() {
+		cli := newClient(true)
+		resp, err := cli.EnsureUserWithResponse(ctx, "clean", openapi.EnsureUserRequestBody{
+			Groupname: "default",
+			Home:      ptr("clean"),
+			Password:  ptr("a-long-enough-passphrase"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusCreated)
+		Expect(resp.Body).To(BeEmpty())
+	})
+
+	It("borderline group home -> 201 + warnings", func() {
+		cli := newClient(true)
+		resp, err := cli.EnsureGroupWithResponse(ctx, "odd", openapi.EnsureGroupRequestBody{Gid: 7001, Home: ptr("odd home")})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusCreated)
+		Expect(resp.JSON201.Warnings).To(ConsistOf(ContainSubstring(`group home "odd home" contains characters`)))
+	})
+
+	It("warnings disabled (default) -> no body", func() {
+		cli := newClient(false)
+		resp, err := cli.EnsureUserWithResponse(ctx, "warned", openapi.EnsureUserRequestBody{
+			Groupname: "default",
+			Home:      ptr("warned"),
+			Password:  ptr("short1"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		mustStatus(resp.StatusCode(), resp.Body, http.StatusCreated)
+		Expect(resp.Body).To(BeEmpty())
+	})
+})
@@ -0,0 +1,166 @@
+package accounts
+
+import (
+	"fs-access-api/internal/app/ports"
+	"io"
+	"sync"
+	"time"
+)
+
+// Enforce compile-time conformance to the interface
+var _ ports.AccountRepository = (*CachedAccountRepository)(nil)
+
+// CachedAccountRepository decorates a repository with a short-TTL cache of single user, group
+// and authz reads. Mutations through the decorator invalidate the affected entries, changes made
+// by other instances become visible after the TTL.
+type CachedAccountRepository struct {
+	inner ports.AccountRepository
+	ttl   time.Duration
+
+	mu     sync.Mutex
+	gen    uint64 // bumped by every invalidation, so a read racing a mutation isn't cached
+	users  map[string]cacheEntry[ports.UserInfo]
+	groups map[string]cacheEntry[ports.GroupInfo]
+	authz  map[string]cacheEntry[ports.UserAuthzInfo]
+}
+
+type cacheEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+func NewCachedAccountRepository(inner ports.AccountRepository, ttl time.Duration) *CachedAccountRepository {
+	return &CachedAccountRepository{
+		inner:  inner,
+		ttl:    ttl,
+		users:  map[string]cacheEntry[ports.UserInfo]{},
+		groups: map[string]cacheEntry[ports.GroupInfo]{},
+		authz:  map[string]cacheEntry[ports.UserAuthzInfo]{},
+	}
+}
+
+// cachedGet returns a fresh entry or loads (and stores) the value, errors are not cached.
+func cachedGet[T any](c *CachedAccountRepository, m map[string]cacheEntry[T], key string, load func(string) (T, error)) (T, error) {
+	c.mu.Lock()
+	e, ok := m[key]
+	gen := c.gen
+	c.mu.Unlock()
+	if ok && time.Now().Before(e.expires) {
+		return e.value, nil
+	}
+	v, err := load(key)
+	if err != nil {
+		return v, err
+	}
+	c.mu.Lock()
+	if gen == c.gen {
+		m[key] = cacheEntry[T]{value: v, expires: time.Now().Add(c.ttl)}
+	}
+	c.mu.Unlock()
+	return v, nil
+}
+
+func (c *CachedAccountRepository) forgetUser(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	delete(c.users, name)
+	delete(c.authz, name)
+}
+
+// forgetGroup also drops all authz entries, they carry the group's GID and home.
+func (c *CachedAccountRepository) forgetGroup(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	delete(c.groups, name)
+	clear(c.authz)
+}
+
+// Close closes the inner repository when it holds resources.
+func (c *CachedAccountRepository) Close() error {
+	if closer, ok := c.inner.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (c *CachedAccountRepository) HealthCheck() error          { return c.inner.HealthCheck() }
+func (c *CachedAccountRepository) GetInfo() (string, error)    { return c.inner.GetInfo() }
+func (c *CachedAccountRepository) GetNextUID() (uint32, error) { return c.inner.GetNextUID() }
+
+func (c *CachedAccountRepository) Capabilities() ports.RepoCapabilities {
+	return c.inner.Capabilities()
+}
+
+func (c *CachedAccountRepository) ListGroups() ([]ports.GroupInfo, error) {
+	return c.inner.ListGroups()
+}
+
+func (c *CachedAccountRepository) ListEmptyGroups() ([]ports.GroupInfo, error) {
+	return c.inner.ListEmptyGroups()
+}
+
+func (c *CachedAccountRepository) GetGroup(name string) (ports.GroupInfo, error) {
+	return cachedGet(c, c.groups, name, c.inner.GetGroup)
+}
+
+func (c *CachedAccountRepository) AddGroup(group ports.GroupInfo) (ports.GroupInfo, error) {
+	defer c.forgetGroup(group.Groupname)
+	return c.inner.AddGroup(group)
+}
+
+func (c *CachedAccountRepository) UpdateGroup(group ports.GroupInfo) (ports.GroupInfo, error) {
+	defer c.forgetGroup(group.Groupname)
+	return c.inner.UpdateGroup(group)
+}
+
+func (c *CachedAccountRepository) DeleteGroup(name string) error {
+	defer c.forgetGroup(name)
+	return c.inner.DeleteGroup(name)
+}
+
+func (c *CachedAccountRepository) ListUsers() ([]ports.UserInfo, error) {
+	return c.inner.ListUsers()
+}
+
+func (c *CachedAccountRepository) ListUsersPage(offset, limit int, sort string) ([]ports.UserInfo, int, error) {
+	return c.inner.ListUsersPage(offset, limit, sort)
+}
+
+func (c *CachedAccountRepository) GetUser(name string) (ports.UserInfo, error) {
+	return cachedGet(c, c.users, name, c.inner.GetUser)
+}
+
+func (c *CachedAccountRepository) AddUser(user ports.UserInfo) (ports.UserInfo, error) {
+	defer c.forgetUser(user.Username)
+	return c.inner.AddUser(user)
+}
+
+func (c *CachedAccountRepository) UpdateUser(user ports.UserInfo) (ports.UserInfo, error) {
+	defer c.forgetUser(user.Username)
+	return c.inner.UpdateUser(user)
+}
+
+func (c *CachedAccountRepository) TouchUser(name string) (ports.UserInfo, error) {
+	defer c.forgetUser(name)
+	return c.inner.TouchUser(name)
+}
+
+func (c *CachedAccountRepository) DeleteUser(name string) error {
+	defer c.forgetUser(name)
+	return c.inner.DeleteUser(name)
+}
+
+func (c *CachedAccountRepository) DeleteUsers(names []string) (int, error) {
+	defer func() {
+		for _, name := range names {
+			c.forgetUser(name)
+		}
+	}()
+	return c.inner.DeleteUsers(names)
+}
+
+func (c *CachedAccountRepository) GetUserAuthzInfo(name string) (ports.UserAuthzInfo, error) {
+	return cachedGet(c, c.authz, name, c.inner.GetUserAuthzInfo)
+}
@@ -0,0 +1,85 @@
+package accounts_test
+
+import (
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CachedAccountRepository", func() {
+	var (
+		inner  *accounts.InMemAccountRepository
+		cached *accounts.CachedAccountRepository
+	)
+
+	BeforeEach(func() {
+		var err error
+		inner, err = accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 100},
+			config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}, true)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = inner.AddGroup(ports.GroupInfo{Groupname: "g1", GID: 3000, Home: "g1"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = inner.AddUser(ports.UserInfo{Username: "u1", UID: 2500, Groupname: "g1", Password: "hash", PasswordIsHash: true, Home: "u1"})
+		Expect(err).ToNot(HaveOccurred())
+		cached = accounts.NewCachedAccountRepository(inner, time.Minute)
+	})
+
+	It("serves repeated reads from the cache", func() {
+		u, err := cached.GetUser("u1")
+		Expect(err).ToNot(HaveOccurred())
+
+		u.Home = "moved" // behind the decorator's back
+		_, err = inner.UpdateUser(u)
+		Expect(err).ToNot(HaveOccurred())
+
+		again, err := cached.GetUser("u1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(again.Home).To(Equal("u1"))
+	})
+
+	It("invalidates the user and its authz info on a user mutation", func() {
+		u, err := cached.GetUser("u1")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = cached.GetUserAuthzInfo("u1")
+		Expect(err).ToNot(HaveOccurred())
+
+		u.Disabled = true
+		_, err = cached.UpdateUser(u)
+		Expect(err).ToNot(HaveOccurred())
+
+		again, err := cached.GetUser("u1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(again.Disabled).To(BeTrue())
+		authz, err := cached.GetUserAuthzInfo("u1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(authz.Disabled).To(BeTrue())
+	})
+
+	It("invalidates authz info on a group mutation", func() {
+		_, err := cached.GetUserAuthzInfo("u1")
+		Expect(err).ToNot(HaveOccurred())
+		g, err := cached.GetGroup("g1")
+		Expect(err).ToNot(HaveOccurred())
+
+		g.Home = "g1-moved"
+		_, err = cached.UpdateGroup(g)
+		Expect(err).ToNot(HaveOccurred())
+
+		authz, err := cached.GetUserAuthzInfo("u1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(authz.GroupHome).To(Equal("g1-moved"))
+	})
+
+	It("doesn't cache misses", func() {
+		_, err := cached.GetUser("u2")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+		_, err = inner.AddUser(ports.UserInfo{Username: "u2", UID: 2501, Groupname: "g1", Password: "hash", PasswordIsHash: true, Home: "u2"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = cached.GetUser("u2")
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,43 @@
+package accounts_test
+
+import (
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Account repositories capabilities", func() {
+	common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+
+	It("inmem is writable without transactions", func() {
+		repo, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10}, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repo.Capabilities()).To(Equal(ports.RepoCapabilities{}))
+	})
+
+	It("SQLite is writable with transactions", func() {
+		repo, err := accounts.NewSQLiteAccountRepository(config.AccountRepositorySqliteConfig{
+			DbFilePath:   filepath.Join(GinkgoT().TempDir(), "caps.db"),
+			WriteTimeout: time.Second,
+			QueryTimeout: time.Second,
+		}, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repo.Capabilities()).To(Equal(ports.RepoCapabilities{SupportsTransactions: true}))
+	})
+
+	It("the cache reports the capabilities of the repository it wraps", func() {
+		repo, err := accounts.NewSQLiteAccountRepository(config.AccountRepositorySqliteConfig{
+			DbFilePath:   filepath.Join(GinkgoT().TempDir(), "caps.db"),
+			WriteTimeout: time.Second,
+			QueryTimeout: time.Second,
+		}, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		cached := accounts.NewCachedAccountRepository(repo, time.Minute)
+		Expect(cached.Capabilities()).To(Equal(repo.Capabilities()))
+	})
+})
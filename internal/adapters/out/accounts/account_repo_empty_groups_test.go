@@ -0,0 +1,63 @@
+package accounts_test
+
+import (
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Account repositories empty groups", func() {
+	common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+
+	seed := func(repo ports.AccountRepository) {
+		for i, name := range []string{"populated", "empty-b", "shared", "empty-a"} {
+			_, err := repo.AddGroup(ports.GroupInfo{Groupname: name, GID: uint32(3000 + i), Home: name})
+			Expect(err).ToNot(HaveOccurred())
+		}
+		for i, u := range [][2]string{{"alice", "populated"}, {"bob", "shared"}, {"carol", "shared"}} {
+			_, err := repo.AddUser(ports.UserInfo{Username: u[0], UID: uint32(4000 + i), Groupname: u[1],
+				Password: "x", PasswordIsHash: true, Home: u[0]})
+			Expect(err).ToNot(HaveOccurred())
+		}
+	}
+	emptyNames := func(repo ports.AccountRepository) []string {
+		groups, err := repo.ListEmptyGroups()
+		Expect(err).ToNot(HaveOccurred())
+		names := []string{}
+		for _, g := range groups {
+			names = append(names, g.Groupname)
+		}
+		return names
+	}
+
+	It("returns only the groups without members, in inmem and SQLite alike", func() {
+		inmem, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 100}, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		seed(inmem)
+		sqlite, err := accounts.NewSQLiteAccountRepository(config.AccountRepositorySqliteConfig{
+			DbFilePath:   filepath.Join(GinkgoT().TempDir(), "empty.db"),
+			WriteTimeout: time.Second,
+			QueryTimeout: time.Second,
+		}, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		seed(sqlite)
+
+		for _, repo := range []ports.AccountRepository{inmem, sqlite} {
+			Expect(emptyNames(repo)).To(Equal([]string{"empty-a", "empty-b"}))
+
+			Expect(repo.DeleteUser("alice")).To(Succeed())
+			Expect(emptyNames(repo)).To(Equal([]string{"empty-a", "empty-b", "populated"}))
+		}
+	})
+
+	It("returns an empty list when every group has members", func() {
+		inmem, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 100}, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(emptyNames(inmem)).To(BeEmpty())
+	})
+})
@@ -1,10 +1,14 @@
 package accounts
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"fs-access-api/internal/app/config"
 	"fs-access-api/internal/app/ports"
+	"os"
+	"slices"
+	"strings"
 	"sync"
 )
 
@@ -14,6 +18,9 @@ type InMemAccountRepository struct {
 	bootstrap bool
 	users     map[string]*ports.UserInfo
 	groups    map[string]*ports.GroupInfo
+	wal       *os.File
+	walSize   int64 // length of the journal records written so far
+	walErr    error // set when a failed write couldn't be rolled back, the journal is unusable
 	mu        sync.RWMutex
 }
 
@@ -21,13 +28,19 @@ type InMemAccountRepository struct {
 var _ ports.AccountRepository = (*InMemAccountRepository)(nil)
 
 func NewInMemAccountRepository(cfg config.AccountRepositoryInMemConfig, common config.AccountRepositoryCommonConfig, bootstrap bool) (*InMemAccountRepository, error) {
-	return &InMemAccountRepository{
+	repo := &InMemAccountRepository{
 		cfg:       cfg,
 		common:    common,
 		bootstrap: bootstrap,
 		users:     make(map[string]*ports.UserInfo),
 		groups:    make(map[string]*ports.GroupInfo),
-	}, nil
+	}
+	if cfg.WalPath != "" {
+		if err := repo.openWAL(cfg.WalPath); err != nil {
+			return nil, err
+		}
+	}
+	return repo, nil
 }
 
 func (s *InMemAccountRepository) HealthCheck() error {
@@ -38,8 +51,13 @@ func (s *InMemAccountRepository) GetInfo() (string, error) {
 	return "in-memory", nil
 }
 
+func (s *InMemAccountRepository) Capabilities() ports.RepoCapabilities {
+	return ports.RepoCapabilities{}
+}
+
 // --- Groups ---
 
+// ListGroups returns the groups ordered by name (byte-wise, like SQLite's ORDER BY).
 func (s *InMemAccountRepository) ListGroups() ([]ports.GroupInfo, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -47,6 +65,25 @@ func (s *InMemAccountRepository) ListGroups() ([]ports.GroupInfo, error) {
 	for _, g := range s.groups {
 		out = append(out, *g)
 	}
+	slices.SortFunc(out, func(a, b ports.GroupInfo) int { return strings.Compare(a.Groupname, b.Groupname) })
+	return out, nil
+}
+
+// ListEmptyGroups scans the users for the groups they belong to and returns the others.
+func (s *InMemAccountRepository) ListEmptyGroups() ([]ports.GroupInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	populated := make(map[string]bool, len(s.groups))
+	for _, u := range s.users {
+		populated[u.Groupname] = true
+	}
+	out := make([]ports.GroupInfo, 0)
+	for _, g := range s.groups {
+		if !populated[g.Groupname] {
+			out = append(out, *g)
+		}
+	}
+	slices.SortFunc(out, func(a, b ports.GroupInfo) int { return strings.Compare(a.Groupname, b.Groupname) })
 	return out, nil
 }
 
@@ -75,6 +112,13 @@ func (s *InMemAccountRepository) AddGroup(group ports.GroupInfo) (ports.GroupInf
 	if _, exists := s.groups[group.Groupname]; exists {
 		return ports.GroupInfo{}, ports.ErrAlreadyExists
 	}
+	if err := s.checkGroupDescriptionUnique(group); err != nil {
+		return ports.GroupInfo{}, err
+	}
+	group.Version = 1
+	if err := s.journal(walRecord{Op: walOpAddGroup, Group: &group}); err != nil {
+		return ports.GroupInfo{}, err
+	}
 	g := group
 	s.groups[group.Groupname] = &g
 	return group, nil
@@ -87,10 +131,33 @@ func (s *InMemAccountRepository) UpdateGroup(group ports.GroupInfo) (ports.Group
 	if !exists {
 		return ports.GroupInfo{}, ports.ErrNotFound
 	}
+	if group.Version != 0 && group.Version != ptr.Version {
+		return ports.GroupInfo{}, ports.ErrVersionMismatch
+	}
+	if err := s.checkGroupDescriptionUnique(group); err != nil {
+		return ports.GroupInfo{}, err
+	}
+	group.Version = ptr.Version + 1
+	if err := s.journal(walRecord{Op: walOpUpdateGroup, Group: &group}); err != nil {
+		return ports.GroupInfo{}, err
+	}
 	*ptr = group
 	return group, nil
 }
 
+// checkGroupDescriptionUnique is the scan counterpart of the SQL query, the caller holds the lock.
+func (s *InMemAccountRepository) checkGroupDescriptionUnique(group ports.GroupInfo) error {
+	if !s.common.UniqueGroupDescriptions || group.Description == nil || *group.Description == "" {
+		return nil
+	}
+	for name, g := range s.groups {
+		if name != group.Groupname && g.Description != nil && *g.Description == *group.Description {
+			return descriptionTakenErr(*group.Description, name)
+		}
+	}
+	return nil
+}
+
 func (s *InMemAccountRepository) DeleteGroup(name string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -98,12 +165,25 @@ func (s *InMemAccountRepository) DeleteGroup(name string) error {
 	if !exists {
 		return ports.ErrNotFound
 	}
+	members := 0
+	for _, u := range s.users {
+		if u.Groupname == name {
+			members++
+		}
+	}
+	if members > 0 {
+		return fmt.Errorf("%w: group %q still has %d members", ports.ErrGroupNotEmpty, name, members)
+	}
+	if err := s.journal(walRecord{Op: walOpDeleteGroup, Name: name}); err != nil {
+		return err
+	}
 	delete(s.groups, name)
 	return nil
 }
 
 // --- Users ---
 
+// ListUsers returns the users ordered by name (byte-wise, like SQLite's ORDER BY).
 func (s *InMemAccountRepository) ListUsers() ([]ports.UserInfo, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -111,9 +191,30 @@ func (s *InMemAccountRepository) ListUsers() ([]ports.UserInfo, error) {
 	for _, u := range s.users {
 		out = append(out, *u) // return values to callers to avoid external mutation
 	}
+	slices.SortFunc(out, func(a, b ports.UserInfo) int { return strings.Compare(a.Username, b.Username) })
 	return out, nil
 }
 
+func (s *InMemAccountRepository) ListUsersPage(offset, limit int, sort string) ([]ports.UserInfo, int, error) {
+	key, desc, err := ports.ParseUserSort(sort)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = checkPageBounds(offset, limit); err != nil {
+		return nil, 0, err
+	}
+	users, _ := s.ListUsers()
+	if key == ports.UserSortUID {
+		slices.SortStableFunc(users, func(a, b ports.UserInfo) int { return cmp.Compare(a.UID, b.UID) })
+	}
+	if desc {
+		slices.Reverse(users)
+	}
+	total := len(users)
+	offset = min(offset, total)
+	return users[offset:min(offset+limit, total)], total, nil
+}
+
 func (s *InMemAccountRepository) GetUser(name string) (ports.UserInfo, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -124,8 +225,17 @@ func (s *InMemAccountRepository) GetUser(name string) (ports.UserInfo, error) {
 	return *u, nil
 }
 
+// GetNextUID returns MAX(uid)+1 like the SQL repositories, so shared UIDs don't cause collisions.
 func (s *InMemAccountRepository) GetNextUID() (uint32, error) {
-	return s.common.MinUID + uint32(len(s.users)), nil
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	next := s.common.MinUID
+	for _, u := range s.users {
+		if u.UID >= next {
+			next = u.UID + 1
+		}
+	}
+	return next, nil
 }
 
 func (s *InMemAccountRepository) AddUser(user ports.UserInfo) (ports.UserInfo, error) {
@@ -143,6 +253,14 @@ func (s *InMemAccountRepository) AddUser(user ports.UserInfo) (ports.UserInfo, e
 	if _, exists := s.users[user.Username]; exists {
 		return ports.UserInfo{}, ports.ErrAlreadyExists
 	}
+	if err := s.checkUIDUnique(user); err != nil {
+		return ports.UserInfo{}, err
+	}
+	user.Version = 1
+	user.UpdatedAt = nowUTC()
+	if err := s.journal(newWalUserRecord(walOpAddUser, user)); err != nil {
+		return ports.UserInfo{}, err
+	}
 	u := user
 	s.users[user.Username] = &u
 	return u, nil
@@ -156,21 +274,91 @@ func (s *InMemAccountRepository) UpdateUser(user ports.UserInfo) (ports.UserInfo
 	if !ok {
 		return ports.UserInfo{}, ports.ErrNotFound
 	}
+	if user.Version != 0 && user.Version != existing.Version {
+		return ports.UserInfo{}, ports.ErrVersionMismatch
+	}
+	if err := s.checkUIDUnique(user); err != nil {
+		return ports.UserInfo{}, err
+	}
+	if user.Password == "" {
+		user.Password = existing.Password
+		user.PasswordIsHash = existing.PasswordIsHash
+	}
+	user.Version = existing.Version + 1
+	user.UpdatedAt = nowUTC()
+	if err := s.journal(newWalUserRecord(walOpUpdateUser, user)); err != nil {
+		return ports.UserInfo{}, err
+	}
 
 	*existing = user
 	return *existing, nil
 }
 
+// checkUIDUnique is the scan counterpart of the unique UID index, the caller holds the lock.
+func (s *InMemAccountRepository) checkUIDUnique(user ports.UserInfo) error {
+	if s.common.AllowDuplicateUIDs {
+		return nil
+	}
+	for name, u := range s.users {
+		if name != user.Username && u.UID == user.UID {
+			return fmt.Errorf("%w: UID %d is used by user %q", ports.ErrUIDTaken, user.UID, name)
+		}
+	}
+	return nil
+}
+
+func (s *InMemAccountRepository) TouchUser(name string) (ports.UserInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.users[name]
+	if !ok {
+		return ports.UserInfo{}, ports.ErrNotFound
+	}
+	user := *existing
+	user.UpdatedAt = nowUTC()
+	if err := s.journal(newWalUserRecord(walOpUpdateUser, user)); err != nil {
+		return ports.UserInfo{}, err
+	}
+	*existing = user
+	return user, nil
+}
+
 func (s *InMemAccountRepository) DeleteUser(name string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if _, exists := s.users[name]; !exists {
 		return ports.ErrNotFound
 	}
+	if err := s.journal(walRecord{Op: walOpDeleteUser, Name: name}); err != nil {
+		return err
+	}
 	delete(s.users, name)
 	return nil
 }
 
+func (s *InMemAccountRepository) DeleteUsers(names []string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, name := range names {
+		if _, exists := s.users[name]; !exists {
+			return 0, fmt.Errorf("%w: user %q is already gone", ports.ErrConflict, name)
+		}
+	}
+	// a single record keeps the batch atomic on replay
+	if err := s.journal(walRecord{Op: walOpDeleteUsers, Names: names}); err != nil {
+		return 0, err
+	}
+	affected := 0
+	for _, name := range names {
+		if _, exists := s.users[name]; exists {
+			delete(s.users, name)
+			affected++
+		}
+	}
+	return affected, nil
+}
+
 func (s *InMemAccountRepository) GetUserAuthzInfo(username string) (ports.UserAuthzInfo, error) {
 	u, err := s.GetUser(username)
 	if err != nil {
@@ -181,13 +369,14 @@ func (s *InMemAccountRepository) GetUserAuthzInfo(username string) (ports.UserAu
 		return ports.UserAuthzInfo{}, err
 	}
 	return ports.UserAuthzInfo{
-		Username:  u.Username,
-		UID:       u.UID,
-		Groupname: u.Groupname,
-		GID:       g.GID,
-		UserHome:  u.Home,
-		GroupHome: g.Home,
-		Locked:    u.IsLocked(),
-		Password:  u.Password,
+		Username:   u.Username,
+		UID:        u.UID,
+		Groupname:  u.Groupname,
+		GID:        g.GID,
+		UserHome:   u.Home,
+		GroupHome:  g.Home,
+		Password:   u.Password,
+		Disabled:   u.Disabled,
+		Expiration: u.Expiration,
 	}, nil
 }
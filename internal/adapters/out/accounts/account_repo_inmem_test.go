@@ -0,0 +1,288 @@
+package accounts_test
+
+import (
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InMemAccountRepository WAL", func() {
+	var (
+		cfg    config.AccountRepositoryInMemConfig
+		common = config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+	)
+
+	BeforeEach(func() {
+		cfg = config.AccountRepositoryInMemConfig{
+			EntitiesLimit: 100,
+			WalPath:       filepath.Join(GinkgoT().TempDir(), "wal", "inmem.wal"),
+			CreateWalDir:  true,
+		}
+	})
+
+	It("replays the journal and reconstructs the state", func() {
+		repo, err := accounts.NewInMemAccountRepository(cfg, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddGroup(ports.GroupInfo{Groupname: "g1", GID: 3000, Home: "g1"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddGroup(ports.GroupInfo{Groupname: "g2", GID: 3001, Home: "g2"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddUser(ports.UserInfo{Username: "u1", UID: 2500, Groupname: "g1", Password: "hash-1", PasswordIsHash: true, Home: "u1"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddUser(ports.UserInfo{Username: "u2", UID: 2501, Groupname: "g1", Password: "hash-2", PasswordIsHash: true, Home: "u2"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repo.DeleteGroup("g2")).To(Succeed())
+		Expect(repo.DeleteUser("u2")).To(Succeed())
+		_, err = repo.UpdateUser(ports.UserInfo{Username: "u1", UID: 2500, Groupname: "g1", Password: "hash-3", PasswordIsHash: true, Home: "u1", Disabled: true})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repo.Close()).To(Succeed())
+
+		replayed, err := accounts.NewInMemAccountRepository(cfg, common, false)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(replayed.Close)
+
+		groups, err := replayed.ListGroups()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(groups).To(HaveLen(1))
+		Expect(groups[0].Groupname).To(Equal("g1"))
+
+		users, err := replayed.ListUsers()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(users).To(HaveLen(1))
+		Expect(users[0].Username).To(Equal("u1"))
+		Expect(users[0].Password).To(Equal("hash-3"))
+		Expect(users[0].PasswordIsHash).To(BeTrue())
+		Expect(users[0].Disabled).To(BeTrue())
+		Expect(users[0].Version).To(Equal(uint64(2)))
+	})
+
+	It("drops a torn trailing record and keeps appending", func() {
+		repo, err := accounts.NewInMemAccountRepository(cfg, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddGroup(ports.GroupInfo{Groupname: "g1", GID: 3000, Home: "g1"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repo.Close()).To(Succeed())
+
+		f, err := os.OpenFile(cfg.WalPath, os.O_APPEND|os.O_WRONLY, 0o600)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = f.WriteString(`{"op":"add_group","group":{"Grou`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(f.Close()).To(Succeed())
+
+		replayed, err := accounts.NewInMemAccountRepository(cfg, common, false)
+		Expect(err).ToNot(HaveOccurred())
+		groups, err := replayed.ListGroups()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(groups).To(HaveLen(1))
+		_, err = replayed.AddGroup(ports.GroupInfo{Groupname: "g2", GID: 3001, Home: "g2"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(replayed.Close()).To(Succeed())
+
+		again, err := accounts.NewInMemAccountRepository(cfg, common, false)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(again.Close)
+		groups, err = again.ListGroups()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(groups).To(HaveLen(2))
+	})
+
+	walLines := func() []string {
+		data, err := os.ReadFile(cfg.WalPath)
+		Expect(err).ToNot(HaveOccurred())
+		return strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+
+	It("compacts the journal to one record per entity on startup", func() {
+		repo, err := accounts.NewInMemAccountRepository(cfg, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddGroup(ports.GroupInfo{Groupname: "g1", GID: 3000, Home: "g1"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddGroup(ports.GroupInfo{Groupname: "g2", GID: 3001, Home: "g2"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repo.DeleteGroup("g2")).To(Succeed())
+		_, err = repo.AddUser(ports.UserInfo{Username: "u1", UID: 2500, Groupname: "g1", Password: "hash-1", PasswordIsHash: true, Home: "u1"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.TouchUser("u1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repo.Close()).To(Succeed())
+		Expect(walLines()).To(HaveLen(5))
+
+		replayed, err := accounts.NewInMemAccountRepository(cfg, common, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(walLines()).To(HaveLen(2))
+		_, err = replayed.AddUser(ports.UserInfo{Username: "u2", UID: 2501, Groupname: "g1", Password: "hash-2", PasswordIsHash: true, Home: "u2"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(replayed.Close()).To(Succeed())
+
+		again, err := accounts.NewInMemAccountRepository(cfg, common, false)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(again.Close)
+		users, err := again.ListUsers()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(users).To(HaveLen(2))
+		Expect(users[0].Password).To(Equal("hash-1"))
+		Expect(users[0].PasswordIsHash).To(BeTrue())
+	})
+
+	It("compacts the journal once it grows over wal_compact_bytes", func() {
+		cfg.WalCompactBytes = 1024
+		repo, err := accounts.NewInMemAccountRepository(cfg, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		g, err := repo.AddGroup(ports.GroupInfo{Groupname: "g1", GID: 3000, Home: "g1"})
+		Expect(err).ToNot(HaveOccurred())
+		for i := 0; i < 100; i++ {
+			g, err = repo.UpdateGroup(g)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(repo.Close()).To(Succeed())
+		fi, err := os.Stat(cfg.WalPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fi.Size()).To(BeNumerically("<", 2*cfg.WalCompactBytes))
+
+		replayed, err := accounts.NewInMemAccountRepository(cfg, common, false)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(replayed.Close)
+		got, err := replayed.GetGroup("g1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(got.Version).To(Equal(uint64(101)))
+	})
+})
+
+var _ = Describe("InMemAccountRepository user touch", func() {
+	It("advances UpdatedAt leaving the attributes and version unchanged", func() {
+		repo, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10},
+			config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}, true)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddGroup(ports.GroupInfo{Groupname: "g1", GID: 3000, Home: "g1"})
+		Expect(err).ToNot(HaveOccurred())
+		u, err := repo.AddUser(ports.UserInfo{Username: "u1", UID: 2500, Groupname: "g1", Password: "hash-1", PasswordIsHash: true, Home: "u1"})
+		Expect(err).ToNot(HaveOccurred())
+
+		time.Sleep(time.Millisecond)
+		touched, err := repo.TouchUser("u1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*touched.UpdatedAt).To(BeTemporally(">", *u.UpdatedAt))
+		Expect(touched.Version).To(Equal(u.Version))
+		Expect(touched.Home).To(Equal(u.Home))
+
+		_, err = repo.TouchUser("ghost")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+	})
+})
+
+var _ = Describe("InMemAccountRepository bulk user deletion", func() {
+	It("counts the users actually deleted, none on conflict", func() {
+		repo, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10},
+			config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}, true)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddGroup(ports.GroupInfo{Groupname: "g1", GID: 3000, Home: "g1"})
+		Expect(err).ToNot(HaveOccurred())
+		for i, name := range []string{"u1", "u2", "u3"} {
+			_, err = repo.AddUser(ports.UserInfo{Username: name, UID: uint32(2500 + i), Groupname: "g1", Password: "x", Home: name})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		affected, err := repo.DeleteUsers([]string{"u1", "ghost"})
+		Expect(err).To(MatchError(ports.ErrConflict))
+		Expect(affected).To(BeZero())
+
+		affected, err = repo.DeleteUsers([]string{"u1", "u2", "u1"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(affected).To(Equal(2))
+		users, err := repo.ListUsers()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(users).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("InMemAccountRepository user update", func() {
+	It("keeps the stored password when the update leaves it empty", func() {
+		repo, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10},
+			config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}, true)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddGroup(ports.GroupInfo{Groupname: "g1", GID: 3000, Home: "g1"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddUser(ports.UserInfo{Username: "u1", UID: 2500, Groupname: "g1", Password: "hash-1", PasswordIsHash: true, Home: "u1"})
+		Expect(err).ToNot(HaveOccurred())
+
+		updated, err := repo.UpdateUser(ports.UserInfo{Username: "u1", UID: 2500, Groupname: "g1", Home: "u1", Disabled: true})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.Disabled).To(BeTrue())
+		Expect(updated.Password).To(Equal("hash-1"))
+		Expect(updated.PasswordIsHash).To(BeTrue())
+
+		updated.Password = "hash-2"
+		updated, err = repo.UpdateUser(updated)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.Password).To(Equal("hash-2"))
+	})
+})
+
+var _ = Describe("InMemAccountRepository group deletion", func() {
+	It("refuses to delete a group that still has members", func() {
+		repo, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10},
+			config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}, true)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddGroup(ports.GroupInfo{Groupname: "g1", GID: 3000, Home: "g1"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddUser(ports.UserInfo{Username: "u1", UID: 2500, Groupname: "g1", Password: "x", Home: "u1"})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = repo.DeleteGroup("g1")
+		Expect(err).To(MatchError(ports.ErrGroupNotEmpty))
+		Expect(err).To(MatchError(ports.ErrConflict))
+		_, err = repo.GetGroup("g1")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(repo.DeleteUser("u1")).To(Succeed())
+		Expect(repo.DeleteGroup("g1")).To(Succeed())
+	})
+})
+
+var _ = Describe("InMemAccountRepository duplicate UIDs", func() {
+	newRepo := func(common config.AccountRepositoryCommonConfig) *accounts.InMemAccountRepository {
+		repo, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 100}, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddGroup(ports.GroupInfo{Groupname: "legacy", GID: 5000, Home: "legacy"})
+		Expect(err).ToNot(HaveOccurred())
+		return repo
+	}
+	user := func(name string, uid uint32) ports.UserInfo {
+		return ports.UserInfo{Username: name, UID: uid, Groupname: "legacy", Password: "x", PasswordIsHash: true, Home: name}
+	}
+
+	It("rejects a second user with the same UID by default, like the sqlite unique index", func() {
+		repo := newRepo(config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000})
+		_, err := repo.AddUser(user("alice", 3000))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddUser(user("alias", 3000))
+		Expect(err).To(MatchError(ports.ErrUIDTaken))
+
+		bob, err := repo.AddUser(user("bob", 3001))
+		Expect(err).ToNot(HaveOccurred())
+		bob.UID = 3000
+		_, err = repo.UpdateUser(bob)
+		Expect(err).To(MatchError(ports.ErrUIDTaken))
+
+		alice, err := repo.GetUser("alice")
+		Expect(err).ToNot(HaveOccurred())
+		alice.Disabled = true
+		_, err = repo.UpdateUser(alice)
+		Expect(err).ToNot(HaveOccurred(), "a user keeps its own UID")
+	})
+
+	It("accepts users sharing a UID when allow_duplicate_uids is set", func() {
+		repo := newRepo(config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000, AllowDuplicateUIDs: true})
+		_, err := repo.AddUser(user("alice", 3000))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddUser(user("alias", 3000))
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,238 @@
+package accounts
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fs-access-api/internal/app/ports"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+type walOp string
+
+const (
+	walOpAddGroup    walOp = "add_group"
+	walOpUpdateGroup walOp = "update_group"
+	walOpDeleteGroup walOp = "delete_group"
+	walOpAddUser     walOp = "add_user"
+	walOpUpdateUser  walOp = "update_user"
+	walOpDeleteUser  walOp = "delete_user"
+	walOpDeleteUsers walOp = "delete_users"
+)
+
+// walUser shadows the password fields hidden from the JSON API, the journal must keep them.
+type walUser struct {
+	ports.UserInfo
+	Password       string `json:"password"`
+	PasswordIsHash bool   `json:"password_is_hash"`
+}
+
+// walRecord is a single journaled mutation, stored as one JSON line.
+type walRecord struct {
+	Op    walOp            `json:"op"`
+	Name  string           `json:"name,omitempty"`
+	Names []string         `json:"names,omitempty"`
+	Group *ports.GroupInfo `json:"group,omitempty"`
+	User  *walUser         `json:"user,omitempty"`
+}
+
+func newWalUserRecord(op walOp, user ports.UserInfo) walRecord {
+	return walRecord{Op: op, User: &walUser{UserInfo: user, Password: user.Password, PasswordIsHash: user.PasswordIsHash}}
+}
+
+// openWAL replays the existing journal into the repository maps and opens it for appending,
+// compacted so the history replayed on every start doesn't grow forever.
+func (s *InMemAccountRepository) openWAL(path string) error {
+	if s.cfg.CreateWalDir {
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return fmt.Errorf("cannot create wal dir: %w", err)
+		}
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot open wal %s: %w", path, err)
+	}
+	valid, err := s.replayWAL(f)
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("cannot replay wal %s: %w", path, err)
+	}
+	// drop a torn tail, so the next record starts on a fresh line
+	if err := f.Truncate(valid); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("cannot truncate wal %s: %w", path, err)
+	}
+	s.wal = f
+	s.walSize = valid
+	if err := s.compactWAL(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("cannot compact wal %s: %w", path, err)
+	}
+	return nil
+}
+
+// compactWAL replaces the journal with one record per group and user, reflecting the current state.
+// The new journal is written aside and renamed over the old one, so a crash keeps either of them.
+// The caller must hold the write lock.
+func (s *InMemAccountRepository) compactWAL() error {
+	path := s.cfg.WalPath
+	tmp := path + ".compact"
+	f, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	size, err := s.writeSnapshot(f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	_ = s.wal.Close()
+	s.wal = f
+	s.walSize = size
+	return nil
+}
+
+// writeSnapshot writes an add record per group, then per user, both ordered by name.
+func (s *InMemAccountRepository) writeSnapshot(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var size int64
+	write := func(rec walRecord) error {
+		data, err := json.Marshal(rec)
+		if err != nil {
+			return err
+		}
+		n, err := bw.Write(append(data, '\n'))
+		size += int64(n)
+		return err
+	}
+	for _, name := range slices.Sorted(maps.Keys(s.groups)) {
+		if err := write(walRecord{Op: walOpAddGroup, Group: s.groups[name]}); err != nil {
+			return 0, err
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(s.users)) {
+		if err := write(newWalUserRecord(walOpAddUser, *s.users[name])); err != nil {
+			return 0, err
+		}
+	}
+	return size, bw.Flush()
+}
+
+// replayWAL applies all complete records and returns the length of the valid journal prefix.
+func (s *InMemAccountRepository) replayWAL(r io.Reader) (int64, error) {
+	br := bufio.NewReader(r)
+	var valid int64
+	for lineNo := 1; ; lineNo++ {
+		line, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// a record without the trailing newline is a torn write from a crash, drop it
+			return valid, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		valid += int64(len(line))
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var rec walRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return 0, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if err := s.applyWalRecord(rec); err != nil {
+			return 0, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+	}
+}
+
+func (s *InMemAccountRepository) applyWalRecord(rec walRecord) error {
+	switch rec.Op {
+	case walOpAddGroup, walOpUpdateGroup:
+		if rec.Group == nil {
+			return fmt.Errorf("%s: missing group", rec.Op)
+		}
+		g := *rec.Group
+		s.groups[g.Groupname] = &g
+	case walOpDeleteGroup:
+		delete(s.groups, rec.Name)
+	case walOpAddUser, walOpUpdateUser:
+		if rec.User == nil {
+			return fmt.Errorf("%s: missing user", rec.Op)
+		}
+		u := rec.User.UserInfo
+		u.Password = rec.User.Password
+		u.PasswordIsHash = rec.User.PasswordIsHash
+		s.users[u.Username] = &u
+	case walOpDeleteUser:
+		delete(s.users, rec.Name)
+	case walOpDeleteUsers:
+		for _, name := range rec.Names {
+			delete(s.users, name)
+		}
+	default:
+		return fmt.Errorf("unknown wal op %q", rec.Op)
+	}
+	return nil
+}
+
+// journal appends the mutation to the WAL before it is applied in memory: a failed write is
+// truncated away and returned, so the mutation is dropped from both. The caller must hold the write lock.
+func (s *InMemAccountRepository) journal(rec walRecord) error {
+	if s.wal == nil {
+		return nil
+	}
+	if s.walErr != nil {
+		return s.walErr
+	}
+	if s.cfg.WalCompactBytes > 0 && s.walSize > s.cfg.WalCompactBytes {
+		// the memory matches the journal here, the mutation isn't applied yet
+		if err := s.compactWAL(); err != nil {
+			log.Printf("WARNING: cannot compact wal %s, still appending: %v", s.cfg.WalPath, err)
+		}
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if _, err = s.wal.Write(data); err != nil {
+		err = fmt.Errorf("wal write: %w", err)
+	} else if err = s.wal.Sync(); err != nil {
+		err = fmt.Errorf("wal sync: %w", err)
+	}
+	if err != nil {
+		if truncErr := s.wal.Truncate(s.walSize); truncErr != nil {
+			s.walErr = fmt.Errorf("wal unusable, a failed write couldn't be rolled back: %w", truncErr)
+		}
+		return err
+	}
+	s.walSize += int64(len(data))
+	return nil
+}
+
+// Close releases the WAL file, if any.
+func (s *InMemAccountRepository) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.wal == nil {
+		return nil
+	}
+	err := s.wal.Close()
+	s.wal = nil
+	return err
+}
@@ -1,12 +1,14 @@
 package accounts
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"fs-access-api/internal/app/config"
 	"fs-access-api/internal/app/ports"
+	"log"
 	"strings"
 	"time"
 
@@ -19,6 +21,7 @@ type MySQLAccountRepository struct {
 	bootstrap    bool
 	db           *sql.DB
 	queryTimeout time.Duration
+	healthQuery  string
 }
 
 // Enforce compile-time conformance to the interface
@@ -32,7 +35,9 @@ func NewMySQLAccountRepository(cfg config.AccountRepositoryMySqlConfig, common c
 
 	tlsName := ""
 	dsnExtra := "parseTime=true&charset=utf8mb4,utf8&collation=utf8mb4_unicode_ci"
-	if !cfg.IgnoreSSL {
+	if cfg.IgnoreSSL {
+		log.Printf("WARNING: account_repository.mysql.ignore_ssl is set, the database connection (credentials included) is not encrypted")
+	} else {
 		name, err := registerMySQLTLSFromCA(cfg.SSLCaPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to register TLS config: %w", err)
@@ -44,20 +49,21 @@ func NewMySQLAccountRepository(cfg config.AccountRepositoryMySqlConfig, common c
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?%s",
 		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Database, dsnExtra)
 
-	db, err := sql.Open("mysql", dsn)
+	db, err := openDB("mysql", dsn, common.LogQueries)
 	if err != nil {
 		return nil, fmt.Errorf("sql.Open: %w", err)
 	}
-	// Sensible pool defaults; adjust for your workload
-	db.SetMaxOpenConns(20)
-	db.SetMaxIdleConns(10)
-	db.SetConnMaxLifetime(30 * time.Minute)
+	// Sensible pool defaults, account_repository.mysql overrides them for your workload
+	db.SetMaxOpenConns(cmp.Or(cfg.MaxOpenConns, 20))
+	db.SetMaxIdleConns(cmp.Or(cfg.MaxIdleConns, 10))
+	db.SetConnMaxLifetime(cmp.Or(cfg.ConnMaxLifetime, 30*time.Minute))
 
 	repo := &MySQLAccountRepository{
 		common:       common,
 		bootstrap:    bootstrap,
 		db:           db,
 		queryTimeout: cfg.QueryTimeout,
+		healthQuery:  cfg.HealthCheckQuery,
 	}
 
 	if bootstrap {
@@ -85,8 +91,10 @@ func (s *MySQLAccountRepository) initSchema() error {
 		`CREATE TABLE IF NOT EXISTS group_info (
 			groupname   VARCHAR(128)  NOT NULL,
 			gid         INT UNSIGNED  NOT NULL,
-			description VARCHAR(255)  NULL,
+			description TEXT          NULL,
 			home        VARCHAR(1024) NOT NULL,
+			quota_bytes BIGINT UNSIGNED NULL,
+			version     BIGINT UNSIGNED NOT NULL DEFAULT 1,
 			PRIMARY KEY (groupname)
 		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;`,
 
@@ -95,12 +103,14 @@ func (s *MySQLAccountRepository) initSchema() error {
 			uid         INT UNSIGNED  NOT NULL,
 			groupname   VARCHAR(128)  NOT NULL,
 			password    VARCHAR(255)  NOT NULL,
-			description VARCHAR(255)  NULL,
+			description TEXT          NULL,
+			email       VARCHAR(254)  NULL,
 			home        VARCHAR(1024) NOT NULL,
 			expiration  DATETIME      NULL,
 			disabled    TINYINT(1)    NOT NULL DEFAULT 0,
+			version     BIGINT UNSIGNED NOT NULL DEFAULT 1,
+			updated_at  DATETIME(6)   NULL,
 			PRIMARY KEY (username),
-			UNIQUE KEY user_info_uid_uq (uid),
 			CONSTRAINT user_info_groupname_fk
 				FOREIGN KEY (groupname) REFERENCES group_info (groupname)
 				ON UPDATE CASCADE ON DELETE RESTRICT
@@ -117,11 +127,54 @@ func (s *MySQLAccountRepository) initSchema() error {
 			return err
 		}
 	}
+	// Duplicate UIDs only affect a schema without a UID index yet, an existing index is kept.
+	uidIndex, uidUnique := "user_info_uid_uq", true
+	if s.common.AllowDuplicateUIDs {
+		uidIndex, uidUnique = "user_info_uid_idx", false
+	}
+	if err := createIndexIfMissing(ctx, tx, SQLDialectMySQL, "user_info", "uid", uidIndex, uidUnique); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	// Optimistic concurrency version, missing in schemas created before it was introduced.
+	for _, table := range []string{"group_info", "user_info"} {
+		if err := addColumnIfMissing(ctx, tx, SQLDialectMySQL, table, "version", "BIGINT UNSIGNED NOT NULL DEFAULT 1"); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	// Descriptions of schemas created as VARCHAR(255), widened to TEXT once.
+	for _, table := range []string{"group_info", "user_info"} {
+		if err := modifyColumnUnlessType(ctx, tx, table, "description", "text", "TEXT NULL"); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	// Group quota, missing in schemas created before it was introduced.
+	if err := addColumnIfMissing(ctx, tx, SQLDialectMySQL, "group_info", "quota_bytes", "BIGINT UNSIGNED NULL"); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	// User update time, missing in schemas created before it was introduced.
+	if err := addColumnIfMissing(ctx, tx, SQLDialectMySQL, "user_info", "updated_at", "DATETIME(6) NULL"); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	// User email, missing in schemas created before it was introduced.
+	if err := addColumnIfMissing(ctx, tx, SQLDialectMySQL, "user_info", "email", "VARCHAR(254) NULL"); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
 	return tx.Commit()
 }
 
+// Close releases the connection pool.
+func (s *MySQLAccountRepository) Close() error {
+	return s.db.Close()
+}
+
 func (s *MySQLAccountRepository) HealthCheck() error {
-	if err := pingWithTimeout(s.db, time.Second); err != nil {
+	if err := healthCheckWithTimeout(s.db, s.healthQuery, time.Second); err != nil {
 		return fmt.Errorf("database unhealthy: %w", err)
 	}
 	return nil
@@ -144,13 +197,17 @@ func (s *MySQLAccountRepository) GetInfo() (string, error) {
 	return msg, nil
 }
 
+func (s *MySQLAccountRepository) Capabilities() ports.RepoCapabilities {
+	return ports.RepoCapabilities{SupportsTransactions: true}
+}
+
 // --- Groups ---
 
 func (s *MySQLAccountRepository) ListGroups() ([]ports.GroupInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
-	const q = `SELECT groupname, gid, description, home FROM group_info ORDER BY groupname;`
+	const q = `SELECT groupname, gid, description, home, quota_bytes, version FROM group_info ORDER BY groupname;`
 	rows, err := s.db.QueryContext(ctx, q)
 	if err != nil {
 		return nil, err
@@ -170,11 +227,17 @@ func (s *MySQLAccountRepository) ListGroups() ([]ports.GroupInfo, error) {
 	return out, rows.Err()
 }
 
+func (s *MySQLAccountRepository) ListEmptyGroups() ([]ports.GroupInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
+	defer cancel()
+	return listEmptyGroups(ctx, s.db)
+}
+
 func (s *MySQLAccountRepository) GetGroup(name string) (ports.GroupInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
-	const q = `SELECT groupname, gid, description, home FROM group_info WHERE groupname = ?;`
+	const q = `SELECT groupname, gid, description, home, quota_bytes, version FROM group_info WHERE groupname = ?;`
 	row := s.db.QueryRowContext(ctx, q, name)
 	u, err := scanGroupInfo(row.Scan)
 	if err != nil {
@@ -197,8 +260,11 @@ func (s *MySQLAccountRepository) AddGroup(group ports.GroupInfo) (ports.GroupInf
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
-	const q = `INSERT INTO group_info (groupname, gid, description, home) VALUES (?, ?, ?, ?);`
-	_, err := s.db.ExecContext(ctx, q, group.Groupname, group.GID, group.Description, group.Home)
+	if err := checkGroupDescriptionUnique(ctx, s.db, s.common, group); err != nil {
+		return ports.GroupInfo{}, err
+	}
+	const q = `INSERT INTO group_info (groupname, gid, description, home, quota_bytes) VALUES (?, ?, ?, ?, ?);`
+	_, err := s.db.ExecContext(ctx, q, group.Groupname, group.GID, group.Description, group.Home, uint64OrNil(group.QuotaBytes))
 	if err != nil {
 		if isDuplicateMySQL(err) {
 			return ports.GroupInfo{}, ports.ErrAlreadyExists
@@ -212,14 +278,19 @@ func (s *MySQLAccountRepository) UpdateGroup(group ports.GroupInfo) (ports.Group
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
-	const q = `UPDATE group_info SET gid = ?, description = ?, home = ? WHERE groupname = ?;`
-	res, err := s.db.ExecContext(ctx, q, group.GID, group.Description, group.Home, group.Groupname)
+	if err := checkGroupDescriptionUnique(ctx, s.db, s.common, group); err != nil {
+		return ports.GroupInfo{}, err
+	}
+	const q = `UPDATE group_info SET gid = ?, description = ?, home = ?, quota_bytes = ?, version = version + 1
+	           WHERE groupname = ? AND (? = 0 OR version = ?);`
+	res, err := s.db.ExecContext(ctx, q, group.GID, group.Description, group.Home, uint64OrNil(group.QuotaBytes), group.Groupname, group.Version, group.Version)
 	if err != nil {
 		return ports.GroupInfo{}, err
 	}
 	aff, _ := res.RowsAffected()
 	if aff == 0 {
-		return ports.GroupInfo{}, ports.ErrNotFound
+		_, err = s.GetGroup(group.Groupname)
+		return ports.GroupInfo{}, versionMissErr(err)
 	}
 	return s.GetGroup(group.Groupname)
 }
@@ -228,6 +299,9 @@ func (s *MySQLAccountRepository) DeleteGroup(name string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
+	if err := checkGroupEmpty(ctx, s.db, name); err != nil {
+		return err
+	}
 	const q = `DELETE FROM group_info WHERE groupname = ?;`
 	res, err := s.db.ExecContext(ctx, q, name)
 	if err != nil {
@@ -246,7 +320,7 @@ func (s *MySQLAccountRepository) ListUsers() ([]ports.UserInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
-	const q = `SELECT username, uid, groupname, password, description, home, expiration, disabled FROM user_info ORDER BY groupname`
+	const q = `SELECT username, uid, groupname, password, description, email, home, expiration, disabled, version, updated_at FROM user_info ORDER BY username;`
 	rows, err := s.db.QueryContext(ctx, q)
 	if err != nil {
 		return nil, err
@@ -266,11 +340,17 @@ func (s *MySQLAccountRepository) ListUsers() ([]ports.UserInfo, error) {
 	return out, rows.Err()
 }
 
+func (s *MySQLAccountRepository) ListUsersPage(offset, limit int, sort string) ([]ports.UserInfo, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
+	defer cancel()
+	return listUsersPage(ctx, s.db, SQLDialectMySQL, offset, limit, sort)
+}
+
 func (s *MySQLAccountRepository) GetUser(name string) (ports.UserInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
-	const q = `SELECT username, uid, groupname, password, description, home, expiration, disabled FROM user_info WHERE username = ?;`
+	const q = `SELECT username, uid, groupname, password, description, email, home, expiration, disabled, version, updated_at FROM user_info WHERE username = ?;`
 	row := s.db.QueryRowContext(ctx, q, name)
 	u, err := scanUserInfo(row.Scan, SQLDialectMySQL)
 	if err != nil {
@@ -301,15 +381,13 @@ func (s *MySQLAccountRepository) AddUser(user ports.UserInfo) (ports.UserInfo, e
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
-	const q = `INSERT INTO user_info (username, uid, groupname, password, description, home, expiration, disabled) VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
+	const q = `INSERT INTO user_info (username, uid, groupname, password, description, email, home, expiration, disabled, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
 
 	_, err := s.db.ExecContext(ctx, q,
-		user.Username, user.UID, user.Groupname, user.Password, user.Description, user.Home, user.Expiration, boolToInt(user.Disabled))
+		user.Username, user.UID, user.Groupname, user.Password, user.Description, user.Email, user.Home, user.Expiration, boolToInt(user.Disabled),
+		updatedAtArg(SQLDialectMySQL, nowUTC()))
 	if err != nil {
-		if isDuplicateMySQL(err) {
-			return ports.UserInfo{}, ports.ErrAlreadyExists
-		}
-		return ports.UserInfo{}, err
+		return ports.UserInfo{}, duplicateUserErr(err, user, isDuplicateMySQL)
 	}
 
 	// Return what is stored (including normalized fields)
@@ -317,26 +395,48 @@ func (s *MySQLAccountRepository) AddUser(user ports.UserInfo) (ports.UserInfo, e
 }
 
 func (s *MySQLAccountRepository) UpdateUser(user ports.UserInfo) (ports.UserInfo, error) {
-	// Must not change password: we fetch existing hash and keep it.
 	existing, err := s.GetUser(user.Username)
 	if err != nil {
 		return ports.UserInfo{}, err
 	}
+	if user.Password == "" {
+		user.Password = existing.Password
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
-	const q = `UPDATE user_info SET uid = ?, groupname = ?, password = ?, description = ?, home = ?, expiration = ?, disabled = ? WHERE username = ?;`
-	_, err = s.db.ExecContext(ctx, q,
-		user.UID, user.Groupname, user.Password, user.Description, user.Home, user.Expiration, boolToInt(user.Disabled), user.Username)
+	const q = `UPDATE user_info SET uid = ?, groupname = ?, password = ?, description = ?, email = ?, home = ?, expiration = ?, disabled = ?, version = version + 1, updated_at = ?
+	           WHERE username = ? AND (? = 0 OR version = ?);`
+	res, err := s.db.ExecContext(ctx, q,
+		user.UID, user.Groupname, user.Password, user.Description, user.Email, user.Home, user.Expiration, boolToInt(user.Disabled),
+		updatedAtArg(SQLDialectMySQL, nowUTC()), user.Username, user.Version, user.Version)
 	if err != nil {
-		return ports.UserInfo{}, err
+		return ports.UserInfo{}, duplicateUserErr(err, user, isDuplicateMySQL)
+	}
+	aff, _ := res.RowsAffected()
+	if aff == 0 {
+		_, err = s.GetUser(user.Username)
+		return ports.UserInfo{}, versionMissErr(err)
 	}
-
-	user.Password = existing.Password
 	return s.GetUser(user.Username)
 }
 
+func (s *MySQLAccountRepository) TouchUser(name string) (ports.UserInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
+	defer cancel()
+
+	const q = `UPDATE user_info SET updated_at = ? WHERE username = ?;`
+	res, err := s.db.ExecContext(ctx, q, updatedAtArg(SQLDialectMySQL, nowUTC()), name)
+	if err != nil {
+		return ports.UserInfo{}, err
+	}
+	if aff, _ := res.RowsAffected(); aff == 0 {
+		return ports.UserInfo{}, ports.ErrNotFound
+	}
+	return s.GetUser(name)
+}
+
 func (s *MySQLAccountRepository) DeleteUser(name string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
@@ -353,6 +453,25 @@ func (s *MySQLAccountRepository) DeleteUser(name string) error {
 	return nil
 }
 
+func (s *MySQLAccountRepository) DeleteUsers(names []string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := deleteUsersTx(ctx, tx, names)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
 func (s *MySQLAccountRepository) GetUserAuthzInfo(username string) (ports.UserAuthzInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
@@ -375,6 +494,7 @@ func (s *MySQLAccountRepository) GetUserAuthzInfo(username string) (ports.UserAu
 		}
 		return res, err
 	}
-	res.Locked = ports.IsUserLocked(disabled != 0, nullTimeToPtr(expiration))
+	res.Disabled = disabled != 0
+	res.Expiration = nullTimeToPtr(expiration)
 	return res, nil
 }
@@ -0,0 +1,101 @@
+package accounts_test
+
+import (
+	"database/sql"
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// mysqlTestDSNEnv names a disposable MySQL database (user:password@tcp(host:port)/database),
+// its group_info and user_info tables are dropped by the tests; they're skipped without it.
+const mysqlTestDSNEnv = "FSAA_TEST_MYSQL_DSN"
+
+var _ = Describe("MySQLAccountRepository schema", func() {
+	var (
+		dsn string
+		cfg config.AccountRepositoryMySqlConfig
+	)
+
+	BeforeEach(func() {
+		dsn = os.Getenv(mysqlTestDSNEnv)
+		if dsn == "" {
+			Skip(mysqlTestDSNEnv + " not set")
+		}
+		parsed, err := mysql.ParseDSN(dsn)
+		Expect(err).ToNot(HaveOccurred())
+		host, port, err := net.SplitHostPort(parsed.Addr)
+		Expect(err).ToNot(HaveOccurred())
+		cfg = config.AccountRepositoryMySqlConfig{
+			Database: parsed.DBName, User: parsed.User, Password: parsed.Passwd, Host: host, IgnoreSSL: true,
+			QueryTimeout: 5 * time.Second, HealthCheckQuery: "SELECT 1",
+		}
+		cfg.Port, err = strconv.Atoi(port)
+		Expect(err).ToNot(HaveOccurred())
+
+		db, err := sql.Open("mysql", dsn)
+		Expect(err).ToNot(HaveOccurred())
+		defer db.Close()
+		for _, q := range []string{"DROP TABLE IF EXISTS user_info", "DROP TABLE IF EXISTS user_info_gone", "DROP TABLE IF EXISTS group_info"} {
+			_, err = db.Exec(q)
+			Expect(err).ToNot(HaveOccurred())
+		}
+	})
+
+	open := func(allowDuplicates bool) *accounts.MySQLAccountRepository {
+		repo, err := accounts.NewMySQLAccountRepository(cfg, config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000, AllowDuplicateUIDs: allowDuplicates}, true)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(repo.Close)
+		return repo
+	}
+	user := func(name string, uid uint32) ports.UserInfo {
+		return ports.UserInfo{Username: name, UID: uid, Groupname: "legacy", Password: "x", PasswordIsHash: true, Home: name}
+	}
+
+	It("keeps the UID index it was created with across restarts", func() {
+		repo := open(false)
+		_, err := repo.AddGroup(ports.GroupInfo{Groupname: "legacy", GID: 5000, Home: "legacy"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddUser(user("alice", 3000))
+		Expect(err).ToNot(HaveOccurred())
+
+		open(false)
+		repo = open(true)
+		_, err = repo.AddUser(user("alias", 3000))
+		Expect(err).To(MatchError(ports.ErrUIDTaken))
+	})
+
+	It("imports users sharing a UID into a schema created with allow_duplicate_uids", func() {
+		repo := open(true)
+		_, err := repo.AddGroup(ports.GroupInfo{Groupname: "legacy", GID: 5000, Home: "legacy"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddUser(user("alice", 3000))
+		Expect(err).ToNot(HaveOccurred())
+
+		repo = open(false)
+		_, err = repo.AddUser(user("alias", 3000))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("turns unhealthy once the table of the health check query becomes unavailable", func() {
+		cfg.HealthCheckQuery = "SELECT 1 FROM user_info LIMIT 1"
+		repo := open(false)
+		Expect(repo.HealthCheck()).To(Succeed())
+
+		db, err := sql.Open("mysql", dsn)
+		Expect(err).ToNot(HaveOccurred())
+		defer db.Close()
+		_, err = db.Exec("RENAME TABLE user_info TO user_info_gone")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(repo.HealthCheck()).To(MatchError(ContainSubstring("user_info")))
+	})
+})
@@ -0,0 +1,70 @@
+package accounts_test
+
+import (
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Account repositories list ordering", func() {
+	common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+	groupNames := []string{"zeta", "Alpha", "beta", "alpha", "b-2"}
+	userNames := []string{"mallory", "Bob", "alice", "alice2", "carol"}
+
+	seed := func(repo ports.AccountRepository) {
+		for i, name := range groupNames {
+			_, err := repo.AddGroup(ports.GroupInfo{Groupname: name, GID: uint32(3000 + i), Home: name})
+			Expect(err).ToNot(HaveOccurred())
+		}
+		for i, name := range userNames {
+			_, err := repo.AddUser(ports.UserInfo{Username: name, UID: uint32(4000 + i), Groupname: groupNames[i],
+				Password: "x", PasswordIsHash: true, Home: name})
+			Expect(err).ToNot(HaveOccurred())
+		}
+	}
+	names := func(repo ports.AccountRepository) ([]string, []string) {
+		groups, err := repo.ListGroups()
+		Expect(err).ToNot(HaveOccurred())
+		users, err := repo.ListUsers()
+		Expect(err).ToNot(HaveOccurred())
+		var gn, un []string
+		for _, g := range groups {
+			gn = append(gn, g.Groupname)
+		}
+		for _, u := range users {
+			un = append(un, u.Username)
+		}
+		return gn, un
+	}
+
+	It("sorts inmem results by name, stable across calls and equal to SQLite", func() {
+		inmem, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 100}, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		seed(inmem)
+		sqlite, err := accounts.NewSQLiteAccountRepository(config.AccountRepositorySqliteConfig{
+			DbFilePath:   filepath.Join(GinkgoT().TempDir(), "order.db"),
+			WriteTimeout: time.Second,
+			QueryTimeout: time.Second,
+		}, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		seed(sqlite)
+
+		groups, users := names(inmem)
+		Expect(groups).To(Equal([]string{"Alpha", "alpha", "b-2", "beta", "zeta"}))
+		Expect(users).To(Equal([]string{"Bob", "alice", "alice2", "carol", "mallory"}))
+		for range 10 {
+			g, u := names(inmem)
+			Expect(g).To(Equal(groups))
+			Expect(u).To(Equal(users))
+		}
+
+		sqliteGroups, sqliteUsers := names(sqlite)
+		Expect(sqliteGroups).To(Equal(groups))
+		Expect(sqliteUsers).To(Equal(users))
+	})
+})
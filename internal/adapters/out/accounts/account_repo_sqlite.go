@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"fs-access-api/internal/app/config"
 	"fs-access-api/internal/app/ports"
+	"math/rand/v2"
 	"os"
 	"path/filepath"
 	"strings"
@@ -44,7 +45,7 @@ func NewSQLiteAccountRepository(cfg config.AccountRepositorySqliteConfig, common
 		"&_pragma=busy_timeout(" + writersWait + ")" + // writers wait instead of erroring
 		"&_pragma=foreign_keys(ON)" // enforce referential integrity
 
-	db, err := sql.Open("sqlite", dsn)
+	db, err := openDB("sqlite", dsn, common.LogQueries)
 	if err != nil {
 		return nil, fmt.Errorf("sqlite open: %w", err)
 	}
@@ -83,7 +84,9 @@ func (s *SQLiteAccountRepository) initSchema() error {
 			groupname   TEXT PRIMARY KEY,
 			gid         INTEGER NOT NULL CHECK (gid BETWEEN 0 AND 4294967295),
 			description TEXT,
-			home        TEXT NOT NULL
+			home        TEXT NOT NULL,
+			quota_bytes INTEGER,
+			version     INTEGER NOT NULL DEFAULT 1
 		);`,
 
 		`CREATE TABLE IF NOT EXISTS user_info (
@@ -92,16 +95,17 @@ func (s *SQLiteAccountRepository) initSchema() error {
 			groupname   TEXT NOT NULL,
 			password    TEXT NOT NULL,
 			description TEXT,
+			email       TEXT,
 			home        TEXT NOT NULL,
 			expiration  TEXT,    -- RFC3339 or NULL
 			disabled    INTEGER NOT NULL DEFAULT 0 CHECK (disabled IN (0,1)),
+			version     INTEGER NOT NULL DEFAULT 1,
+			updated_at  TEXT,    -- RFC3339 with nanoseconds
 			FOREIGN KEY (groupname)
 				REFERENCES group_info(groupname)
 				ON UPDATE CASCADE ON DELETE RESTRICT,
 			CHECK (uid IS NULL OR (uid BETWEEN 0 AND 4294967295))
 		);`,
-
-		`CREATE UNIQUE INDEX IF NOT EXISTS idx_user_info_uid ON user_info(uid);`,
 	}
 
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -114,13 +118,67 @@ func (s *SQLiteAccountRepository) initSchema() error {
 			return err
 		}
 	}
+	// Duplicate UIDs only affect a schema without a UID index yet, an existing index is kept.
+	uidIndex, uidUnique := "idx_user_info_uid", true
+	if s.common.AllowDuplicateUIDs {
+		uidIndex, uidUnique = "idx_user_info_uid_nonunique", false
+	}
+	if err := createIndexIfMissing(ctx, tx, SQLDialectSQLite, "user_info", "uid", uidIndex, uidUnique); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	// Optimistic concurrency version, missing in schemas created before it was introduced.
+	for _, table := range []string{"group_info", "user_info"} {
+		if err := addColumnIfMissing(ctx, tx, SQLDialectSQLite, table, "version", "INTEGER NOT NULL DEFAULT 1"); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	// Group quota, missing in schemas created before it was introduced.
+	if err := addColumnIfMissing(ctx, tx, SQLDialectSQLite, "group_info", "quota_bytes", "INTEGER NULL"); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	// User update time, missing in schemas created before it was introduced.
+	if err := addColumnIfMissing(ctx, tx, SQLDialectSQLite, "user_info", "updated_at", "TEXT NULL"); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	// User email, missing in schemas created before it was introduced.
+	if err := addColumnIfMissing(ctx, tx, SQLDialectSQLite, "user_info", "email", "TEXT NULL"); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
 	return tx.Commit()
 }
 
+// retryOnBusy re-runs a write failing with SQLITE_BUSY, using exponential backoff with full jitter
+// so that contending instances don't retry in lockstep.
+func (s *SQLiteAccountRepository) retryOnBusy(ctx context.Context, op func() error) error {
+	err := op()
+	for attempt := 0; attempt < s.cfg.MaxWriteRetries && isBusySQLite(err); attempt++ {
+		backoff := s.cfg.RetryBaseDelay << attempt
+		delay := time.Duration(rand.Int64N(int64(backoff) + 1))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		err = op()
+	}
+	return err
+}
+
+// Close releases the database handle.
+func (s *SQLiteAccountRepository) Close() error {
+	return s.db.Close()
+}
+
 func (s *SQLiteAccountRepository) HealthCheck() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-	defer cancel()
-	return s.db.PingContext(ctx)
+	if err := healthCheckWithTimeout(s.db, s.cfg.HealthCheckQuery, time.Second); err != nil {
+		return fmt.Errorf("database unhealthy: %w", err)
+	}
+	return nil
 }
 
 func (s *SQLiteAccountRepository) GetInfo() (string, error) {
@@ -136,13 +194,17 @@ func (s *SQLiteAccountRepository) GetInfo() (string, error) {
 	return fmt.Sprintf("Connected to SQLite (%s) version: '%s', database time: '%s'", s.cfg.DbFilePath, ver, now), nil
 }
 
+func (s *SQLiteAccountRepository) Capabilities() ports.RepoCapabilities {
+	return ports.RepoCapabilities{SupportsTransactions: true}
+}
+
 // -------- Groups --------
 
 func (s *SQLiteAccountRepository) ListGroups() ([]ports.GroupInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
-	const q = `SELECT groupname, gid, description, home FROM group_info ORDER BY groupname;`
+	const q = `SELECT groupname, gid, description, home, quota_bytes, version FROM group_info ORDER BY groupname;`
 	rows, err := s.db.QueryContext(ctx, q)
 	if err != nil {
 		return nil, err
@@ -160,11 +222,17 @@ func (s *SQLiteAccountRepository) ListGroups() ([]ports.GroupInfo, error) {
 	return out, rows.Err()
 }
 
+func (s *SQLiteAccountRepository) ListEmptyGroups() ([]ports.GroupInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
+	defer cancel()
+	return listEmptyGroups(ctx, s.db)
+}
+
 func (s *SQLiteAccountRepository) GetGroup(name string) (ports.GroupInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
-	const q = `SELECT groupname, gid, description, home FROM group_info WHERE groupname = ?;`
+	const q = `SELECT groupname, gid, description, home, quota_bytes, version FROM group_info WHERE groupname = ?;`
 	row := s.db.QueryRowContext(ctx, q, name)
 	u, err := scanGroupInfo(row.Scan)
 	if err != nil {
@@ -187,8 +255,14 @@ func (s *SQLiteAccountRepository) AddGroup(group ports.GroupInfo) (ports.GroupIn
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
-	const q = `INSERT INTO group_info (groupname, gid, description, home) VALUES (?, ?, ?, ?);`
-	_, err := s.db.ExecContext(ctx, q, group.Groupname, group.GID, group.Description, group.Home)
+	if err := checkGroupDescriptionUnique(ctx, s.db, s.common, group); err != nil {
+		return ports.GroupInfo{}, err
+	}
+	const q = `INSERT INTO group_info (groupname, gid, description, home, quota_bytes) VALUES (?, ?, ?, ?, ?);`
+	err := s.retryOnBusy(ctx, func() error {
+		_, err := s.db.ExecContext(ctx, q, group.Groupname, group.GID, group.Description, group.Home, uint64OrNil(group.QuotaBytes))
+		return err
+	})
 	if err != nil {
 		if isDuplicateSQLite(err) {
 			return ports.GroupInfo{}, ports.ErrAlreadyExists
@@ -202,14 +276,23 @@ func (s *SQLiteAccountRepository) UpdateGroup(group ports.GroupInfo) (ports.Grou
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
-	const q = `UPDATE group_info SET gid = ?, description = ?, home = ? WHERE groupname = ?;`
-	res, err := s.db.ExecContext(ctx, q, group.GID, group.Description, group.Home, group.Groupname)
+	if err := checkGroupDescriptionUnique(ctx, s.db, s.common, group); err != nil {
+		return ports.GroupInfo{}, err
+	}
+	const q = `UPDATE group_info SET gid = ?, description = ?, home = ?, quota_bytes = ?, version = version + 1
+	           WHERE groupname = ? AND (? = 0 OR version = ?);`
+	var res sql.Result
+	err := s.retryOnBusy(ctx, func() (err error) {
+		res, err = s.db.ExecContext(ctx, q, group.GID, group.Description, group.Home, uint64OrNil(group.QuotaBytes), group.Groupname, group.Version, group.Version)
+		return err
+	})
 	if err != nil {
 		return ports.GroupInfo{}, err
 	}
 	aff, _ := res.RowsAffected()
 	if aff == 0 {
-		return ports.GroupInfo{}, ports.ErrNotFound
+		_, err = s.GetGroup(group.Groupname)
+		return ports.GroupInfo{}, versionMissErr(err)
 	}
 	return s.GetGroup(group.Groupname)
 }
@@ -218,8 +301,15 @@ func (s *SQLiteAccountRepository) DeleteGroup(name string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
+	if err := checkGroupEmpty(ctx, s.db, name); err != nil {
+		return err
+	}
 	const q = `DELETE FROM group_info WHERE groupname = ?;`
-	res, err := s.db.ExecContext(ctx, q, name)
+	var res sql.Result
+	err := s.retryOnBusy(ctx, func() (err error) {
+		res, err = s.db.ExecContext(ctx, q, name)
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -236,7 +326,7 @@ func (s *SQLiteAccountRepository) ListUsers() ([]ports.UserInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
-	const q = `SELECT username, uid, groupname, password, description, home, expiration, disabled FROM user_info ORDER BY username;`
+	const q = `SELECT username, uid, groupname, password, description, email, home, expiration, disabled, version, updated_at FROM user_info ORDER BY username;`
 	rows, err := s.db.QueryContext(ctx, q)
 	if err != nil {
 		return nil, err
@@ -254,11 +344,17 @@ func (s *SQLiteAccountRepository) ListUsers() ([]ports.UserInfo, error) {
 	return out, rows.Err()
 }
 
+func (s *SQLiteAccountRepository) ListUsersPage(offset, limit int, sort string) ([]ports.UserInfo, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
+	defer cancel()
+	return listUsersPage(ctx, s.db, SQLDialectSQLite, offset, limit, sort)
+}
+
 func (s *SQLiteAccountRepository) GetUser(name string) (ports.UserInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
-	const q = `SELECT username, uid, groupname, password, description, home, expiration, disabled FROM user_info WHERE username = ?;`
+	const q = `SELECT username, uid, groupname, password, description, email, home, expiration, disabled, version, updated_at FROM user_info WHERE username = ?;`
 	row := s.db.QueryRowContext(ctx, q, name)
 	u, err := scanUserInfo(row.Scan, SQLDialectSQLite)
 	if err != nil {
@@ -288,50 +384,86 @@ func (s *SQLiteAccountRepository) AddUser(user ports.UserInfo) (ports.UserInfo,
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
-	const q = `INSERT INTO user_info (username, uid, groupname, password, description, home, expiration, disabled) VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
-	_, err := s.db.ExecContext(ctx, q,
-		user.Username, user.UID, user.Groupname, user.Password,
-		stringOrNil(user.Description), user.Home, timeToTimeStringOrNil(user.Expiration), boolToInt(user.Disabled),
-	)
+	const q = `INSERT INTO user_info (username, uid, groupname, password, description, email, home, expiration, disabled, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+	err := s.retryOnBusy(ctx, func() error {
+		_, err := s.db.ExecContext(ctx, q,
+			user.Username, user.UID, user.Groupname, user.Password,
+			stringOrNil(user.Description), stringOrNil(user.Email), user.Home, timeToTimeStringOrNil(user.Expiration), boolToInt(user.Disabled),
+			updatedAtArg(SQLDialectSQLite, nowUTC()),
+		)
+		return err
+	})
 	if err != nil {
-		if isDuplicateSQLite(err) {
-			return ports.UserInfo{}, ports.ErrAlreadyExists
-		}
 		// Could also be FK violation if group does not exist.
-		return ports.UserInfo{}, err
+		return ports.UserInfo{}, duplicateUserErr(err, user, isDuplicateSQLite)
 	}
 	return s.GetUser(user.Username)
 }
 
 func (s *SQLiteAccountRepository) UpdateUser(user ports.UserInfo) (ports.UserInfo, error) {
-	_, err := s.GetUser(user.Username)
+	existing, err := s.GetUser(user.Username)
 	if err != nil {
 		return ports.UserInfo{}, err
 	}
+	if user.Password == "" {
+		user.Password = existing.Password
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
 	const q = `UPDATE user_info
-	           SET uid = ?, groupname = ?,  password = ?, description = ?, home = ?, expiration = ?, disabled = ?
-	           WHERE username = ?;`
-	_, err = s.db.ExecContext(ctx, q,
-		user.UID, user.Groupname, user.Password,
-		stringOrNil(user.Description), user.Home, timeToTimeStringOrNil(user.Expiration), boolToInt(user.Disabled),
-		user.Username,
-	)
+	           SET uid = ?, groupname = ?,  password = ?, description = ?, email = ?, home = ?, expiration = ?, disabled = ?, version = version + 1, updated_at = ?
+	           WHERE username = ? AND (? = 0 OR version = ?);`
+	var res sql.Result
+	err = s.retryOnBusy(ctx, func() (err error) {
+		res, err = s.db.ExecContext(ctx, q,
+			user.UID, user.Groupname, user.Password,
+			stringOrNil(user.Description), stringOrNil(user.Email), user.Home, timeToTimeStringOrNil(user.Expiration), boolToInt(user.Disabled),
+			updatedAtArg(SQLDialectSQLite, nowUTC()), user.Username, user.Version, user.Version,
+		)
+		return err
+	})
 	if err != nil {
-		return ports.UserInfo{}, err
+		return ports.UserInfo{}, duplicateUserErr(err, user, isDuplicateSQLite)
+	}
+	aff, _ := res.RowsAffected()
+	if aff == 0 {
+		_, err = s.GetUser(user.Username)
+		return ports.UserInfo{}, versionMissErr(err)
 	}
 	return s.GetUser(user.Username)
 }
 
+func (s *SQLiteAccountRepository) TouchUser(name string) (ports.UserInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
+	defer cancel()
+
+	const q = `UPDATE user_info SET updated_at = ? WHERE username = ?;`
+	var res sql.Result
+	err := s.retryOnBusy(ctx, func() (err error) {
+		res, err = s.db.ExecContext(ctx, q, updatedAtArg(SQLDialectSQLite, nowUTC()), name)
+		return err
+	})
+	if err != nil {
+		return ports.UserInfo{}, err
+	}
+	if aff, _ := res.RowsAffected(); aff == 0 {
+		return ports.UserInfo{}, ports.ErrNotFound
+	}
+	return s.GetUser(name)
+}
+
 func (s *SQLiteAccountRepository) DeleteUser(name string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
 
 	const q = `DELETE FROM user_info WHERE username = ?;`
-	res, err := s.db.ExecContext(ctx, q, name)
+	var res sql.Result
+	err := s.retryOnBusy(ctx, func() (err error) {
+		res, err = s.db.ExecContext(ctx, q, name)
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -342,6 +474,28 @@ func (s *SQLiteAccountRepository) DeleteUser(name string) error {
 	return nil
 }
 
+func (s *SQLiteAccountRepository) DeleteUsers(names []string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
+	defer cancel()
+
+	var affected int
+	err := s.retryOnBusy(ctx, func() error {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if affected, err = deleteUsersTx(ctx, tx, names); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	})
+	if err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
 func (s *SQLiteAccountRepository) GetUserAuthzInfo(username string) (ports.UserAuthzInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), s.queryTimeout)
 	defer cancel()
@@ -365,6 +519,7 @@ func (s *SQLiteAccountRepository) GetUserAuthzInfo(username string) (ports.UserA
 		}
 		return ports.UserAuthzInfo{}, err
 	}
-	res.Locked = ports.IsUserLocked(disabled != 0, nullTimeStringToPtr(expiration))
+	res.Disabled = disabled != 0
+	res.Expiration = nullTimeStringToPtr(expiration)
 	return res, nil
 }
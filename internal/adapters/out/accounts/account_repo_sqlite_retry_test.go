@@ -0,0 +1,58 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fs-access-api/internal/app/config"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeSQLiteError int
+
+func (e fakeSQLiteError) Error() string { return "fake sqlite error" }
+func (e fakeSQLiteError) Code() int     { return int(e) }
+
+var _ = Describe("SQLiteAccountRepository busy retries", func() {
+	newRepo := func(retries int) *SQLiteAccountRepository {
+		return &SQLiteAccountRepository{cfg: config.AccountRepositorySqliteConfig{
+			MaxWriteRetries: retries,
+			RetryBaseDelay:  time.Millisecond,
+		}}
+	}
+
+	It("retries busy errors until the write succeeds", func() {
+		calls := 0
+		err := newRepo(3).retryOnBusy(context.Background(), func() error {
+			calls++
+			if calls < 3 {
+				return fakeSQLiteError(5) // SQLITE_BUSY
+			}
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal(3))
+	})
+
+	It("gives up after max_write_retries", func() {
+		calls := 0
+		err := newRepo(2).retryOnBusy(context.Background(), func() error {
+			calls++
+			return fakeSQLiteError(5 | 2<<8) // SQLITE_BUSY_SNAPSHOT
+		})
+		Expect(isBusySQLite(err)).To(BeTrue())
+		Expect(calls).To(Equal(3))
+	})
+
+	It("does not retry other errors", func() {
+		calls := 0
+		err := newRepo(3).retryOnBusy(context.Background(), func() error {
+			calls++
+			return errors.New("boom")
+		})
+		Expect(err).To(MatchError("boom"))
+		Expect(calls).To(Equal(1))
+	})
+})
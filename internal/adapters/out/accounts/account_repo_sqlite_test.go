@@ -0,0 +1,340 @@
+package accounts_test
+
+import (
+	"database/sql"
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func newSQLiteRepo(common config.AccountRepositoryCommonConfig) *accounts.SQLiteAccountRepository {
+	cfg := config.AccountRepositorySqliteConfig{
+		DbFilePath:   filepath.Join(GinkgoT().TempDir(), "fs-access.db"),
+		WriteTimeout: time.Second,
+		QueryTimeout: time.Second,
+	}
+	repo, err := accounts.NewSQLiteAccountRepository(cfg, common, true)
+	Expect(err).ToNot(HaveOccurred())
+	_, err = repo.AddGroup(ports.GroupInfo{Groupname: "legacy", GID: 5000, Home: "legacy"})
+	Expect(err).ToNot(HaveOccurred())
+	return repo
+}
+
+var _ = Describe("SQLiteAccountRepository health check query", func() {
+	newCfg := func(query string) config.AccountRepositorySqliteConfig {
+		return config.AccountRepositorySqliteConfig{
+			DbFilePath:       filepath.Join(GinkgoT().TempDir(), "fs-access.db"),
+			WriteTimeout:     time.Second,
+			QueryTimeout:     time.Second,
+			HealthCheckQuery: query,
+		}
+	}
+
+	It("is healthy when the query reads an (empty) table", func() {
+		repo, err := accounts.NewSQLiteAccountRepository(newCfg("SELECT 1 FROM user_info LIMIT 1"), config.AccountRepositoryCommonConfig{}, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repo.HealthCheck()).To(Succeed())
+	})
+
+	It("refuses to start when the query fails", func() {
+		_, err := accounts.NewSQLiteAccountRepository(newCfg("SELECT 1 FROM missing_table LIMIT 1"), config.AccountRepositoryCommonConfig{}, true)
+		Expect(err).To(MatchError(ContainSubstring("database unhealthy")))
+		Expect(err).To(MatchError(ContainSubstring("missing_table")))
+	})
+
+	It("turns unhealthy once the queried table becomes unavailable", func() {
+		cfg := newCfg("SELECT 1 FROM user_info LIMIT 1")
+		repo, err := accounts.NewSQLiteAccountRepository(cfg, config.AccountRepositoryCommonConfig{}, true)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(repo.Close)
+		Expect(repo.HealthCheck()).To(Succeed())
+
+		db, err := sql.Open("sqlite", cfg.DbFilePath)
+		Expect(err).ToNot(HaveOccurred())
+		defer db.Close()
+		_, err = db.Exec(`ALTER TABLE user_info RENAME TO user_info_gone`)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = repo.HealthCheck()
+		Expect(err).To(MatchError(ContainSubstring("database unhealthy")))
+		Expect(err).To(MatchError(ContainSubstring("user_info")))
+	})
+})
+
+var _ = Describe("SQLiteAccountRepository duplicate UIDs", func() {
+	user := func(name string, uid uint32) ports.UserInfo {
+		return ports.UserInfo{Username: name, UID: uid, Groupname: "legacy", Password: "x", PasswordIsHash: true, Home: name}
+	}
+
+	It("rejects a second user with the same UID by default", func() {
+		repo := newSQLiteRepo(config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000})
+		_, err := repo.AddUser(user("alice", 3000))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddUser(user("alias", 3000))
+		Expect(err).To(MatchError(ports.ErrUIDTaken))
+		_, err = repo.AddUser(user("alice", 3001))
+		Expect(err).To(MatchError(ports.ErrAlreadyExists))
+		Expect(err).NotTo(MatchError(ports.ErrUIDTaken))
+
+		bob, err := repo.AddUser(user("bob", 3002))
+		Expect(err).ToNot(HaveOccurred())
+		bob.UID = 3000
+		_, err = repo.UpdateUser(bob)
+		Expect(err).To(MatchError(ports.ErrUIDTaken))
+		Expect(err.Error()).To(ContainSubstring("UID 3000"))
+	})
+
+	It("imports users sharing a UID when allow_duplicate_uids is set", func() {
+		repo := newSQLiteRepo(config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000, AllowDuplicateUIDs: true})
+		_, err := repo.AddUser(user("alice", 3000))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddUser(user("alias", 3000))
+		Expect(err).ToNot(HaveOccurred())
+
+		next, err := repo.GetNextUID()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(next).To(Equal(uint32(3001)))
+	})
+
+	It("keeps the UID index it was created with across restarts", func() {
+		cfg := config.AccountRepositorySqliteConfig{
+			DbFilePath:   filepath.Join(GinkgoT().TempDir(), "fs-access.db"),
+			WriteTimeout: time.Second,
+			QueryTimeout: time.Second,
+		}
+		open := func(allowDuplicates bool) *accounts.SQLiteAccountRepository {
+			repo, err := accounts.NewSQLiteAccountRepository(cfg, config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000, AllowDuplicateUIDs: allowDuplicates}, true)
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(repo.Close)
+			return repo
+		}
+		uidIndexes := func() []string {
+			db, err := sql.Open("sqlite", cfg.DbFilePath)
+			Expect(err).ToNot(HaveOccurred())
+			defer db.Close()
+			rows, err := db.Query(`SELECT il.name FROM pragma_index_list('user_info') AS il, pragma_index_info(il.name) AS ii WHERE ii.name = 'uid'`)
+			Expect(err).ToNot(HaveOccurred())
+			defer rows.Close()
+			var names []string
+			for rows.Next() {
+				var name string
+				Expect(rows.Scan(&name)).To(Succeed())
+				names = append(names, name)
+			}
+			return names
+		}
+
+		repo := open(false)
+		_, err := repo.AddGroup(ports.GroupInfo{Groupname: "legacy", GID: 5000, Home: "legacy"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddUser(user("alice", 3000))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(uidIndexes()).To(Equal([]string{"idx_user_info_uid"}))
+
+		open(false)
+		repo = open(true)
+		Expect(uidIndexes()).To(Equal([]string{"idx_user_info_uid"}))
+		_, err = repo.AddUser(user("alias", 3000))
+		Expect(err).To(MatchError(ports.ErrUIDTaken))
+	})
+})
+
+var _ = Describe("SQLiteAccountRepository optimistic concurrency", func() {
+	common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+
+	It("rejects a stale user version and increments on a fresh one", func() {
+		repo := newSQLiteRepo(common)
+		u, err := repo.AddUser(ports.UserInfo{Username: "alice", UID: 3000, Groupname: "legacy", Password: "x", PasswordIsHash: true, Home: "alice"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(u.Version).To(Equal(uint64(1)))
+
+		first := u
+		first.Disabled = true
+		updated, err := repo.UpdateUser(first)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.Version).To(Equal(uint64(2)))
+
+		stale := u // still carries version 1
+		stale.Home = "elsewhere"
+		_, err = repo.UpdateUser(stale)
+		Expect(err).To(MatchError(ports.ErrVersionMismatch))
+		Expect(err).To(MatchError(ports.ErrConflict))
+
+		fresh := updated
+		fresh.Home = "elsewhere"
+		updated, err = repo.UpdateUser(fresh)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.Version).To(Equal(uint64(3)))
+		Expect(updated.Home).To(Equal("elsewhere"))
+
+		_, err = repo.UpdateUser(ports.UserInfo{Username: "ghost", UID: 3001, Groupname: "legacy", Password: "x", Version: 1})
+		Expect(err).To(MatchError(ports.ErrNotFound))
+	})
+
+	It("keeps the stored password when the update leaves it empty", func() {
+		repo := newSQLiteRepo(common)
+		_, err := repo.AddUser(ports.UserInfo{Username: "alice", UID: 3000, Groupname: "legacy", Password: "$5$salt$hash", PasswordIsHash: true, Home: "alice"})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = repo.UpdateUser(ports.UserInfo{Username: "alice", UID: 3000, Groupname: "legacy", Home: "alice", Disabled: true})
+		Expect(err).ToNot(HaveOccurred())
+		stored, err := repo.GetUser("alice")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stored.Disabled).To(BeTrue())
+		Expect(stored.Password).To(Equal("$5$salt$hash"))
+
+		stored.Password = "$5$salt$other"
+		updated, err := repo.UpdateUser(stored)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.Password).To(Equal("$5$salt$other"))
+	})
+
+	It("rejects a stale group version and increments on a fresh one", func() {
+		repo := newSQLiteRepo(common)
+		g, err := repo.GetGroup("legacy")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(g.Version).To(Equal(uint64(1)))
+
+		g.Home = "legacy-2"
+		updated, err := repo.UpdateGroup(g)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.Version).To(Equal(uint64(2)))
+
+		_, err = repo.UpdateGroup(g) // version 1 again
+		Expect(err).To(MatchError(ports.ErrVersionMismatch))
+	})
+
+	It("adds the version column to a schema created before it existed", func() {
+		dbPath := filepath.Join(GinkgoT().TempDir(), "old.db")
+		db, err := sql.Open("sqlite", dbPath)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = db.Exec(`CREATE TABLE group_info (groupname TEXT PRIMARY KEY, gid INTEGER NOT NULL, description TEXT, home TEXT NOT NULL);
+			INSERT INTO group_info (groupname, gid, home) VALUES ('legacy', 5000, 'legacy');`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(db.Close()).To(Succeed())
+
+		cfg := config.AccountRepositorySqliteConfig{DbFilePath: dbPath, WriteTimeout: time.Second, QueryTimeout: time.Second}
+		repo, err := accounts.NewSQLiteAccountRepository(cfg, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		g, err := repo.GetGroup("legacy")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(g.Version).To(Equal(uint64(1)))
+	})
+})
+
+var _ = Describe("SQLiteAccountRepository group quota", func() {
+	It("stores, updates and clears quota_bytes", func() {
+		repo := newSQLiteRepo(config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000})
+		quota := uint64(1 << 40)
+		g, err := repo.AddGroup(ports.GroupInfo{Groupname: "proj", GID: 5001, Home: "proj", QuotaBytes: &quota})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(g.QuotaBytes).To(HaveValue(Equal(quota)))
+
+		g.QuotaBytes = nil
+		g, err = repo.UpdateGroup(g)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(g.QuotaBytes).To(BeNil())
+
+		legacy, err := repo.GetGroup("legacy")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(legacy.QuotaBytes).To(BeNil())
+	})
+})
+
+var _ = Describe("SQLiteAccountRepository bulk user deletion", func() {
+	It("deletes all the users in one transaction or none", func() {
+		repo := newSQLiteRepo(config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000})
+		for i, name := range []string{"u1", "u2", "u3"} {
+			_, err := repo.AddUser(ports.UserInfo{Username: name, UID: uint32(3001 + i), Groupname: "legacy", Password: "x", Home: name})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		_, err := repo.DeleteUsers([]string{"u1", "ghost"})
+		Expect(err).To(MatchError(ports.ErrConflict))
+		_, err = repo.GetUser("u1")
+		Expect(err).ToNot(HaveOccurred())
+
+		affected, err := repo.DeleteUsers([]string{"u1", "u2"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(affected).To(Equal(2))
+		users, err := repo.ListUsers()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(users).To(HaveLen(1))
+		Expect(users[0].Username).To(Equal("u3"))
+	})
+})
+
+var _ = Describe("SQLiteAccountRepository user touch", func() {
+	It("advances updated_at leaving the attributes and version unchanged", func() {
+		repo := newSQLiteRepo(config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000})
+		u, err := repo.AddUser(ports.UserInfo{Username: "u1", UID: 3001, Groupname: "legacy", Password: "x", Home: "u1"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(u.UpdatedAt).NotTo(BeNil())
+
+		time.Sleep(2 * time.Millisecond)
+		touched, err := repo.TouchUser("u1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*touched.UpdatedAt).To(BeTemporally(">", *u.UpdatedAt))
+		touched.UpdatedAt = u.UpdatedAt
+		Expect(touched).To(Equal(u))
+
+		_, err = repo.TouchUser("ghost")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+	})
+})
+
+var _ = Describe("SQLiteAccountRepository user email", func() {
+	It("stores, updates and clears the email", func() {
+		repo := newSQLiteRepo(config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000})
+		email := "u1@example.com"
+		u, err := repo.AddUser(ports.UserInfo{Username: "u1", UID: 3001, Groupname: "legacy", Password: "x", Home: "u1", Email: &email})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(u.Email).To(HaveValue(Equal(email)))
+
+		u.Email = nil
+		u, err = repo.UpdateUser(u)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(u.Email).To(BeNil())
+	})
+})
+
+var _ = Describe("SQLiteAccountRepository long descriptions", func() {
+	It("round-trips group and user descriptions longer than 255 characters", func() {
+		repo := newSQLiteRepo(config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000})
+		long := strings.Repeat("ż", 4096)
+		_, err := repo.AddGroup(ports.GroupInfo{Groupname: "rich-meta", GID: 5001, Home: "rich-meta", Description: &long})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddUser(ports.UserInfo{Username: "u1", UID: 3001, Groupname: "rich-meta", Password: "x", Home: "u1", Description: &long})
+		Expect(err).ToNot(HaveOccurred())
+
+		g, err := repo.GetGroup("rich-meta")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(g.Description).To(HaveValue(Equal(long)))
+		u, err := repo.GetUser("u1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(u.Description).To(HaveValue(Equal(long)))
+	})
+})
+
+var _ = Describe("SQLiteAccountRepository group deletion", func() {
+	It("refuses to delete a group that still has members", func() {
+		repo := newSQLiteRepo(config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000})
+		_, err := repo.AddUser(ports.UserInfo{Username: "u1", UID: 3001, Groupname: "legacy", Password: "x", Home: "u1"})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = repo.DeleteGroup("legacy")
+		Expect(err).To(MatchError(ports.ErrGroupNotEmpty))
+		Expect(err.Error()).To(ContainSubstring("1 members"))
+		_, err = repo.GetGroup("legacy")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(repo.DeleteUser("u1")).To(Succeed())
+		Expect(repo.DeleteGroup("legacy")).To(Succeed())
+	})
+})
@@ -0,0 +1,71 @@
+package accounts_test
+
+import (
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Account repositories unique group descriptions", func() {
+	repos := func(unique bool) []ports.AccountRepository {
+		common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000, UniqueGroupDescriptions: unique}
+		inmem, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 100}, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		sqlite, err := accounts.NewSQLiteAccountRepository(config.AccountRepositorySqliteConfig{
+			DbFilePath:   filepath.Join(GinkgoT().TempDir(), "descriptions.db"),
+			WriteTimeout: time.Second,
+			QueryTimeout: time.Second,
+		}, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		return []ports.AccountRepository{inmem, sqlite}
+	}
+	desc := func(s string) *string { return &s }
+	group := func(name string, gid uint32, description *string) ports.GroupInfo {
+		return ports.GroupInfo{Groupname: name, GID: gid, Home: name, Description: description}
+	}
+
+	It("rejects a description already used by another group", func() {
+		for _, repo := range repos(true) {
+			_, err := repo.AddGroup(group("sales", 3000, desc("ext-42")))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = repo.AddGroup(group("marketing", 3001, desc("ext-42")))
+			Expect(err).To(MatchError(ports.ErrConflict))
+			Expect(err).To(MatchError(ContainSubstring(`already used by group "sales"`)))
+
+			other, err := repo.AddGroup(group("marketing", 3001, desc("ext-43")))
+			Expect(err).ToNot(HaveOccurred())
+			other.Description = desc("ext-42")
+			_, err = repo.UpdateGroup(other)
+			Expect(err).To(MatchError(ports.ErrConflict))
+
+			sales, err := repo.GetGroup("sales")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = repo.UpdateGroup(sales)
+			Expect(err).ToNot(HaveOccurred(), "a group keeps its own description")
+		}
+	})
+
+	It("never compares groups without a description", func() {
+		for _, repo := range repos(true) {
+			_, err := repo.AddGroup(group("a", 3000, nil))
+			Expect(err).ToNot(HaveOccurred())
+			_, err = repo.AddGroup(group("b", 3001, nil))
+			Expect(err).ToNot(HaveOccurred())
+		}
+	})
+
+	It("allows duplicates by default", func() {
+		for _, repo := range repos(false) {
+			_, err := repo.AddGroup(group("sales", 3000, desc("ext-42")))
+			Expect(err).ToNot(HaveOccurred())
+			_, err = repo.AddGroup(group("marketing", 3001, desc("ext-42")))
+			Expect(err).ToNot(HaveOccurred())
+		}
+	})
+})
@@ -0,0 +1,93 @@
+package accounts_test
+
+import (
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Account repositories users page", func() {
+	common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+
+	// repos seeds inmem and SQLite with 5 users whose uids don't follow their names
+	repos := func() []ports.AccountRepository {
+		inmem, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 100}, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		sqlite, err := accounts.NewSQLiteAccountRepository(config.AccountRepositorySqliteConfig{
+			DbFilePath:   filepath.Join(GinkgoT().TempDir(), "page.db"),
+			WriteTimeout: time.Second,
+			QueryTimeout: time.Second,
+		}, common, true)
+		Expect(err).ToNot(HaveOccurred())
+		out := []ports.AccountRepository{inmem, sqlite}
+		for _, repo := range out {
+			_, err = repo.AddGroup(ports.GroupInfo{Groupname: "staff", GID: 3000, Home: "staff"})
+			Expect(err).ToNot(HaveOccurred())
+			for i, name := range []string{"dave", "alice", "erin", "carol", "bob"} {
+				_, err = repo.AddUser(ports.UserInfo{Username: name, UID: uint32(4000 + i), Groupname: "staff",
+					Password: "x", PasswordIsHash: true, Home: name})
+				Expect(err).ToNot(HaveOccurred())
+			}
+		}
+		return out
+	}
+	names := func(users []ports.UserInfo) []string {
+		out := []string{}
+		for _, u := range users {
+			out = append(out, u.Username)
+		}
+		return out
+	}
+
+	It("pages the users by username by default, with the total", func() {
+		for _, repo := range repos() {
+			page, total, err := repo.ListUsersPage(0, 2, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(total).To(Equal(5))
+			Expect(names(page)).To(Equal([]string{"alice", "bob"}))
+
+			page, _, err = repo.ListUsersPage(4, 2, "username")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names(page)).To(Equal([]string{"erin"}))
+
+			page, total, err = repo.ListUsersPage(10, 2, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(total).To(Equal(5))
+			Expect(page).To(BeEmpty())
+		}
+	})
+
+	It("sorts by uid and descending", func() {
+		for _, repo := range repos() {
+			page, _, err := repo.ListUsersPage(0, 3, "uid")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names(page)).To(Equal([]string{"dave", "alice", "erin"}))
+
+			page, _, err = repo.ListUsersPage(1, 2, "-uid")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names(page)).To(Equal([]string{"carol", "erin"}))
+
+			page, _, err = repo.ListUsersPage(0, 2, "-username")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names(page)).To(Equal([]string{"erin", "dave"}))
+		}
+	})
+
+	It("rejects an unknown sort key and invalid bounds", func() {
+		for _, repo := range repos() {
+			_, _, err := repo.ListUsersPage(0, 2, "email")
+			Expect(err).To(MatchError(ports.ErrInvalidInput))
+			_, _, err = repo.ListUsersPage(0, 2, "-")
+			Expect(err).To(MatchError(ports.ErrInvalidInput))
+			_, _, err = repo.ListUsersPage(-1, 2, "")
+			Expect(err).To(MatchError(ports.ErrInvalidInput))
+			_, _, err = repo.ListUsersPage(0, 0, "")
+			Expect(err).To(MatchError(ports.ErrInvalidInput))
+		}
+	})
+})
@@ -7,13 +7,16 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"fs-access-api/internal/app/config"
 	"fs-access-api/internal/app/ports"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-sql-driver/mysql"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
 func stringOrNil(s *string) any {
@@ -40,6 +43,22 @@ func nullStringToPtr(ns sql.NullString) *string {
 	return nil
 }
 
+// uint64OrNil binds a quota to its signed BIGINT column, the api rejects values above math.MaxInt64.
+func uint64OrNil(v *uint64) any {
+	if v == nil {
+		return nil
+	}
+	return int64(*v)
+}
+
+func nullInt64ToUint64Ptr(ni sql.NullInt64) *uint64 {
+	if ni.Valid && ni.Int64 >= 0 {
+		v := uint64(ni.Int64)
+		return &v
+	}
+	return nil
+}
+
 func nullTimeToPtr(nt sql.NullTime) *time.Time {
 	if nt.Valid {
 		return &nt.Time
@@ -57,6 +76,19 @@ func nullTimeStringToPtr(ts sql.NullString) *time.Time {
 	return expPtr
 }
 
+func nowUTC() *time.Time {
+	now := time.Now().UTC()
+	return &now
+}
+
+// updatedAtArg is the updated_at column value, SQLite keeps the sub-second precision in the text.
+func updatedAtArg(dialect SQLDialect, t *time.Time) any {
+	if dialect == SQLDialectMySQL {
+		return *t
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
 func timeToTimeStringOrNil(t *time.Time) any {
 	if t == nil {
 		return nil
@@ -71,6 +103,24 @@ func pingWithTimeout(db *sql.DB, d time.Duration) error {
 	return db.PingContext(ctx)
 }
 
+// healthCheckWithTimeout runs the health check query (any rows, including none, mean healthy),
+// or pings the database when the query is empty.
+func healthCheckWithTimeout(db *sql.DB, query string, d time.Duration) error {
+	if strings.TrimSpace(query) == "" {
+		return pingWithTimeout(db, d)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
 func getUserNextUID(db *sql.DB, timeout time.Duration, minValue uint32) (uint32, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
@@ -85,19 +135,221 @@ func getUserNextUID(db *sql.DB, timeout time.Duration, minValue uint32) (uint32,
 	return uint32(next.Int64), nil
 }
 
+// addColumnIfMissing adds a column to a table created by an older release;
+// neither SQLite nor MySQL support ADD COLUMN IF NOT EXISTS, so the catalog is checked first.
+func addColumnIfMissing(ctx context.Context, tx *sql.Tx, dialect SQLDialect, table, column, definition string) error {
+	q := `SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?;`
+	if dialect == SQLDialectMySQL {
+		q = `SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?;`
+	}
+	var n int
+	if err := tx.QueryRowContext(ctx, q, table, column).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", table, column, definition))
+	return err
+}
+
+// createIndexIfMissing creates the index name on table(column) unless the column is already indexed,
+// whatever that index is named and whether it's unique: an existing schema keeps its index as created.
+func createIndexIfMissing(ctx context.Context, tx *sql.Tx, dialect SQLDialect, table, column, name string, unique bool) error {
+	q := `SELECT COUNT(*) FROM pragma_index_list(?) AS il, pragma_index_info(il.name) AS ii WHERE ii.name = ?;`
+	if dialect == SQLDialectMySQL {
+		q = `SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?;`
+	}
+	var n int
+	if err := tx.QueryRowContext(ctx, q, table, column).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	kind := "INDEX"
+	if unique {
+		kind = "UNIQUE INDEX"
+	}
+	_, err := tx.ExecContext(ctx, fmt.Sprintf("CREATE %s %s ON %s (%s);", kind, name, table, column))
+	return err
+}
+
+// modifyColumnUnlessType redefines a MySQL column whose data type isn't dataType yet,
+// so the (table copying) ALTER TABLE runs once instead of on every start.
+func modifyColumnUnlessType(ctx context.Context, tx *sql.Tx, table, column, dataType, definition string) error {
+	var current string
+	err := tx.QueryRowContext(ctx, `SELECT DATA_TYPE FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?;`,
+		table, column).Scan(&current)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(current, dataType) {
+		return nil
+	}
+	_, err = tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s MODIFY %s %s;", table, column, definition))
+	return err
+}
+
+// deleteUsersBatchSize bounds the placeholders of a single DELETE ... IN (...) statement.
+const deleteUsersBatchSize = 500
+
+// deleteUsersTx deletes the named users in batches within tx and returns the rows deleted, it fails
+// with ErrConflict when fewer rows than names were deleted (the caller rolls back).
+func deleteUsersTx(ctx context.Context, tx *sql.Tx, names []string) (int, error) {
+	var deleted int64
+	for batch := range slices.Chunk(names, deleteUsersBatchSize) {
+		args := make([]any, len(batch))
+		for i, name := range batch {
+			args[i] = name
+		}
+		q := "DELETE FROM user_info WHERE username IN (?" + strings.Repeat(", ?", len(batch)-1) + ");"
+		res, err := tx.ExecContext(ctx, q, args...)
+		if err != nil {
+			return 0, err
+		}
+		aff, _ := res.RowsAffected()
+		deleted += aff
+	}
+	if deleted != int64(len(names)) {
+		return 0, fmt.Errorf("%w: %d of %d users are already gone", ports.ErrConflict, int64(len(names))-deleted, len(names))
+	}
+	return int(deleted), nil
+}
+
+// checkPageBounds refuses a negative offset and a limit below 1.
+func checkPageBounds(offset, limit int) error {
+	if offset < 0 {
+		return ports.InvalidField("offset", "offset must not be negative, got %d", offset)
+	}
+	if limit < 1 {
+		return ports.InvalidField("limit", "limit must be at least 1, got %d", limit)
+	}
+	return nil
+}
+
+// listUsersPage is ListUsersPage of the SQL repositories, users sharing a uid are ordered by username.
+func listUsersPage(ctx context.Context, db *sql.DB, dialect SQLDialect, offset, limit int, sort string) ([]ports.UserInfo, int, error) {
+	key, desc, err := ports.ParseUserSort(sort)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = checkPageBounds(offset, limit); err != nil {
+		return nil, 0, err
+	}
+	direction := ""
+	if desc {
+		direction = " DESC"
+	}
+	order := "username" + direction
+	if key == ports.UserSortUID {
+		order = "uid" + direction + ", " + order
+	}
+
+	var total int
+	if err = db.QueryRowContext(ctx, `SELECT COUNT(*) FROM user_info;`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	q := `SELECT username, uid, groupname, password, description, email, home, expiration, disabled, version, updated_at FROM user_info
+ORDER BY ` + order + ` LIMIT ? OFFSET ?;`
+	rows, err := db.QueryContext(ctx, q, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	out := make([]ports.UserInfo, 0)
+	for rows.Next() {
+		u, err := scanUserInfo(rows.Scan, dialect)
+		if err != nil {
+			return nil, 0, err
+		}
+		out = append(out, u)
+	}
+	return out, total, rows.Err()
+}
+
+// listEmptyGroups returns the groups no user_info row refers to, ordered like ListGroups.
+func listEmptyGroups(ctx context.Context, db *sql.DB) ([]ports.GroupInfo, error) {
+	const q = `SELECT g.groupname, g.gid, g.description, g.home, g.quota_bytes, g.version FROM group_info g
+WHERE NOT EXISTS (SELECT 1 FROM user_info u WHERE u.groupname = g.groupname) ORDER BY g.groupname;`
+	rows, err := db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]ports.GroupInfo, 0)
+	for rows.Next() {
+		g, err := scanGroupInfo(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, g)
+	}
+	return out, rows.Err()
+}
+
+// checkGroupEmpty fails with ErrGroupNotEmpty when users still belong to the group, so its deletion
+// doesn't end in the foreign key error.
+func checkGroupEmpty(ctx context.Context, db *sql.DB, name string) error {
+	const q = `SELECT COUNT(*) FROM user_info WHERE groupname = ?;`
+	var members int
+	if err := db.QueryRowContext(ctx, q, name).Scan(&members); err != nil {
+		return err
+	}
+	if members > 0 {
+		return fmt.Errorf("%w: group %q still has %d members", ports.ErrGroupNotEmpty, name, members)
+	}
+	return nil
+}
+
+// checkGroupDescriptionUnique fails with ErrConflict when another group already has the description
+// of the group, under common.unique_group_descriptions.
+func checkGroupDescriptionUnique(ctx context.Context, db *sql.DB, common config.AccountRepositoryCommonConfig, group ports.GroupInfo) error {
+	if !common.UniqueGroupDescriptions || group.Description == nil || *group.Description == "" {
+		return nil
+	}
+	const q = `SELECT groupname FROM group_info WHERE description = ? AND groupname <> ? LIMIT 1;`
+	var other string
+	err := db.QueryRowContext(ctx, q, *group.Description, group.Groupname).Scan(&other)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return descriptionTakenErr(*group.Description, other)
+}
+
+func descriptionTakenErr(description, other string) error {
+	return fmt.Errorf("%w: description %q is already used by group %q", ports.ErrConflict, description, other)
+}
+
+// versionMissErr explains a versioned update that matched no row: either the row is gone
+// (getErr is ErrNotFound) or its version has moved on.
+func versionMissErr(getErr error) error {
+	if getErr != nil {
+		return getErr
+	}
+	return ports.ErrVersionMismatch
+}
+
 // scanGroupInfo maps a single row into the model.GroupInfo.
 func scanGroupInfo(scan func(dest ...any) error) (ports.GroupInfo, error) {
 	res := ports.GroupInfo{}
 	var (
 		description sql.NullString
+		quotaBytes  sql.NullInt64
 	)
-	if err := scan(&res.Groupname, &res.GID, &description, &res.Home); err != nil {
+	if err := scan(&res.Groupname, &res.GID, &description, &res.Home, &quotaBytes, &res.Version); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ports.GroupInfo{}, ports.ErrNotFound
 		}
 		return ports.GroupInfo{}, err
 	}
 	res.Description = nullStringToPtr(description)
+	res.QuotaBytes = nullInt64ToUint64Ptr(quotaBytes)
 	return res, nil
 }
 
@@ -113,34 +365,52 @@ func scanUserInfo(scan func(dest ...any) error, dialect SQLDialect) (ports.UserI
 	res := ports.UserInfo{}
 	var (
 		description sql.NullString
+		email       sql.NullString
 		expiration  any
+		updatedAt   any
 		disabled    int
 	)
 
 	if dialect == SQLDialectMySQL {
-		expiration = new(sql.NullTime)
+		expiration, updatedAt = new(sql.NullTime), new(sql.NullTime)
 	} else {
-		expiration = new(sql.NullString)
+		expiration, updatedAt = new(sql.NullString), new(sql.NullString)
 	}
 
-	if err := scan(&res.Username, &res.UID, &res.Groupname, &res.Password, &description, &res.Home, expiration, &disabled); err != nil {
+	if err := scan(&res.Username, &res.UID, &res.Groupname, &res.Password, &description, &email, &res.Home, expiration, &disabled, &res.Version, updatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return res, ports.ErrNotFound
 		}
 		return res, err
 	}
 	res.Description = nullStringToPtr(description)
+	res.Email = nullStringToPtr(email)
 
 	if dialect == SQLDialectMySQL {
 		res.Expiration = nullTimeToPtr(*expiration.(*sql.NullTime))
+		res.UpdatedAt = nullTimeToPtr(*updatedAt.(*sql.NullTime))
 	} else {
 		res.Expiration = nullTimeStringToPtr(*expiration.(*sql.NullString))
+		res.UpdatedAt = nullTimeStringToPtr(*updatedAt.(*sql.NullString))
 	}
 	res.Disabled = disabled != 0
 	res.PasswordIsHash = true
 	return res, nil
 }
 
+// duplicateUserErr translates a unique violation on user_info (reported by isDuplicate) to ErrAlreadyExists,
+// or ErrUIDTaken when the unique UID index was hit: "user_info.uid" (SQLite), "user_info_uid_uq" (MySQL).
+func duplicateUserErr(err error, user ports.UserInfo, isDuplicate func(error) bool) error {
+	if !isDuplicate(err) {
+		return err
+	}
+	msg := strings.ToLower(err.Error())
+	if strings.Contains(msg, "user_info.uid") || strings.Contains(msg, "user_info_uid_uq") {
+		return fmt.Errorf("%w: UID %d", ports.ErrUIDTaken, user.UID)
+	}
+	return ports.ErrAlreadyExists
+}
+
 func isDuplicateSQLite(err error) bool {
 	if err == nil {
 		return false
@@ -150,6 +420,19 @@ func isDuplicateSQLite(err error) bool {
 	return strings.Contains(msg, "unique constraint failed")
 }
 
+// isBusySQLite reports SQLITE_BUSY/SQLITE_LOCKED (including extended codes) from modernc.org/sqlite.
+func isBusySQLite(err error) bool {
+	var coder interface{ Code() int }
+	if !errors.As(err, &coder) {
+		return false
+	}
+	switch coder.Code() & 0xff {
+	case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+		return true
+	}
+	return false
+}
+
 func isDuplicateMySQL(err error) bool {
 	if err == nil {
 		return false
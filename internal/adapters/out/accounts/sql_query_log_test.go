@@ -0,0 +1,66 @@
+package accounts_test
+
+import (
+	"bytes"
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"log"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SQL query logging", func() {
+	var logged *bytes.Buffer
+
+	BeforeEach(func() {
+		logged = &bytes.Buffer{}
+		out := log.Writer()
+		log.SetOutput(logged)
+		DeferCleanup(log.SetOutput, out)
+	})
+
+	newRepo := func(logQueries bool) *accounts.SQLiteAccountRepository {
+		repo, err := accounts.NewSQLiteAccountRepository(config.AccountRepositorySqliteConfig{
+			DbFilePath:   filepath.Join(GinkgoT().TempDir(), "queries.db"),
+			WriteTimeout: time.Second,
+			QueryTimeout: time.Second,
+		}, config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000, LogQueries: logQueries}, true)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddGroup(ports.GroupInfo{Groupname: "proj", GID: 5000, Home: "proj"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.AddUser(ports.UserInfo{Username: "alice", UID: 3001, Groupname: "proj", Password: "$5$s3cr3t-salt$s3cr3t-hash", PasswordIsHash: true, Home: "alice"})
+		Expect(err).ToNot(HaveOccurred())
+		return repo
+	}
+
+	It("logs the GetUser query template with its duration but not its arguments", func() {
+		repo := newRepo(true)
+		logged.Reset()
+
+		_, err := repo.GetUser("alice")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(logged.String()).To(MatchRegexp(`DEBUG sql: SELECT username, uid, groupname, password, .* FROM user_info WHERE username = \?; \[1 args redacted\] took \S+`))
+		Expect(logged.String()).ToNot(ContainSubstring("alice"))
+	})
+
+	It("never logs the password parameter", func() {
+		repo := newRepo(true)
+		Expect(logged.String()).To(ContainSubstring("INSERT INTO user_info"))
+		Expect(logged.String()).ToNot(ContainSubstring("s3cr3t"))
+
+		_, err := repo.GetUser("alice")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(logged.String()).ToNot(ContainSubstring("s3cr3t"))
+	})
+
+	It("logs nothing when disabled", func() {
+		repo := newRepo(false)
+		_, err := repo.GetUser("alice")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(logged.String()).ToNot(ContainSubstring("DEBUG sql:"))
+	})
+})
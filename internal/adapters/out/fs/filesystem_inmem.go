@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"fs-access-api/internal/app/ports"
 	"io/fs"
+	"math"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -15,6 +16,8 @@ import (
 // implementing FilesystemService (for tests and unit logic).
 type InMemFilesystemService struct {
 	root *memDir
+	// capacity of the synthetic filesystem reported by StatFS, unlimited unless set
+	capacityBytes, capacityInodes uint64
 }
 
 var _ ports.FilesystemService = (*InMemFilesystemService)(nil)
@@ -25,6 +28,9 @@ type memDir struct {
 	uid  uint32
 	gid  uint32
 	sub  map[string]*memDir
+	// file marks a regular file node of the given size, it has no sub entries.
+	file bool
+	size int64
 }
 
 func NewInMemFilesystemService() *InMemFilesystemService {
@@ -34,14 +40,45 @@ func NewInMemFilesystemService() *InMemFilesystemService {
 			mode: 0o755,
 			sub:  map[string]*memDir{},
 		},
+		capacityBytes:  math.MaxUint64,
+		capacityInodes: math.MaxUint64,
 	}
 }
 
+// SetCapacity bounds the synthetic filesystem, StatFS reports the capacity minus the file sizes
+// and the number of nodes (directories and files) stored.
+func (m *InMemFilesystemService) SetCapacity(bytes, inodes uint64) {
+	m.capacityBytes, m.capacityInodes = bytes, inodes
+}
+
+func (m *InMemFilesystemService) StatFS(p string) (ports.FSStats, error) {
+	if _, err := m.lookupDir(p, false); err != nil {
+		return ports.FSStats{}, err
+	}
+	var usedBytes, usedInodes uint64
+	var walk func(d *memDir)
+	walk = func(d *memDir) {
+		usedInodes++
+		usedBytes += uint64(d.size)
+		for _, sub := range d.sub {
+			walk(sub)
+		}
+	}
+	walk(m.root)
+	free := func(capacity, used uint64) uint64 {
+		if used >= capacity {
+			return 0
+		}
+		return capacity - used
+	}
+	return ports.FSStats{FreeBytes: free(m.capacityBytes, usedBytes), FreeInodes: free(m.capacityInodes, usedInodes)}, nil
+}
+
 func (m *InMemFilesystemService) GetInfo(p string) (fi fs.FileInfo, uid, gid uint32, err error) {
 	if p == "" || p == "/" || p == "." {
 		return nil, 0, 0, nil
 	}
-	d, err := m.lookupDir(p, true)
+	d, err := m.lookupDir(p, false)
 	if err != nil {
 		return nil, 0, 0, err
 	}
@@ -104,11 +141,32 @@ func (m *InMemFilesystemService) Chmod(p string, perm fs.FileMode) error {
 	return nil
 }
 
+// WriteFile creates or replaces a regular file of the given size, its parent directory must exist.
+func (m *InMemFilesystemService) WriteFile(p string, size int64) error {
+	parts := splitPath(p)
+	if len(parts) == 0 {
+		return fmt.Errorf("invalid file path: %q", p)
+	}
+	parent, err := m.lookupDir(joinPath(parts[:len(parts)-1]), false)
+	if err != nil {
+		return fmt.Errorf("parent directory not found: %w", err)
+	}
+	name := parts[len(parts)-1]
+	if cur, ok := parent.sub[name]; ok && !cur.file {
+		return fmt.Errorf("is a directory: %s", p)
+	}
+	parent.sub[name] = &memDir{name: name, mode: 0o644, file: true, size: size}
+	return nil
+}
+
 func (m *InMemFilesystemService) ReadDir(p string) ([]fs.DirEntry, error) {
 	d, err := m.lookupDir(p, false)
 	if err != nil {
 		return nil, fmt.Errorf("not a directory: %w", err)
 	}
+	if d.file {
+		return nil, fmt.Errorf("not a directory: %s", p)
+	}
 	names := make([]string, 0, len(d.sub))
 	for k := range d.sub {
 		names = append(names, k)
@@ -122,6 +180,14 @@ func (m *InMemFilesystemService) ReadDir(p string) ([]fs.DirEntry, error) {
 	return out, nil
 }
 
+func (m *InMemFilesystemService) ReadDirN(p string, n int) ([]fs.DirEntry, bool, error) {
+	entries, err := m.ReadDir(p)
+	if err != nil || n <= 0 || len(entries) <= n {
+		return entries, false, err
+	}
+	return entries[:n], true, nil
+}
+
 func (m *InMemFilesystemService) Remove(p string) error {
 	if p == "" || p == "/" || p == "." {
 		return errors.New("refusing to remove root or invalid path")
@@ -204,6 +270,9 @@ func (m *InMemFilesystemService) lookupDir(p string, create bool) (*memDir, erro
 			continue
 		}
 
+		if cur.file {
+			return nil, fmt.Errorf("not a directory: %s", cur.name)
+		}
 		next, ok := cur.sub[part]
 		if !ok {
 			if !create {
@@ -232,8 +301,8 @@ type memDirEntry struct {
 }
 
 func (e memDirEntry) Name() string               { return e.d.name }
-func (e memDirEntry) IsDir() bool                { return true }
-func (e memDirEntry) Type() fs.FileMode          { return fs.ModeDir }
+func (e memDirEntry) IsDir() bool                { return !e.d.file }
+func (e memDirEntry) Type() fs.FileMode          { return memFileInfo(e).Mode().Type() }
 func (e memDirEntry) Info() (fs.FileInfo, error) { return memFileInfo{e.d}, nil }
 
 /* ---------- FileInfo wrapper ---------- */
@@ -245,8 +314,13 @@ type memFileInfo struct {
 var _ fs.FileInfo = (*memFileInfo)(nil)
 
 func (f memFileInfo) Name() string       { return f.d.name }
-func (f memFileInfo) Size() int64        { return 0 }
-func (f memFileInfo) Mode() fs.FileMode  { return f.d.mode | fs.ModeDir }
+func (f memFileInfo) Size() int64        { return f.d.size }
 func (f memFileInfo) ModTime() time.Time { return time.Time{} }
-func (f memFileInfo) IsDir() bool        { return true }
+func (f memFileInfo) IsDir() bool        { return !f.d.file }
 func (f memFileInfo) Sys() any           { return nil }
+func (f memFileInfo) Mode() fs.FileMode {
+	if f.d.file {
+		return f.d.mode
+	}
+	return f.d.mode | fs.ModeDir
+}
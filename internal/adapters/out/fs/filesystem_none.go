@@ -3,6 +3,7 @@ package fs
 import (
 	"fs-access-api/internal/app/ports"
 	"io/fs"
+	"math"
 )
 
 type NoneFilesystemService struct{}
@@ -30,3 +31,11 @@ func (NoneFilesystemService) Chmod(_ string, _ fs.FileMode) error     { return n
 func (NoneFilesystemService) ReadDir(_ string) ([]fs.DirEntry, error) { return []fs.DirEntry{}, nil }
 func (NoneFilesystemService) Remove(_ string) error                   { return nil }
 func (NoneFilesystemService) RemoveAll(_ string) error                { return nil }
+func (NoneFilesystemService) ReadDirN(_ string, _ int) ([]fs.DirEntry, bool, error) {
+	return []fs.DirEntry{}, false, nil
+}
+
+// StatFS reports no limits, nothing is ever stored.
+func (NoneFilesystemService) StatFS(_ string) (ports.FSStats, error) {
+	return ports.FSStats{FreeBytes: math.MaxUint64, FreeInodes: math.MaxUint64}, nil
+}
@@ -1,8 +1,10 @@
 package fs
 
 import (
+	"errors"
 	"fmt"
 	"fs-access-api/internal/app/ports"
+	"io"
 	"io/fs"
 	"os"
 	"syscall"
@@ -40,3 +42,35 @@ func (UnixFilesystemService) Chmod(p string, perm fs.FileMode) error  { return o
 func (UnixFilesystemService) ReadDir(p string) ([]fs.DirEntry, error) { return os.ReadDir(p) }
 func (UnixFilesystemService) Remove(p string) error                   { return os.Remove(p) }
 func (UnixFilesystemService) RemoveAll(p string) error                { return os.RemoveAll(p) }
+
+func (UnixFilesystemService) ReadDirN(p string, n int) ([]fs.DirEntry, bool, error) {
+	if n <= 0 {
+		entries, err := os.ReadDir(p)
+		return entries, false, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() { _ = f.Close() }()
+	entries, err := f.ReadDir(n)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, false, err
+	}
+	if len(entries) < n {
+		return entries, false, nil
+	}
+	next, err := f.ReadDir(1)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, false, err
+	}
+	return entries, len(next) > 0, nil
+}
+
+func (UnixFilesystemService) StatFS(p string) (ports.FSStats, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(p, &st); err != nil {
+		return ports.FSStats{}, &fs.PathError{Op: "statfs", Path: p, Err: err}
+	}
+	return ports.FSStats{FreeBytes: uint64(st.Bavail) * uint64(st.Bsize), FreeInodes: uint64(st.Ffree)}, nil
+}
@@ -7,9 +7,9 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"log"
 	"path/filepath"
 	"sort"
-	"strings"
 
 	"fs-access-api/internal/app/config"
 	"fs-access-api/internal/app/ports"
@@ -19,14 +19,17 @@ import (
 // Conform to your existing port.
 var _ ports.FsStorageService = (*DefaultFsStorageService)(nil)
 
+const userHomeMode fs.FileMode = 0o751
+
 type DefaultFsStorageService struct {
-	fs  ports.FilesystemService
-	cfg config.StorageConfig
+	fs    ports.FilesystemService
+	cfg   config.StorageConfig
+	paths ports.HomePaths
 }
 
 func NewDefaultFsStorageService(cfg config.StorageConfig, fs ports.FilesystemService, bootstrap bool) (*DefaultFsStorageService, error) {
 	homesBaseDir := filepath.Clean(cfg.HomesBaseDir)
-	if bootstrap && cfg.CreateHomesBaseDir {
+	if (bootstrap && cfg.CreateHomesBaseDir) || cfg.CreateHomesBaseDirAlways {
 		if err := fs.MkdirAll(homesBaseDir, 0o777); err != nil {
 			return nil, fmt.Errorf("cannot create root directory %q: %w", homesBaseDir, err)
 		}
@@ -35,95 +38,119 @@ func NewDefaultFsStorageService(cfg config.StorageConfig, fs ports.FilesystemSer
 	if _, err := fs.ReadDir(homesBaseDir); err != nil {
 		return nil, fmt.Errorf("root directory invalid %q: %w", homesBaseDir, err)
 	}
-	return &DefaultFsStorageService{fs: fs, cfg: cfg}, nil
+	if actual := implementationOf(fs); cfg.Implementation != "" && actual != "" && actual != cfg.Implementation {
+		log.Printf("WARNING: storage.implementation is %q but the filesystem service in use is %q: the configured value is ignored", cfg.Implementation, actual)
+	}
+	if len(cfg.DefaultUserTopDirs) == 0 {
+		log.Printf("storage.default_user_top_dirs is empty: user homes are prepared without top dirs, list them empty until dirs are ensured")
+	}
+	paths, err := cfg.HomePaths()
+	if err != nil {
+		return nil, err
+	}
+	return &DefaultFsStorageService{fs: fs, cfg: cfg, paths: paths}, nil
 }
 
-func (c *DefaultFsStorageService) PrepareGroupHome(group ports.GroupInfo) error {
-	groupHome := filepath.Clean(group.Home)
-	if strings.HasPrefix(groupHome, string(filepath.Separator)) {
-		return fmt.Errorf("cannot prepare group home using absolute path: %q", groupHome)
+// implementationOf names the storage.implementation value matching the given filesystem service,
+// or "" when the service is not one of ours.
+func implementationOf(fs ports.FilesystemService) string {
+	switch fs.(type) {
+	case *UnixFilesystemService:
+		return "unix"
+	case *InMemFilesystemService:
+		return "inmem"
+	case *NoneFilesystemService:
+		return "none"
+	default:
+		return ""
 	}
-	absGroupHome := filepath.Clean(filepath.Join(c.cfg.HomesBaseDir, groupHome))
-	if !strings.HasPrefix(absGroupHome+string(filepath.Separator), c.cfg.HomesBaseDir+string(filepath.Separator)) {
-		return fmt.Errorf("group home %q escapes root %q", absGroupHome, c.cfg.HomesBaseDir)
+}
+
+func (c *DefaultFsStorageService) PrepareGroupHome(group ports.GroupInfo) error {
+	absGroupHome, err := c.paths.GroupHome(group.Home)
+	if err != nil {
+		return err
 	}
-	return ensureDir(c.fs, absGroupHome, 0o751, 0, group.GID, false)
+	_, err = ensureDir(c.fs, absGroupHome, 0o751, 0, group.GID, false, true)
+	return err
 }
 
 func (c *DefaultFsStorageService) PrepareUserHome(user ports.UserInfo, group ports.GroupInfo) error {
-	groupHome := filepath.Clean(group.Home)
-	if strings.HasPrefix(groupHome, string(filepath.Separator)) {
-		return fmt.Errorf("cannot prepare group home using absolute path: %q", groupHome)
-	}
-	userHome := filepath.Clean(user.Home)
-	if strings.HasPrefix(userHome, string(filepath.Separator)) {
-		return fmt.Errorf("cannot prepare user home using absolute path: %q", userHome)
+	_, err := c.PrepareUserHomeDetailed(user, group)
+	return err
+}
+
+func (c *DefaultFsStorageService) PrepareUserHomeDetailed(user ports.UserInfo, group ports.GroupInfo) (ports.HomePrepResult, error) {
+	var res ports.HomePrepResult
+	absUserHome, err := c.paths.UserHome(group.Home, user.Home)
+	if err != nil {
+		return res, err
 	}
-	absGroupHome := filepath.Clean(filepath.Join(c.cfg.HomesBaseDir, groupHome))
-	absUserHome := filepath.Clean(filepath.Join(absGroupHome, userHome))
-	if !strings.HasPrefix(absUserHome+string(filepath.Separator), absGroupHome+string(filepath.Separator)) {
-		return fmt.Errorf("user home %q escapes group %q", absUserHome, absGroupHome)
+	for _, topDir := range c.cfg.DefaultUserTopDirs {
+		if _, err := c.paths.TopDir(group.Home, user.Home, topDir.Name); err != nil {
+			return res, err
+		}
 	}
-	if err := ensureDir(c.fs, absUserHome, 0o751, user.UID, group.GID, false); err != nil {
-		return err
+	created, err := ensureDir(c.fs, absUserHome, userHomeMode, user.UID, group.GID, false, c.cfg.EffectiveEnforceModeOnEnsure())
+	if err != nil {
+		return res, err
 	}
+	addPrepared(&res, absUserHome, created)
 	for _, topDir := range c.cfg.DefaultUserTopDirs {
-		err := ensureDir(c.fs, filepath.Join(absUserHome, topDir), 0o2770, user.UID, group.GID, true)
+		absTop := filepath.Join(absUserHome, topDir.Name)
+		created, err = ensureDir(c.fs, absTop, topDir.EffectiveMode(), user.UID, group.GID, topDir.EffectiveSetgid(), c.cfg.EffectiveEnforceModeOnEnsure())
 		if err != nil {
-			return fmt.Errorf("cannot create user '%s' top dir '%s': %w", userHome, topDir, err)
+			return res, fmt.Errorf("cannot create user '%s' top dir '%s': %w", user.Home, topDir.Name, err)
 		}
+		addPrepared(&res, absTop, created)
 	}
-	return nil
+	return res, nil
 }
 
 func (c *DefaultFsStorageService) CreateUserTopDir(user ports.UserInfo, group ports.GroupInfo, topDir string) error {
-	groupHome := filepath.Clean(group.Home)
-	if strings.HasPrefix(groupHome, string(filepath.Separator)) {
-		return fmt.Errorf("cannot prepare group home using absolute path: %q", groupHome)
-	}
-	userHome := filepath.Clean(user.Home)
-	if strings.HasPrefix(userHome, string(filepath.Separator)) {
-		return fmt.Errorf("cannot prepare user home using absolute path: %q", userHome)
+	absTop, err := c.paths.TopDir(group.Home, user.Home, topDir)
+	if err != nil {
+		return err
 	}
-	topDir = filepath.Clean(topDir)
-	if strings.HasPrefix(topDir, string(filepath.Separator)) {
-		return fmt.Errorf("cannot prepare top dir using absolute path: %q", topDir)
+	if _, _, _, err := c.fs.GetInfo(absTop); errors.Is(err, stdos.ErrNotExist) {
+		if err := c.checkFreeSpace(); err != nil {
+			return err
+		}
 	}
+	settings := c.topDirSettings(filepath.Base(absTop))
+	_, err = ensureDir(c.fs, absTop, settings.EffectiveMode(), user.UID, group.GID, settings.EffectiveSetgid(), true)
+	return err
+}
 
-	absUserHome := filepath.Clean(filepath.Join(c.cfg.HomesBaseDir, groupHome, userHome))
-	absTop := filepath.Clean(filepath.Join(absUserHome, topDir))
-	if !strings.HasPrefix(absTop+string(filepath.Separator), absUserHome+string(filepath.Separator)) {
-		return fmt.Errorf("top dir %q escapes user home %q", absTop, absUserHome)
-	}
-	// enforce “top-level” (no nested paths)
-	if filepath.Dir(absTop) != absUserHome {
-		return fmt.Errorf("refusing non-top-level directory: %q", absTop)
+// topDirSettings returns the configured settings of a default top dir, the defaults for any other.
+func (c *DefaultFsStorageService) topDirSettings(name string) config.TopDirConfig {
+	for _, d := range c.cfg.DefaultUserTopDirs {
+		if d.Name == name {
+			return d
+		}
 	}
-	return ensureDir(c.fs, absTop, 0o2770, user.UID, group.GID, true)
+	return config.TopDirConfig{Name: name}
 }
 
-func (c *DefaultFsStorageService) ListUserTopDirs(user ports.UserInfo, group ports.GroupInfo) ([]string, error) {
-	groupHome := filepath.Clean(group.Home)
-	if strings.HasPrefix(groupHome, string(filepath.Separator)) {
-		return nil, fmt.Errorf("cannot list: absolute group home: %q", groupHome)
-	}
-	userHome := filepath.Clean(user.Home)
-	if strings.HasPrefix(userHome, string(filepath.Separator)) {
-		return nil, fmt.Errorf("cannot list: absolute user home: %q", userHome)
+func (c *DefaultFsStorageService) ListUserTopDirs(user ports.UserInfo, group ports.GroupInfo) ([]string, bool, error) {
+	absUserHome, err := c.paths.UserHome(group.Home, user.Home)
+	if err != nil {
+		return nil, false, err
 	}
 
-	absGroupHome := filepath.Clean(filepath.Join(c.cfg.HomesBaseDir, groupHome))
-	absUserHome := filepath.Clean(filepath.Join(absGroupHome, userHome))
-	if !strings.HasPrefix(absUserHome+string(filepath.Separator), absGroupHome+string(filepath.Separator)) {
-		return nil, fmt.Errorf("user home %q escapes group %q", absUserHome, absGroupHome)
+	// succeeds only for real directories, reads no more than max_dir_entries of them
+	entries, truncated, err := c.fs.ReadDirN(absUserHome, c.cfg.MaxDirEntries)
+	if errors.Is(err, stdos.ErrNotExist) {
+		return []string{}, false, nil // the home is not prepared yet, so it has no dirs
 	}
-
-	entries, err := c.fs.ReadDir(absUserHome) // succeeds only for real directories
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+	if truncated {
+		log.Printf("WARNING: user %q: home %s has more than %d entries, its listing is truncated", user.Username, absUserHome, c.cfg.MaxDirEntries)
 	}
 
-	var dirs []string
+	dirs := []string{}
 	for _, e := range entries {
 		// ignore symlinks: ReadDir’s DirEntry.Type doesn’t follow symlinks
 		if e.Type()&stdos.ModeSymlink != 0 {
@@ -134,35 +161,31 @@ func (c *DefaultFsStorageService) ListUserTopDirs(user ports.UserInfo, group por
 		}
 	}
 	sort.Strings(dirs)
-	return dirs, nil
+	return dirs, truncated, nil
 }
 
-func (c *DefaultFsStorageService) DeleteUserTopDir(user ports.UserInfo, group ports.GroupInfo, topDir string) error {
-	groupHome := filepath.Clean(group.Home)
-	if strings.HasPrefix(groupHome, string(filepath.Separator)) {
-		return fmt.Errorf("cannot delete: absolute group home: %q", groupHome)
-	}
-	userHome := filepath.Clean(user.Home)
-	if strings.HasPrefix(userHome, string(filepath.Separator)) {
-		return fmt.Errorf("cannot delete: absolute user home: %q", userHome)
-	}
-	topDir = filepath.Clean(topDir)
-	if strings.HasPrefix(topDir, string(filepath.Separator)) {
-		return fmt.Errorf("cannot delete: absolute top dir: %q", topDir)
+// ListUserTopDirsDetailed lists the same directories as ListUserTopDirs with their mode, owner and modtime.
+func (c *DefaultFsStorageService) ListUserTopDirsDetailed(user ports.UserInfo, group ports.GroupInfo) ([]ports.DirInfo, bool, error) {
+	names, truncated, err := c.ListUserTopDirs(user, group) // validates the homes, skips symlinks
+	if err != nil {
+		return nil, false, err
 	}
-
-	absGroupHome := filepath.Clean(filepath.Join(c.cfg.HomesBaseDir, groupHome))
-	absUserHome := filepath.Clean(filepath.Join(absGroupHome, userHome))
-	if !strings.HasPrefix(absUserHome+string(filepath.Separator), absGroupHome+string(filepath.Separator)) {
-		return fmt.Errorf("user home %q escapes group %q", absUserHome, absGroupHome)
+	absUserHome, _ := c.paths.UserHome(group.Home, user.Home) // validated by ListUserTopDirs
+	dirs := make([]ports.DirInfo, 0, len(names))
+	for _, name := range names {
+		fi, uid, gid, err := c.fs.GetInfo(filepath.Join(absUserHome, name))
+		if err != nil {
+			return nil, false, fmt.Errorf("cannot stat top dir %q: %w", name, err)
+		}
+		dirs = append(dirs, ports.DirInfo{Name: name, Mode: fi.Mode(), UID: uid, GID: gid, ModTime: fi.ModTime()})
 	}
+	return dirs, truncated, nil
+}
 
-	absTop := filepath.Clean(filepath.Join(absUserHome, topDir))
-	if !strings.HasPrefix(absTop+string(filepath.Separator), absUserHome+string(filepath.Separator)) {
-		return fmt.Errorf("top dir %q escapes user home %q", absTop, absUserHome)
-	}
-	if filepath.Dir(absTop) != absUserHome {
-		return fmt.Errorf("refusing non-top-level directory: %q", absTop)
+func (c *DefaultFsStorageService) DeleteUserTopDir(user ports.UserInfo, group ports.GroupInfo, topDir string) error {
+	absTop, err := c.paths.TopDir(group.Home, user.Home, topDir)
+	if err != nil {
+		return err
 	}
 
 	// Confirm it is a directory (ReadDir works only on directories)
@@ -176,21 +199,173 @@ func (c *DefaultFsStorageService) DeleteUserTopDir(user ports.UserInfo, group po
 	return c.fs.RemoveAll(absTop)
 }
 
+func (c *DefaultFsStorageService) PurgeUserHome(user ports.UserInfo, group ports.GroupInfo) error {
+	absUserHome, err := c.paths.UserHome(group.Home, user.Home)
+	if err != nil {
+		return err
+	}
+	if absGroupHome, _ := c.paths.GroupHome(group.Home); absUserHome == absGroupHome {
+		return fmt.Errorf("refusing to purge user home %q shared with the group", absUserHome)
+	}
+	return c.fs.RemoveAll(absUserHome)
+}
+
+// RemoveGroupHome removes an empty group home; a group home that doesn't exist is already removed.
+func (c *DefaultFsStorageService) RemoveGroupHome(group ports.GroupInfo) error {
+	absGroupHome, err := c.paths.GroupHome(group.Home)
+	if err != nil {
+		return err
+	}
+	if absGroupHome == filepath.Clean(c.cfg.HomesBaseDir) {
+		return fmt.Errorf("refusing to remove group home %q shared with the homes base dir", absGroupHome)
+	}
+	entries, err := c.fs.ReadDir(absGroupHome)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("%w: group home %q is not empty", ports.ErrConflict, absGroupHome)
+	}
+	return c.fs.Remove(absGroupHome)
+}
+
+// GroupUsageBytes walks the group home summing regular file sizes, symlinks are not followed.
+// A group home that doesn't exist yet uses nothing.
+func (c *DefaultFsStorageService) GroupUsageBytes(group ports.GroupInfo) (uint64, error) {
+	absGroupHome, err := c.paths.GroupHome(group.Home)
+	if err != nil {
+		return 0, err
+	}
+	usage, err := dirUsage(c.fs, absGroupHome)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	return usage, err
+}
+
+func (c *DefaultFsStorageService) CheckUserHome(user ports.UserInfo, group ports.GroupInfo) ([]ports.HomeDrift, error) {
+	absUserHome, err := c.paths.UserHome(group.Home, user.Home)
+	if err != nil {
+		return nil, err
+	}
+	fi, uid, gid, err := c.fs.GetInfo(absUserHome)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []ports.HomeDrift{{Username: user.Username, Path: absUserHome, Kind: ports.HomeDriftMissing}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot stat user home %q: %w", absUserHome, err)
+	}
+	if fi == nil { // the "none" filesystem keeps nothing to compare
+		return nil, nil
+	}
+	var drifts []ports.HomeDrift
+	if uid != user.UID || gid != group.GID {
+		drifts = append(drifts, ports.HomeDrift{Username: user.Username, Path: absUserHome, Kind: ports.HomeDriftOwner,
+			Expected: fmt.Sprintf("%d:%d", user.UID, group.GID), Actual: fmt.Sprintf("%d:%d", uid, gid)})
+	}
+	modeDrift := ports.HomeDrift{Username: user.Username, Path: absUserHome, Kind: ports.HomeDriftMode, Expected: fmt.Sprintf("%04o", userHomeMode)}
+	switch {
+	case !fi.IsDir():
+		modeDrift.Actual = "not a directory"
+		drifts = append(drifts, modeDrift)
+	case fi.Mode().Perm() != userHomeMode:
+		modeDrift.Actual = fmt.Sprintf("%04o", fi.Mode().Perm())
+		drifts = append(drifts, modeDrift)
+	}
+	return drifts, nil
+}
+
+func dirUsage(fsys ports.FilesystemService, path string) (uint64, error) {
+	entries, err := fsys.ReadDir(path)
+	if err != nil {
+		return 0, err
+	}
+	var total uint64
+	for _, e := range entries {
+		switch {
+		case e.IsDir():
+			sub, err := dirUsage(fsys, filepath.Join(path, e.Name()))
+			if err != nil {
+				return 0, err
+			}
+			total += sub
+		case e.Type().IsRegular():
+			fi, err := e.Info()
+			if err != nil {
+				return 0, fmt.Errorf("stat %s: %w", filepath.Join(path, e.Name()), err)
+			}
+			total += uint64(fi.Size())
+		}
+	}
+	return total, nil
+}
+
 /* ---------- 4) Single helper for all dir creation cases ---------- */
 
-func ensureDir(fsys ports.FilesystemService, path string, mode fs.FileMode, uid, gid uint32, setgid bool) error {
-	if err := fsys.MkdirAll(path, mode); err != nil {
-		return fmt.Errorf("mkdir %s: %w", path, err)
+// checkFreeSpace refuses new directories once the homes filesystem runs below
+// storage.min_free_bytes or storage.min_free_inodes.
+func (c *DefaultFsStorageService) checkFreeSpace() error {
+	if c.cfg.MinFreeBytes == 0 && c.cfg.MinFreeInodes == 0 {
+		return nil
+	}
+	st, err := c.fs.StatFS(c.cfg.HomesBaseDir)
+	if err != nil {
+		return fmt.Errorf("cannot stat the homes filesystem: %w", err)
+	}
+	if st.FreeBytes < c.cfg.MinFreeBytes {
+		return fmt.Errorf("%w: %d bytes free, storage.min_free_bytes is %d", ports.ErrInsufficientStorage, st.FreeBytes, c.cfg.MinFreeBytes)
 	}
-	if err := fsys.Chown(path, uid, gid); err != nil {
-		return fmt.Errorf("chown %s: %w", path, err)
+	if st.FreeInodes < c.cfg.MinFreeInodes {
+		return fmt.Errorf("%w: %d inodes free, storage.min_free_inodes is %d", ports.ErrInsufficientStorage, st.FreeInodes, c.cfg.MinFreeInodes)
+	}
+	return nil
+}
+
+// addPrepared records a prepared directory as created or existing.
+func addPrepared(res *ports.HomePrepResult, path string, created bool) {
+	if created {
+		res.CreatedDirs = append(res.CreatedDirs, path)
+	} else {
+		res.ExistingDirs = append(res.ExistingDirs, path)
+	}
+}
+
+// ensureDir creates the directory (with its missing parents) and sets its owner and mode, those of an
+// existing directory are only re-applied with enforceExisting; it reports whether the directory itself
+// was created, Mkdir failing with ErrExist tells it wasn't.
+func ensureDir(fsys ports.FilesystemService, path string, mode fs.FileMode, uid, gid uint32, setgid, enforceExisting bool) (created bool, err error) {
+	err = fsys.Mkdir(path, mode)
+	created = err == nil
+	if errors.Is(err, fs.ErrExist) {
+		fi, _, _, statErr := fsys.GetInfo(path)
+		if statErr != nil {
+			return false, fmt.Errorf("stat %s: %w", path, statErr)
+		}
+		if !fi.IsDir() {
+			return false, fmt.Errorf("mkdir %s: not a directory", path)
+		}
+		if !enforceExisting {
+			return false, nil
+		}
+	} else if err != nil {
+		// MkdirAll creates the missing parents
+		if err = fsys.MkdirAll(path, mode); err != nil {
+			return false, fmt.Errorf("mkdir %s: %w", path, err)
+		}
+		created = true
+	}
+	if err = fsys.Chown(path, uid, gid); err != nil {
+		return created, fmt.Errorf("chown %s: %w", path, err)
 	}
 	if setgid {
-		mode |= 0o2000 // setgid bit
+		mode |= fs.ModeSetgid
 	}
 	// force exact perms (bypass umask effects)
-	if err := fsys.Chmod(path, mode); err != nil {
-		return fmt.Errorf("chmod %s: %w", path, err)
+	if err = fsys.Chmod(path, mode); err != nil {
+		return created, fmt.Errorf("chmod %s: %w", path, err)
 	}
-	return nil
+	return created, nil
 }
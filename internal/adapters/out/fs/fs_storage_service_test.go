@@ -3,10 +3,16 @@
 package fs_test
 
 import (
+	"bytes"
 	"fs-access-api/internal/adapters/out/fs"
 	"fs-access-api/internal/app/config"
 	"fs-access-api/internal/app/ports"
+	iofs "io/fs"
+	"log"
+	"math"
+	"os"
 	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -27,10 +33,10 @@ var _ = Describe("DefaultFsStorageService", func() {
 		err = fsm.MkdirAll(homesBaseDir, 0o777)
 		Expect(err).ToNot(HaveOccurred())
 		cfg := config.StorageConfig{
-			Implementation:     "unix",
+			Implementation:     "inmem",
 			HomesBaseDir:       homesBaseDir,
 			CreateHomesBaseDir: false,
-			DefaultUserTopDirs: []string{"_test"},
+			DefaultUserTopDirs: []config.TopDirConfig{{Name: "_test"}},
 		}
 		storage, err = fs.NewDefaultFsStorageService(cfg, fsm, true)
 		Expect(err).ToNot(HaveOccurred())
@@ -66,7 +72,7 @@ var _ = Describe("DefaultFsStorageService", func() {
 			g := ports.GroupInfo{GID: 2000, Home: home}
 			err := storage.PrepareGroupHome(g)
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("cannot prepare group home using absolute path"))
+			Expect(err.Error()).To(ContainSubstring("absolute group home"))
 		})
 
 		It("should refuse to prepare group home attempting traversal outside root", func() {
@@ -112,7 +118,7 @@ var _ = Describe("DefaultFsStorageService", func() {
 			g := ports.GroupInfo{GID: 2000, Home: "groupns"}
 			err := storage.PrepareUserHome(u, g)
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("cannot prepare user home using absolute path"))
+			Expect(err.Error()).To(ContainSubstring("absolute user home"))
 		})
 
 		It("should refuse user home attempting traversal outside group home", func() {
@@ -125,6 +131,33 @@ var _ = Describe("DefaultFsStorageService", func() {
 		})
 	})
 
+	Describe("PrepareUserHome with require_user_home_subdir", func() {
+		var strict *fs.DefaultFsStorageService
+
+		BeforeEach(func() {
+			var err error
+			strict, err = fs.NewDefaultFsStorageService(config.StorageConfig{
+				HomesBaseDir:          homesBaseDir,
+				RequireUserHomeSubdir: true,
+			}, fsm, true)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should refuse user home same as group", func() {
+			g := ports.GroupInfo{GID: 2000, Home: "group-dir"}
+			for _, home := range []string{".", "user-dir/.."} {
+				err := strict.PrepareUserHome(ports.UserInfo{UID: 2001, Home: home}, g)
+				Expect(err).To(MatchError(ports.ErrInvalidInput), "home %q", home)
+			}
+		})
+
+		It("should still prepare a proper subdirectory", func() {
+			u := ports.UserInfo{UID: 2001, Home: "user-dir"}
+			g := ports.GroupInfo{GID: 2000, Home: "group-dir"}
+			Expect(strict.PrepareUserHome(u, g)).To(Succeed())
+		})
+	})
+
 	Describe("PrepareUserHome default top-dirs", func() {
 		It("creates default top-dirs with setgid 02770", func() {
 			u := ports.UserInfo{UID: 2001, Home: "bob"}
@@ -139,7 +172,7 @@ var _ = Describe("DefaultFsStorageService", func() {
 			Expect(uid).To(Equal(uint32(2001)))
 			Expect(gid).To(Equal(uint32(2000)))
 			Expect(int(fi.Mode().Perm())).To(Equal(0o770))
-			// TODO: Expect((fi.Mode()&os.ModeSetgid) != 0).To(BeTrue(), "setgid bit should be set")
+			Expect(fi.Mode()&iofs.ModeSetgid).NotTo(BeZero(), "setgid bit should be set")
 
 			// Always assert base perms; harmless everywhere
 			fi, uid, gid, err = fsm.GetInfo(userHome)
@@ -150,6 +183,270 @@ var _ = Describe("DefaultFsStorageService", func() {
 
 	})
 
+	Describe("PrepareUserHomeDetailed", func() {
+		It("lists the created directories first, then the existing ones", func() {
+			u := ports.UserInfo{UID: 2001, Home: "bob"}
+			g := ports.GroupInfo{GID: 2000, Home: "grpD"}
+			userHome := filepath.Join(homesBaseDir, "grpD", "bob")
+			testDir := filepath.Join(userHome, "_test")
+
+			res, err := storage.PrepareUserHomeDetailed(u, g)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.CreatedDirs).To(Equal([]string{userHome, testDir}))
+			Expect(res.ExistingDirs).To(BeEmpty())
+
+			Expect(fsm.Remove(testDir)).To(Succeed())
+			res, err = storage.PrepareUserHomeDetailed(u, g)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.CreatedDirs).To(Equal([]string{testDir}))
+			Expect(res.ExistingDirs).To(Equal([]string{userHome}))
+
+			res, err = storage.PrepareUserHomeDetailed(u, g)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.CreatedDirs).To(BeEmpty())
+			Expect(res.ExistingDirs).To(Equal([]string{userHome, testDir}))
+		})
+	})
+
+	Describe("per-dir top-dir settings", func() {
+		var custom *fs.DefaultFsStorageService
+		u := ports.UserInfo{UID: 2001, Home: "bob"}
+		g := ports.GroupInfo{GID: 2000, Home: "grpC"}
+
+		BeforeEach(func() {
+			var err error
+			mode := iofs.FileMode(0o755)
+			noSetgid := false
+			custom, err = fs.NewDefaultFsStorageService(config.StorageConfig{HomesBaseDir: homesBaseDir, DefaultUserTopDirs: []config.TopDirConfig{
+				{Name: "_test"}, {Name: "public", Mode: &mode, Setgid: &noSetgid},
+			}}, fsm, true)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("applies the per-dir mode and setgid when preparing the home", func() {
+			Expect(custom.PrepareUserHome(u, g)).To(Succeed())
+			fi, _, _, err := fsm.GetInfo(filepath.Join(homesBaseDir, "grpC", "bob", "public"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fi.Mode().Perm()).To(Equal(iofs.FileMode(0o755)))
+			Expect(fi.Mode() & iofs.ModeSetgid).To(BeZero())
+
+			fi, _, _, err = fsm.GetInfo(filepath.Join(homesBaseDir, "grpC", "bob", "_test"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fi.Mode().Perm()).To(Equal(iofs.FileMode(0o770)))
+			Expect(fi.Mode() & iofs.ModeSetgid).NotTo(BeZero())
+		})
+
+		It("applies them when (re)creating the top dir on its own", func() {
+			Expect(custom.PrepareGroupHome(g)).To(Succeed())
+			Expect(custom.CreateUserTopDir(u, g, "public")).To(Succeed())
+			fi, _, _, err := fsm.GetInfo(filepath.Join(homesBaseDir, "grpC", "bob", "public"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fi.Mode().Perm()).To(Equal(iofs.FileMode(0o755)))
+			Expect(fi.Mode() & iofs.ModeSetgid).To(BeZero())
+		})
+	})
+
+	Describe("empty default top-dirs", func() {
+		var bare *fs.DefaultFsStorageService
+		u := ports.UserInfo{UID: 2001, Home: "bob"}
+		g := ports.GroupInfo{GID: 2000, Home: "grpA"}
+
+		BeforeEach(func() {
+			var err error
+			bare, err = fs.NewDefaultFsStorageService(config.StorageConfig{HomesBaseDir: homesBaseDir, DefaultUserTopDirs: []config.TopDirConfig{}}, fsm, true)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("prepares a bare home listed as an empty, non-nil slice", func() {
+			Expect(bare.PrepareUserHome(u, g)).To(Succeed())
+			dirs, _, err := bare.ListUserTopDirs(u, g)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dirs).NotTo(BeNil())
+			Expect(dirs).To(BeEmpty())
+
+			Expect(bare.CreateUserTopDir(u, g, "data")).To(Succeed())
+			Expect(bare.ListUserTopDirs(u, g)).To(Equal([]string{"data"}))
+		})
+
+		It("lists a home not prepared yet as empty", func() {
+			dirs, _, err := bare.ListUserTopDirs(u, g)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dirs).To(Equal([]string{}))
+			detailed, _, err := bare.ListUserTopDirsDetailed(u, g)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(detailed).To(BeEmpty())
+		})
+	})
+
+	Describe("max_dir_entries", func() {
+		u := ports.UserInfo{UID: 2001, Home: "bob"}
+		g := ports.GroupInfo{GID: 2000, Home: "grpA"}
+
+		capped := func(maxEntries int) *fs.DefaultFsStorageService {
+			storage, err := fs.NewDefaultFsStorageService(config.StorageConfig{HomesBaseDir: homesBaseDir,
+				DefaultUserTopDirs: []config.TopDirConfig{}, MaxDirEntries: maxEntries}, fsm, true)
+			Expect(err).ToNot(HaveOccurred())
+			for _, name := range []string{"a", "b", "c"} {
+				Expect(storage.CreateUserTopDir(u, g, name)).To(Succeed())
+			}
+			return storage
+		}
+
+		It("truncates and flags a listing past the cap", func() {
+			dirs, truncated, err := capped(2).ListUserTopDirs(u, g)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(truncated).To(BeTrue())
+			Expect(dirs).To(Equal([]string{"a", "b"}))
+
+			detailed, truncated, err := capped(2).ListUserTopDirsDetailed(u, g)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(truncated).To(BeTrue())
+			Expect(detailed).To(HaveLen(2))
+		})
+
+		It("lists everything up to the cap or without one", func() {
+			for _, maxEntries := range []int{0, 3} {
+				dirs, truncated, err := capped(maxEntries).ListUserTopDirs(u, g)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(truncated).To(BeFalse())
+				Expect(dirs).To(Equal([]string{"a", "b", "c"}))
+			}
+		})
+
+		It("reads at most n entries of a real directory", func() {
+			dir := GinkgoT().TempDir()
+			for _, name := range []string{"a", "b", "c"} {
+				Expect(os.Mkdir(filepath.Join(dir, name), 0o750)).To(Succeed())
+			}
+			unix := fs.NewUnixFilesystemService()
+			entries, more, err := unix.ReadDirN(dir, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+			Expect(more).To(BeTrue())
+
+			entries, more, err = unix.ReadDirN(dir, 3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(3))
+			Expect(more).To(BeFalse())
+		})
+	})
+
+	Describe("enforce_mode_on_ensure", func() {
+		u := ports.UserInfo{UID: 2001, Home: "bob"}
+		g := ports.GroupInfo{GID: 2000, Home: "grpE"}
+
+		tamper := func() (userHome, testDir string) {
+			userHome = filepath.Join(homesBaseDir, "grpE", "bob")
+			testDir = filepath.Join(userHome, "_test")
+			Expect(fsm.Chmod(userHome, 0o777)).To(Succeed())
+			Expect(fsm.Chmod(testDir, 0o700)).To(Succeed())
+			return userHome, testDir
+		}
+
+		It("leaves the modes of existing dirs alone when disabled", func() {
+			disabled := false
+			lenient, err := fs.NewDefaultFsStorageService(config.StorageConfig{
+				HomesBaseDir:        homesBaseDir,
+				DefaultUserTopDirs:  []config.TopDirConfig{{Name: "_test"}},
+				EnforceModeOnEnsure: &disabled,
+			}, fsm, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lenient.PrepareUserHome(u, g)).To(Succeed())
+			userHome, testDir := tamper()
+			Expect(lenient.PrepareUserHome(u, g)).To(Succeed())
+
+			fi, _, _, err := fsm.GetInfo(userHome)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fi.Mode().Perm()).To(Equal(iofs.FileMode(0o777)))
+			fi, _, _, err = fsm.GetInfo(testDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fi.Mode().Perm()).To(Equal(iofs.FileMode(0o700)))
+		})
+
+		It("corrects a tampered mode on the next ensure by default", func() {
+			Expect(storage.PrepareUserHome(u, g)).To(Succeed())
+			userHome, testDir := tamper()
+			Expect(storage.PrepareUserHome(u, g)).To(Succeed())
+
+			fi, _, _, err := fsm.GetInfo(userHome)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fi.Mode().Perm()).To(Equal(iofs.FileMode(0o751)))
+			fi, _, _, err = fsm.GetInfo(testDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fi.Mode().Perm()).To(Equal(iofs.FileMode(0o770)))
+			Expect(fi.Mode() & iofs.ModeSetgid).NotTo(BeZero())
+		})
+	})
+
+	Describe("max_path_length", func() {
+		var bounded *fs.DefaultFsStorageService
+		g := ports.GroupInfo{GID: 2000, Home: "grpA"}
+
+		BeforeEach(func() {
+			var err error
+			// room for "<homesBaseDir>/grpA/bob" but not for its "_test" top dir
+			limit := len(filepath.Join(homesBaseDir, "grpA", "bob")) + 3
+			bounded, err = fs.NewDefaultFsStorageService(config.StorageConfig{
+				HomesBaseDir: homesBaseDir, DefaultUserTopDirs: []config.TopDirConfig{{Name: "_test"}}, MaxPathLength: limit,
+			}, fsm, true)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("rejects a too long home before creating anything", func() {
+			u := ports.UserInfo{UID: 2001, Home: "bob-with-a-longer-home"}
+			Expect(bounded.PrepareUserHome(u, g)).To(MatchError(ports.ErrInvalidInput))
+			_, err := fsm.ReadDir(filepath.Join(homesBaseDir, "grpA"))
+			Expect(err).To(MatchError(iofs.ErrNotExist))
+		})
+
+		It("rejects a home whose default top dirs would be too long", func() {
+			u := ports.UserInfo{UID: 2001, Home: "bob"}
+			err := bounded.PrepareUserHome(u, g)
+			Expect(err).To(MatchError(ports.ErrInvalidInput))
+			Expect(err.Error()).To(ContainSubstring("storage.max_path_length"))
+			_, err = fsm.ReadDir(filepath.Join(homesBaseDir, "grpA", "bob"))
+			Expect(err).To(MatchError(iofs.ErrNotExist))
+		})
+	})
+
+	Describe("max_home_depth", func() {
+		var bounded *fs.DefaultFsStorageService
+
+		BeforeEach(func() {
+			var err error
+			bounded, err = fs.NewDefaultFsStorageService(config.StorageConfig{
+				HomesBaseDir: homesBaseDir, DefaultUserTopDirs: []config.TopDirConfig{{Name: "_test"}}, MaxHomeDepth: 2,
+			}, fsm, true)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("accepts homes within the limit, counted after cleaning", func() {
+			g := ports.GroupInfo{GID: 2000, Home: "org/grpA/"}
+			u := ports.UserInfo{UID: 2001, Home: "team/./bob"}
+			Expect(bounded.PrepareGroupHome(g)).To(Succeed())
+			Expect(bounded.PrepareUserHome(u, g)).To(Succeed())
+			_, _, _, err := fsm.GetInfo(filepath.Join(homesBaseDir, "org", "grpA", "team", "bob", "_test"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects a too deep group home", func() {
+			err := bounded.PrepareGroupHome(ports.GroupInfo{GID: 2000, Home: "a/b/c"})
+			Expect(err).To(MatchError(ports.ErrInvalidInput))
+			Expect(err.Error()).To(ContainSubstring("storage.max_home_depth"))
+			_, err = fsm.ReadDir(filepath.Join(homesBaseDir, "a"))
+			Expect(err).To(MatchError(iofs.ErrNotExist))
+		})
+
+		It("rejects a too deep user home before creating anything", func() {
+			g := ports.GroupInfo{GID: 2000, Home: "grpA"}
+			err := bounded.PrepareUserHome(ports.UserInfo{UID: 2001, Home: "a/b/c"}, g)
+			Expect(err).To(MatchError(ports.ErrInvalidInput))
+			Expect(err.Error()).To(ContainSubstring("storage.max_home_depth"))
+			_, err = fsm.ReadDir(filepath.Join(homesBaseDir, "grpA"))
+			Expect(err).To(MatchError(iofs.ErrNotExist))
+		})
+	})
+
 	Describe("CreateUserTopDir", func() {
 		BeforeEach(func() {
 			// Ensure base structure exists
@@ -170,7 +467,7 @@ var _ = Describe("DefaultFsStorageService", func() {
 			Expect(gid).To(Equal(uint32(2000)))
 			Expect(fi.IsDir()).To(BeTrue())
 			Expect(int(fi.Mode().Perm())).To(Equal(0o770))
-			// TODO: Expect((fi.Mode()&os.ModeSetgid) != 0).To(BeTrue(), "setgid bit should be set")
+			Expect(fi.Mode()&iofs.ModeSetgid).NotTo(BeZero(), "setgid bit should be set")
 		})
 
 		It("supports relative userHome normalization (../ inside group)", func() {
@@ -191,7 +488,7 @@ var _ = Describe("DefaultFsStorageService", func() {
 			g := ports.GroupInfo{GID: 2000, Home: "grpB"}
 			err := storage.CreateUserTopDir(u, g, "uploads")
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("cannot prepare user home using absolute path"))
+			Expect(err.Error()).To(ContainSubstring("absolute user home"))
 		})
 
 		It("rejects absolute topDir", func() {
@@ -200,7 +497,7 @@ var _ = Describe("DefaultFsStorageService", func() {
 			absTop := string(filepath.Separator) + "tmp"
 			err := storage.CreateUserTopDir(u, g, absTop)
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("cannot prepare top dir using absolute path"))
+			Expect(err.Error()).To(ContainSubstring("absolute top dir"))
 		})
 
 		It("rejects traversal of topDir outside user home", func() {
@@ -213,4 +510,219 @@ var _ = Describe("DefaultFsStorageService", func() {
 
 	})
 
+	Describe("min_free_bytes and min_free_inodes", func() {
+		var guarded *fs.DefaultFsStorageService
+		u := ports.UserInfo{UID: 2002, Home: "alice"}
+		g := ports.GroupInfo{GID: 2000, Home: "grpE"}
+
+		BeforeEach(func() {
+			var err error
+			guarded, err = fs.NewDefaultFsStorageService(config.StorageConfig{
+				HomesBaseDir: homesBaseDir, DefaultUserTopDirs: []config.TopDirConfig{{Name: "_test"}}, MinFreeBytes: 1000, MinFreeInodes: 10,
+			}, fsm, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(guarded.PrepareUserHome(u, g)).To(Succeed())
+		})
+
+		inodesUsed := func() uint64 {
+			fsm.SetCapacity(math.MaxUint64, math.MaxUint64)
+			st, err := fsm.StatFS(homesBaseDir)
+			Expect(err).NotTo(HaveOccurred())
+			return math.MaxUint64 - st.FreeInodes
+		}
+
+		It("creates top dirs while enough inodes and bytes are free", func() {
+			fsm.SetCapacity(1_000_000, inodesUsed()+10)
+			Expect(guarded.CreateUserTopDir(u, g, "uploads")).To(Succeed())
+		})
+
+		It("refuses a new top dir once the free inodes fall below the threshold", func() {
+			fsm.SetCapacity(1_000_000, inodesUsed()+9)
+			err := guarded.CreateUserTopDir(u, g, "uploads")
+			Expect(err).To(MatchError(ports.ErrInsufficientStorage))
+			Expect(err.Error()).To(ContainSubstring("storage.min_free_inodes is 10"))
+			_, _, _, err = fsm.GetInfo(filepath.Join(homesBaseDir, "grpE", "alice", "uploads"))
+			Expect(err).To(MatchError(iofs.ErrNotExist))
+
+			// an existing top dir is still ensured
+			Expect(guarded.CreateUserTopDir(u, g, "_test")).To(Succeed())
+		})
+
+		It("refuses a new top dir once the free bytes fall below the threshold", func() {
+			fsm.SetCapacity(1_000_000, math.MaxUint64)
+			Expect(fsm.WriteFile(filepath.Join(homesBaseDir, "grpE", "alice", "_test", "big.bin"), 999_500)).To(Succeed())
+			err := guarded.CreateUserTopDir(u, g, "uploads")
+			Expect(err).To(MatchError(ports.ErrInsufficientStorage))
+			Expect(err.Error()).To(ContainSubstring("500 bytes free"))
+		})
+
+		It("doesn't stat the filesystem when no threshold is set", func() {
+			fsm.SetCapacity(0, 0)
+			Expect(storage.CreateUserTopDir(u, g, "uploads")).To(Succeed())
+		})
+	})
+
+	Describe("top_dir_name_pattern", func() {
+		var patterned *fs.DefaultFsStorageService
+		u := ports.UserInfo{UID: 2002, Home: "alice"}
+		g := ports.GroupInfo{GID: 2000, Home: "grpF"}
+
+		BeforeEach(func() {
+			var err error
+			patterned, err = fs.NewDefaultFsStorageService(config.StorageConfig{
+				HomesBaseDir: homesBaseDir, DefaultUserTopDirs: []config.TopDirConfig{{Name: "_test"}}, TopDirNamePattern: `^[A-Za-z0-9_.-]{1,64}$`,
+			}, fsm, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(patterned.PrepareUserHome(u, g)).To(Succeed())
+		})
+
+		DescribeTable("accepts names matching the pattern",
+			func(name string) {
+				Expect(patterned.CreateUserTopDir(u, g, name)).To(Succeed())
+			},
+			Entry("letters", "uploads"),
+			Entry("all allowed chars", "a.B-c_9"),
+			Entry("dot prefixed", ".config"),
+			Entry("64 chars", strings.Repeat("d", 64)),
+		)
+
+		DescribeTable("refuses other names before any filesystem call",
+			func(name string) {
+				Expect(patterned.CreateUserTopDir(u, g, name)).To(MatchError(ports.ErrInvalidInput))
+				entries, err := fsm.ReadDir(filepath.Join(homesBaseDir, "grpF", "alice"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(HaveLen(1)) // _test only
+			},
+			Entry("dot", "."),
+			Entry("dot dot", ".."),
+			Entry("a space", "my dir"),
+			Entry("a tab", "my\tdir"),
+			Entry("a newline", "dir\n"),
+			Entry("65 chars", strings.Repeat("d", 65)),
+			Entry("empty", ""),
+			Entry("unicode look-alike", "uplоads"), // Cyrillic "о"
+			Entry("a nested path", "a/b"),
+		)
+
+		It("always refuses . and .. even without a pattern", func() {
+			Expect(storage.CreateUserTopDir(u, g, "..")).To(MatchError(ports.ErrInvalidInput))
+			Expect(storage.CreateUserTopDir(u, g, ".")).To(MatchError(ports.ErrInvalidInput))
+		})
+	})
+
+	Describe("ListUserTopDirsDetailed", func() {
+		It("returns mode and ownership of the top dirs", func() {
+			u := ports.UserInfo{UID: 2003, Home: "carol"}
+			g := ports.GroupInfo{GID: 2000, Home: "grpC"}
+			Expect(storage.PrepareUserHome(u, g)).To(Succeed())
+
+			dirs, _, err := storage.ListUserTopDirsDetailed(u, g)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dirs).To(HaveLen(1))
+			Expect(dirs[0].Name).To(Equal("_test"))
+			Expect(dirs[0].UID).To(Equal(uint32(2003)))
+			Expect(dirs[0].GID).To(Equal(uint32(2000)))
+			Expect(dirs[0].Mode.IsDir()).To(BeTrue())
+			Expect(dirs[0].Mode.Perm()).To(Equal(iofs.FileMode(0o770)))
+		})
+	})
+
+	Describe("GroupUsageBytes", func() {
+		It("sums the files stored by all members", func() {
+			g := ports.GroupInfo{GID: 2000, Home: "grpD"}
+			alice := ports.UserInfo{UID: 2004, Home: "alice"}
+			bob := ports.UserInfo{UID: 2005, Home: "bob"}
+			Expect(storage.PrepareUserHome(alice, g)).To(Succeed())
+			Expect(storage.PrepareUserHome(bob, g)).To(Succeed())
+			Expect(fsm.WriteFile(filepath.Join(homesBaseDir, "grpD", "alice", "_test", "a.bin"), 300)).To(Succeed())
+			Expect(fsm.WriteFile(filepath.Join(homesBaseDir, "grpD", "bob", "_test", "b.bin"), 200)).To(Succeed())
+			Expect(fsm.WriteFile(filepath.Join(homesBaseDir, "grpD", "bob", "c.bin"), 24)).To(Succeed())
+
+			usage, err := storage.GroupUsageBytes(g)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usage).To(Equal(uint64(524)))
+		})
+
+		It("reports no usage for a group home not created yet", func() {
+			usage, err := storage.GroupUsageBytes(ports.GroupInfo{GID: 2000, Home: "grpMissing"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usage).To(BeZero())
+		})
+
+		It("rejects a group home escaping root", func() {
+			_, err := storage.GroupUsageBytes(ports.GroupInfo{GID: 2000, Home: filepath.Join("..", "escape")})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(" escapes "))
+		})
+	})
+
+	Describe("RemoveGroupHome", func() {
+		It("removes an empty group home", func() {
+			g := ports.GroupInfo{GID: 2000, Home: "grpE"}
+			Expect(storage.PrepareGroupHome(g)).To(Succeed())
+			Expect(storage.RemoveGroupHome(g)).To(Succeed())
+			_, err := fsm.ReadDir(filepath.Join(homesBaseDir, "grpE"))
+			Expect(err).To(MatchError(iofs.ErrNotExist))
+		})
+
+		It("refuses a group home that isn't empty", func() {
+			g := ports.GroupInfo{GID: 2000, Home: "grpF"}
+			Expect(storage.PrepareUserHome(ports.UserInfo{UID: 2006, Home: "carol"}, g)).To(Succeed())
+			Expect(storage.RemoveGroupHome(g)).To(MatchError(ports.ErrConflict))
+			Expect(fsm.ReadDir(filepath.Join(homesBaseDir, "grpF"))).To(HaveLen(1))
+		})
+
+		It("accepts a group home not created yet", func() {
+			Expect(storage.RemoveGroupHome(ports.GroupInfo{GID: 2000, Home: "grpMissing"})).To(Succeed())
+		})
+
+		It("refuses the homes base dir and homes escaping root", func() {
+			Expect(storage.RemoveGroupHome(ports.GroupInfo{GID: 2000, Home: "."})).To(HaveOccurred())
+			err := storage.RemoveGroupHome(ports.GroupInfo{GID: 2000, Home: filepath.Join("..", "escape")})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(" escapes "))
+		})
+	})
+
+	Describe("homes base dir creation", func() {
+		It("creates a missing base dir on every start with create_homes_base_dir_always", func() {
+			fresh := filepath.Join(homesBaseDir, "fresh-volume")
+			_, err := fs.NewDefaultFsStorageService(config.StorageConfig{HomesBaseDir: fresh, CreateHomesBaseDirAlways: true}, fsm, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fsm.ReadDir(fresh)).To(BeEmpty())
+		})
+
+		It("requires bootstrap with create_homes_base_dir only", func() {
+			fresh := filepath.Join(homesBaseDir, "fresh-volume")
+			_, err := fs.NewDefaultFsStorageService(config.StorageConfig{HomesBaseDir: fresh, CreateHomesBaseDir: true}, fsm, false)
+			Expect(err).To(MatchError(ContainSubstring("root directory invalid")))
+		})
+	})
+
+	Describe("implementation mismatch", func() {
+		var logs *bytes.Buffer
+
+		BeforeEach(func() {
+			logs = &bytes.Buffer{}
+			prev := log.Writer()
+			log.SetOutput(logs)
+			DeferCleanup(func() { log.SetOutput(prev) })
+		})
+
+		It("warns when storage.implementation does not match the injected service", func() {
+			_, err := fs.NewDefaultFsStorageService(config.StorageConfig{
+				Implementation: "unix", HomesBaseDir: homesBaseDir, DefaultUserTopDirs: []config.TopDirConfig{{Name: "_test"}},
+			}, fsm, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(logs.String()).To(ContainSubstring(`WARNING: storage.implementation is "unix" but the filesystem service in use is "inmem"`))
+		})
+
+		It("stays silent when they match", func() {
+			_, err := fs.NewDefaultFsStorageService(config.StorageConfig{
+				Implementation: "inmem", HomesBaseDir: homesBaseDir, DefaultUserTopDirs: []config.TopDirConfig{{Name: "_test"}},
+			}, fsm, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(logs.String()).NotTo(ContainSubstring("storage.implementation"))
+		})
+	})
 })
@@ -12,6 +12,7 @@ type AuthzAction struct {
 	Username        string
 	Result          string
 	DurationFloat64 float64
+	Trace           string
 }
 
 // Enforce compile-time conformance to the interface
@@ -21,15 +22,21 @@ func (a *AuthzAction) Duration() float64 {
 	return a.DurationFloat64
 }
 
-func NewAuthzAction(action, username string) *AuthzAction {
+// NewAuthzAction starts measuring an action, traceID is "" when the request carries no trace context.
+func NewAuthzAction(action, username, traceID string) *AuthzAction {
 	return &AuthzAction{
 		start:    time.Now(),
 		Action:   action,
 		Username: username,
 		Result:   "unknown",
+		Trace:    traceID,
 	}
 }
 
+func (a *AuthzAction) TraceID() string {
+	return a.Trace
+}
+
 func (a *AuthzAction) Done(result ports.MeasuredActionResult) ports.MeasuredAction {
 	a.Result = string(result)
 	a.DurationFloat64 = time.Since(a.start).Seconds()
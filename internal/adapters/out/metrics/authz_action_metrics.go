@@ -46,7 +46,7 @@ func NewAuthzActionMetrics(programName, programVersion string, cfg config.Metric
 				Namespace:   cfg.Namespace,
 				Name:        "authz_action_duration_seconds",
 				Help:        "Distribution of authorization action durations in seconds.",
-				Buckets:     []float64{0.010, 0.100, 0.500, 1.0, 3.0, 5.0, 10.0},
+				Buckets:     cfg.ActionDurationBuckets,
 				ConstLabels: prometheus.Labels{"environment": cfg.Environment},
 			},
 			actionLabels,
@@ -79,6 +79,11 @@ func (m *AuthzActionMetrics) OnActionDone(ma ports.MeasuredAction) {
 		string(ports.MALabelUsername): mal[ports.MALabelUsername],
 		string(ports.MALabelResult):   mal[ports.MALabelResult],
 	}
-	m.ActionDurationHistogram.With(labels).Observe(ma.Duration())
+	observer := m.ActionDurationHistogram.With(labels)
+	if eo, ok := observer.(prometheus.ExemplarObserver); ok && m.cfg.TraceExemplars && ma.TraceID() != "" {
+		eo.ObserveWithExemplar(ma.Duration(), prometheus.Labels{"trace_id": ma.TraceID()})
+	} else {
+		observer.Observe(ma.Duration())
+	}
 	m.UserActionsTotal.With(userLabels).Inc()
 }
@@ -0,0 +1,90 @@
+package metrics_test
+
+import (
+	"fs-access-api/internal/adapters/out/metrics"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuthzActionMetrics exemplars", func() {
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	// exemplarTraceIDs gathers the trace_id of the exemplars attached to the duration histogram buckets.
+	exemplarTraceIDs := func(reg *prometheus.Registry) []string {
+		families, err := reg.Gather()
+		Expect(err).NotTo(HaveOccurred())
+		var ids []string
+		for _, mf := range families {
+			if mf.GetName() != "fsaa_authz_action_duration_seconds" {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				for _, b := range m.GetHistogram().GetBucket() {
+					ids = append(ids, exemplarLabel(b.GetExemplar(), "trace_id")...)
+				}
+			}
+		}
+		return ids
+	}
+
+	observe := func(cfg config.MetricsContext, trace string) *prometheus.Registry {
+		reg := prometheus.NewRegistry()
+		m, err := metrics.NewAuthzActionMetrics("fs-access-api", "test", cfg, reg)
+		Expect(err).NotTo(HaveOccurred())
+		m.OnActionDone(metrics.NewAuthzAction("auth", "alice", trace).Done(ports.MAResultSuccess))
+		return reg
+	}
+
+	It("attaches the trace id of a traced observation as an exemplar", func() {
+		reg := observe(config.MetricsContext{Namespace: "fsaa", TraceExemplars: true}, traceID)
+		Expect(exemplarTraceIDs(reg)).To(ConsistOf(traceID))
+	})
+
+	It("attaches nothing without a trace id", func() {
+		reg := observe(config.MetricsContext{Namespace: "fsaa", TraceExemplars: true}, "")
+		Expect(exemplarTraceIDs(reg)).To(BeEmpty())
+	})
+
+	It("attaches nothing unless metrics.trace_exemplars is set", func() {
+		reg := observe(config.MetricsContext{Namespace: "fsaa"}, traceID)
+		Expect(exemplarTraceIDs(reg)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("AuthzActionMetrics buckets", func() {
+	It("uses metrics.action_duration_buckets as the duration histogram bounds", func() {
+		reg := prometheus.NewRegistry()
+		cfg := config.MetricsContext{Namespace: "fsaa", ActionDurationBuckets: []float64{0.001, 0.01}}
+		m, err := metrics.NewAuthzActionMetrics("fs-access-api", "test", cfg, reg)
+		Expect(err).NotTo(HaveOccurred())
+		m.OnActionDone(metrics.NewAuthzAction("auth", "alice", "").Done(ports.MAResultSuccess))
+
+		families, err := reg.Gather()
+		Expect(err).NotTo(HaveOccurred())
+		var bounds []float64
+		for _, mf := range families {
+			if mf.GetName() == "fsaa_authz_action_duration_seconds" {
+				for _, b := range mf.GetMetric()[0].GetHistogram().GetBucket() {
+					bounds = append(bounds, b.GetUpperBound())
+				}
+			}
+		}
+		Expect(bounds).To(Equal([]float64{0.001, 0.01}))
+	})
+})
+
+func exemplarLabel(e *dto.Exemplar, name string) []string {
+	var values []string
+	for _, l := range e.GetLabel() {
+		if l.GetName() == name {
+			values = append(values, l.GetValue())
+		}
+	}
+	return values
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type HomeDriftMetrics struct {
+	HomeDriftTotal *prometheus.CounterVec
+}
+
+// Enforce compile-time conformance to the interface
+var _ ports.HomeDriftMetrics = (*HomeDriftMetrics)(nil)
+
+func NewHomeDriftMetrics(cfg config.MetricsContext, reg prometheus.Registerer) *HomeDriftMetrics {
+	return &HomeDriftMetrics{
+		HomeDriftTotal: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   cfg.Namespace,
+				Name:        "home_drift_total",
+				Help:        "Total number of user home mismatches (missing, owner, mode) found by the home drift checks.",
+				ConstLabels: prometheus.Labels{"environment": cfg.Environment},
+			},
+			[]string{"kind"},
+		),
+	}
+}
+
+// OnHomeDrift counts a mismatch found by a home drift check.
+func (m *HomeDriftMetrics) OnHomeDrift(drift ports.HomeDrift) {
+	m.HomeDriftTotal.WithLabelValues(string(drift.Kind)).Inc()
+}
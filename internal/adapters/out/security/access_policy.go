@@ -0,0 +1,53 @@
+package security
+
+import (
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+)
+
+// KeyAccessPolicy enforces the scopes and rate limits configured per access key.
+type KeyAccessPolicy struct {
+	keys         map[string]config.AccessKey
+	defaultLimit config.RateLimitConfig
+	limiter      *RateLimiter
+}
+
+// Enforce compile-time conformance to the interface
+var _ ports.AccessPolicy = (*KeyAccessPolicy)(nil)
+
+// NewKeyAccessPolicy limits the keys without a rate_limit of their own with defaultLimit.
+func NewKeyAccessPolicy(authCfg config.AuthenticatorConfig, defaultLimit config.RateLimitConfig) *KeyAccessPolicy {
+	return &KeyAccessPolicy{keys: authCfg.AccessKeys, defaultLimit: defaultLimit, limiter: NewRateLimiter()}
+}
+
+// Authorize checks the scope first, so requests denied by scope don't drain the key's bucket.
+// An empty scope can only be satisfied by keys without scope restrictions.
+func (p *KeyAccessPolicy) Authorize(apiKey, scope string) error {
+	key, ok := p.keys[apiKey]
+	if !ok {
+		return ports.ErrInsufficientScope
+	}
+	if len(key.Scopes) > 0 && !hasScope(key.Scopes, scope) {
+		return ports.ErrInsufficientScope
+	}
+	limit := key.RateLimit
+	if limit.RequestsPerSecond == 0 {
+		limit = p.defaultLimit
+	}
+	if ok, retryAfter := p.limiter.Allow(apiKey, limit); !ok {
+		return &ports.RateLimitedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+func hasScope(granted []string, scope string) bool {
+	if scope == "" {
+		return false
+	}
+	for _, g := range granted {
+		if g == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package security_test
+
+import (
+	"fs-access-api/internal/adapters/out/security"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyAccessPolicy", func() {
+	var policy *security.KeyAccessPolicy
+
+	BeforeEach(func() {
+		policy = security.NewKeyAccessPolicy(config.AuthenticatorConfig{
+			AccessKeys: map[string]config.AccessKey{
+				"admin":  {Secret: "00"},
+				"reader": {Secret: "00", Scopes: []string{ports.ScopeUsersRead, ports.ScopeGroupsRead}},
+				"slow":   {Secret: "00", RateLimit: config.RateLimitConfig{RequestsPerSecond: 0.001, Burst: 2}},
+			},
+		}, config.RateLimitConfig{})
+	})
+
+	It("grants everything to keys without scopes", func() {
+		Expect(policy.Authorize("admin", ports.ScopeUsersWrite)).To(Succeed())
+		Expect(policy.Authorize("admin", "")).To(Succeed())
+	})
+
+	It("checks the granted scopes", func() {
+		Expect(policy.Authorize("reader", ports.ScopeUsersRead)).To(Succeed())
+		Expect(policy.Authorize("reader", ports.ScopeUsersWrite)).To(MatchError(ports.ErrInsufficientScope))
+		Expect(policy.Authorize("reader", "")).To(MatchError(ports.ErrInsufficientScope))
+		Expect(policy.Authorize("unknown", ports.ScopeUsersRead)).To(MatchError(ports.ErrInsufficientScope))
+	})
+
+	It("rate limits per key once the burst is spent", func() {
+		Expect(policy.Authorize("slow", ports.ScopeUsersRead)).To(Succeed())
+		Expect(policy.Authorize("slow", ports.ScopeUsersRead)).To(Succeed())
+		err := policy.Authorize("slow", ports.ScopeUsersRead)
+		Expect(err).To(MatchError(ports.ErrRateLimited))
+		var rle *ports.RateLimitedError
+		Expect(err).To(BeAssignableToTypeOf(rle))
+		Expect(err.(*ports.RateLimitedError).RetryAfter).To(BeNumerically(">", 0))
+		// other keys have their own buckets
+		Expect(policy.Authorize("admin", ports.ScopeUsersRead)).To(Succeed())
+	})
+
+	It("limits the keys without their own rate limit with the default one", func() {
+		policy = security.NewKeyAccessPolicy(config.AuthenticatorConfig{
+			AccessKeys: map[string]config.AccessKey{
+				"admin": {Secret: "00"},
+				"fast":  {Secret: "00", RateLimit: config.RateLimitConfig{RequestsPerSecond: 1000, Burst: 1000}},
+			},
+		}, config.RateLimitConfig{RequestsPerSecond: 0.001, Burst: 1})
+		Expect(policy.Authorize("admin", ports.ScopeUsersRead)).To(Succeed())
+		Expect(policy.Authorize("admin", ports.ScopeUsersRead)).To(MatchError(ports.ErrRateLimited))
+		for range 3 {
+			Expect(policy.Authorize("fast", ports.ScopeUsersRead)).To(Succeed())
+		}
+	})
+})
@@ -0,0 +1,96 @@
+package security
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"fs-access-api/internal/app/ports"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Argon2id defaults (RFC 9106 second recommended option) and hard limits on the configured cost.
+// Verify is capped lower, at the configured cost (see verifyArgon2id).
+const (
+	defaultArgon2Memory      = 64 * 1024 // KiB
+	defaultArgon2Time        = 3
+	defaultArgon2Parallelism = 4
+	maxArgon2Memory          = 1024 * 1024 // KiB, 1 GiB
+	maxArgon2Time            = 100
+	argon2KeyLen             = 32
+	minArgon2SaltLen         = 8
+	maxArgon2SaltLen         = 16
+	maxArgon2KeyLen          = 128
+)
+
+// argon2Params are the cost parameters of an argon2id hash, memory in KiB.
+type argon2Params struct {
+	memory      uint32
+	time        uint32
+	parallelism uint8
+}
+
+func (p argon2Params) validate() error {
+	if p.memory < 8*uint32(p.parallelism) || p.memory > maxArgon2Memory {
+		return fmt.Errorf("argon2id memory must be between 8*parallelism and %d KiB, got %d", maxArgon2Memory, p.memory)
+	}
+	if p.time < 1 || p.time > maxArgon2Time {
+		return fmt.Errorf("argon2id time must be between 1 and %d, got %d", maxArgon2Time, p.time)
+	}
+	if p.parallelism < 1 {
+		return fmt.Errorf("argon2id parallelism must be positive")
+	}
+	return nil
+}
+
+// hashArgon2id returns a PHC string like `$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>`.
+func hashArgon2id(plain string, p argon2Params, saltLen int, rr io.Reader) (string, error) {
+	if saltLen < minArgon2SaltLen || saltLen > maxArgon2SaltLen {
+		return "", fmt.Errorf("argon2id salt length must be between %d and %d", minArgon2SaltLen, maxArgon2SaltLen)
+	}
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(rr, salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(plain), salt, p.time, p.memory, p.parallelism, argon2KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, p.memory, p.time, p.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// verifyArgon2id parses the PHC string, refusing (ErrInvalidInput) a cost above ceiling, the configured
+// one, before hashing: the public verify endpoint takes client supplied hashes, which must not make
+// it allocate more memory or spend more passes than hashing a password does.
+func verifyArgon2id(hashed, plain string, ceiling argon2Params) (bool, error) {
+	parts := strings.Split(strings.TrimSpace(hashed), "$")
+	// "", argon2id, v=19, m=..,t=..,p=.., salt, hash
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return false, fmt.Errorf("malformed argon2id hash")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, fmt.Errorf("unsupported argon2id version %q", parts[2])
+	}
+	var p argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.parallelism); err != nil {
+		return false, fmt.Errorf("malformed argon2id parameters %q", parts[3])
+	}
+	if err := p.validate(); err != nil {
+		return false, err
+	}
+	if p.memory > ceiling.memory || p.time > ceiling.time || p.parallelism > ceiling.parallelism {
+		return false, ports.InvalidField("hash", "argon2id cost m=%d,t=%d,p=%d exceeds the configured m=%d,t=%d,p=%d",
+			p.memory, p.time, p.parallelism, ceiling.memory, ceiling.time, ceiling.parallelism)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, fmt.Errorf("malformed argon2id salt: %w", err)
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(want) == 0 || len(want) > maxArgon2KeyLen {
+		return false, fmt.Errorf("malformed argon2id hash value")
+	}
+	got := argon2.IDKey([]byte(plain), salt, p.time, p.memory, p.parallelism, uint32(len(want)))
+	return subtle.ConstantTimeCompare(got, want) == 1, nil
+}
@@ -2,6 +2,8 @@ package security
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -12,8 +14,8 @@ import (
 )
 
 type BearerAuthenticator struct {
-	// accessSecrets maps public key-id -> secret bytes
-	accessSecrets map[string]string
+	// accessSecrets maps public key-id -> SHA-256 of its hex secrets, the secrets themselves aren't kept
+	accessSecrets map[string][][sha256.Size]byte
 }
 
 // Enforce compile-time conformance to the interface
@@ -26,17 +28,22 @@ const (
 
 func NewBearerAuthenticator(authCfg config.AuthenticatorConfig) (*BearerAuthenticator, error) {
 	// decode hex secrets
-	secrets := make(map[string]string, len(authCfg.AccessKeys))
-	for keyID, hexSecret := range authCfg.AccessKeys {
-		hexSecret = strings.TrimSpace(hexSecret)
-		if hexSecret == "" {
+	secrets := make(map[string][][sha256.Size]byte, len(authCfg.AccessKeys))
+	for keyID, accessKey := range authCfg.AccessKeys {
+		if len(accessKey.AllSecrets()) == 0 {
 			return nil, errors.New("empty secret for key " + keyID)
 		}
-		_, err := hex.DecodeString(hexSecret)
-		if err != nil {
-			return nil, errors.New("invalid hex secret for key " + keyID + ": " + err.Error())
+		for _, secret := range accessKey.AllSecrets() {
+			hexSecret := strings.TrimSpace(secret)
+			if hexSecret == "" {
+				return nil, errors.New("empty secret for key " + keyID)
+			}
+			_, err := hex.DecodeString(hexSecret)
+			if err != nil {
+				return nil, errors.New("invalid hex secret for key " + keyID + ": " + err.Error())
+			}
+			secrets[keyID] = append(secrets[keyID], sha256.Sum256([]byte(hexSecret)))
 		}
-		secrets[keyID] = hexSecret
 	}
 
 	return &BearerAuthenticator{
@@ -57,15 +64,20 @@ func (s *BearerAuthenticator) Verify(r *http.Request) error {
 	if apiKey == "" || authz == "" {
 		return fmt.Errorf("missing auth headers")
 	}
-	secretHex, ok := s.accessSecrets[apiKey]
+	secretSums, ok := s.accessSecrets[apiKey]
 	if !ok {
 		return fmt.Errorf("unknown api key")
 	}
 	if !strings.HasPrefix(authz, bearerScheme+" ") {
 		return fmt.Errorf("invalid auth scheme")
 	}
-	sigHex := strings.TrimPrefix(authz, bearerScheme+" ")
-	if sigHex != secretHex {
+	// comparing the fixed-size digests in constant time leaks neither the content nor the length of the secret
+	sigSum := sha256.Sum256([]byte(strings.TrimPrefix(authz, bearerScheme+" ")))
+	matched := 0
+	for _, secretSum := range secretSums {
+		matched |= subtle.ConstantTimeCompare(sigSum[:], secretSum[:])
+	}
+	if matched != 1 {
 		return fmt.Errorf("not verified")
 	}
 	return nil
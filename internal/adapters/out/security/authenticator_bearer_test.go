@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	. "github.com/onsi/ginkgo/v2"
@@ -37,7 +38,7 @@ var _ = Describe("BearerAuthenticator.Verify", func() {
 	BeforeEach(func() {
 		sec := config.AuthenticatorConfig{
 			WindowSeconds: 300,
-			AccessKeys:    map[string]string{apiKeyID: secretHex},
+			AccessKeys:    map[string]config.AccessKey{apiKeyID: {Secret: secretHex}},
 		}
 		var err error
 		auth, err = security.NewBearerAuthenticator(sec)
@@ -67,6 +68,50 @@ var _ = Describe("BearerAuthenticator.Verify", func() {
 		Expect(err).To(HaveOccurred())
 	})
 
+	// the digests are compared in constant time, only the outcome can be asserted here
+	DescribeTable("rejects secrets differing from the configured one",
+		func(token string) {
+			Expect(auth.Verify(newBearerRequest(http.MethodGet, "http://example.test/api/users", nil, apiKeyID, token))).To(MatchError("not verified"))
+		},
+		Entry("a prefix", secretHex[:63]),
+		Entry("one char longer", secretHex+"0"),
+		Entry("the last char changed", secretHex[:63]+"e"),
+		Entry("in upper case", strings.ToUpper(secretHex)),
+		Entry("empty", ""),
+	)
+
+	It("accepts the secret of the presented key only", func() {
+		other := "ffeeddccbbaa99887766554433221100ffeeddccbbaa99887766554433221100"
+		auth, err := security.NewBearerAuthenticator(config.AuthenticatorConfig{
+			AccessKeys: map[string]config.AccessKey{apiKeyID: {Secret: secretHex}, "other-key": {Secret: other}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.Verify(newBearerRequest(http.MethodGet, "http://example.test/api/users", nil, "other-key", other))).To(Succeed())
+		Expect(auth.Verify(newBearerRequest(http.MethodGet, "http://example.test/api/users", nil, "other-key", secretHex))).To(MatchError("not verified"))
+	})
+})
+
+var _ = Describe("BearerAuthenticator with a rotated secret", func() {
+	It("accepts the old and the new secret during the overlap", func() {
+		const (
+			oldHex = "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+			newHex = "ffeeddccbbaa99887766554433221100ffeeddccbbaa99887766554433221100"
+		)
+		auth, err := security.NewBearerAuthenticator(config.AuthenticatorConfig{
+			AccessKeys: map[string]config.AccessKey{"test-key": {Secret: oldHex, Secrets: []string{newHex}}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.Verify(newBearerRequest(http.MethodGet, "http://example.test/api/users", nil, "test-key", oldHex))).To(Succeed())
+		Expect(auth.Verify(newBearerRequest(http.MethodGet, "http://example.test/api/users", nil, "test-key", newHex))).To(Succeed())
+		Expect(auth.Verify(newBearerRequest(http.MethodGet, "http://example.test/api/users", nil, "test-key", "deadbeef"))).To(MatchError("not verified"))
+	})
+
+	It("refuses a key without any secret", func() {
+		_, err := security.NewBearerAuthenticator(config.AuthenticatorConfig{
+			AccessKeys: map[string]config.AccessKey{"test-key": {Secrets: []string{}}},
+		})
+		Expect(err).To(MatchError("empty secret for key test-key"))
+	})
 })
 
 var _ = Describe("BearerAuthenticator.WithAuthChi middleware", func() {
@@ -81,7 +126,7 @@ var _ = Describe("BearerAuthenticator.WithAuthChi middleware", func() {
 	BeforeEach(func() {
 		sec := config.AuthenticatorConfig{
 			WindowSeconds: 300,
-			AccessKeys:    map[string]string{apiKeyID: secretHex},
+			AccessKeys:    map[string]config.AccessKey{apiKeyID: {Secret: secretHex}},
 		}
 		var err error
 		auth, err = security.NewBearerAuthenticator(sec)
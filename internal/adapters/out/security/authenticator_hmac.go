@@ -5,11 +5,13 @@ import (
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"fs-access-api/internal/app/config"
 	"fs-access-api/internal/app/ports"
+	"hash"
 	"io"
 	"net/http"
 	"strings"
@@ -18,20 +20,34 @@ import (
 
 type HMACAuthenticator struct {
 	window time.Duration
-	// accessSecrets maps public key-id -> secret bytes
-	accessSecrets map[string][]byte
+	// accessSecrets maps public key-id -> secrets bytes, several while a secret is rotated
+	accessSecrets map[string][][]byte
+	// defaultHash is security.authenticator.hmac_hash, used when the request doesn't send X-Hmac-Hash
+	defaultHash string
+	// allowedHashes are the hashes X-Hmac-Hash may name: hmac_hash and hmac_allowed_hashes
+	allowedHashes map[string]bool
+	clock         ports.Clock
 }
 
 // Enforce compile-time conformance to the interface
 var _ ports.Authenticator = (*HMACAuthenticator)(nil)
+var _ ports.ResponseSigner = (*HMACAuthenticator)(nil)
 
 // Headers as constants for consistency
 const (
 	hmacScheme        = "HMAC"
 	hmacHdrTimestamp  = "X-Timestamp"
 	hmacHdrBodySHA256 = "X-Content-Sha256"
+	// hmacHdrHash names the hash of the signature, it's then signed too as the last canonical line
+	hmacHdrHash = "X-Hmac-Hash"
 )
 
+// hmacHashes are the hashes a signature may be computed with.
+var hmacHashes = map[string]func() hash.Hash{
+	config.HmacHashSHA256: sha256.New,
+	config.HmacHashSHA512: sha512.New,
+}
+
 // Context key + helper if you want to pass identity down the stack.
 type ctxKey string
 
@@ -40,27 +56,54 @@ func NewHMACAuthenticator(authCfg config.AuthenticatorConfig) (*HMACAuthenticato
 	if win <= 0 {
 		win = 5 * time.Minute
 	}
+	defaultHash := authCfg.HmacHash
+	if defaultHash == "" {
+		defaultHash = config.HmacHashSHA256
+	}
+	if _, ok := hmacHashes[defaultHash]; !ok {
+		return nil, fmt.Errorf("unsupported hmac hash %q", defaultHash)
+	}
+	allowedHashes := map[string]bool{defaultHash: true}
+	for _, name := range authCfg.HmacAllowedHashes {
+		if _, ok := hmacHashes[name]; !ok {
+			return nil, fmt.Errorf("unsupported hmac hash %q", name)
+		}
+		allowedHashes[name] = true
+	}
 
 	// decode hex secrets
-	secrets := make(map[string][]byte, len(authCfg.AccessKeys))
-	for keyID, hexSecret := range authCfg.AccessKeys {
-		hexSecret = strings.TrimSpace(hexSecret)
-		if hexSecret == "" {
+	secrets := make(map[string][][]byte, len(authCfg.AccessKeys))
+	for keyID, accessKey := range authCfg.AccessKeys {
+		if len(accessKey.AllSecrets()) == 0 {
 			return nil, errors.New("empty secret for key " + keyID)
 		}
-		raw, err := hex.DecodeString(hexSecret)
-		if err != nil {
-			return nil, errors.New("invalid hex secret for key " + keyID + ": " + err.Error())
+		for _, secret := range accessKey.AllSecrets() {
+			hexSecret := strings.TrimSpace(secret)
+			if hexSecret == "" {
+				return nil, errors.New("empty secret for key " + keyID)
+			}
+			raw, err := hex.DecodeString(hexSecret)
+			if err != nil {
+				return nil, errors.New("invalid hex secret for key " + keyID + ": " + err.Error())
+			}
+			secrets[keyID] = append(secrets[keyID], raw)
 		}
-		secrets[keyID] = raw
 	}
 
 	return &HMACAuthenticator{
 		window:        win,
 		accessSecrets: secrets,
+		defaultHash:   defaultHash,
+		allowedHashes: allowedHashes,
+		clock:         ports.SystemClock,
 	}, nil
 }
 
+// SetClock replaces the system clock the timestamp window is checked against.
+func (s *HMACAuthenticator) SetClock(clock ports.Clock) {
+	s.clock = clock
+}
+
 func (s *HMACAuthenticator) Supports(r *http.Request) bool {
 	authz := r.Header.Get(hdrAuthz)
 	return strings.HasPrefix(authz, hmacScheme+" ")
@@ -76,8 +119,10 @@ func (s *HMACAuthenticator) Verify(r *http.Request) error {
 	if apiKey == "" || authz == "" || tsStr == "" || bodySHA == "" {
 		return fmt.Errorf("missing auth headers")
 	}
-	secret, ok := s.accessSecrets[apiKey]
-	if !ok {
+	if _, err := s.requestHash(r); err != nil {
+		return err
+	}
+	if _, ok := s.accessSecrets[apiKey]; !ok {
 		return fmt.Errorf("unknown api key")
 	}
 	if !strings.HasPrefix(authz, "HMAC ") {
@@ -90,7 +135,7 @@ func (s *HMACAuthenticator) Verify(r *http.Request) error {
 	if err != nil {
 		return fmt.Errorf("bad timestamp")
 	}
-	now := time.Now().UTC()
+	now := s.clock.Now().UTC()
 	if d := now.Sub(ts); d > s.window || d < -s.window {
 		return fmt.Errorf("timestamp outside allowed window")
 	}
@@ -104,33 +149,89 @@ func (s *HMACAuthenticator) Verify(r *http.Request) error {
 		return fmt.Errorf("body hash mismatch")
 	}
 
+	provided, err := hex.DecodeString(sigHex)
+	if err != nil {
+		return fmt.Errorf("bad signature encoding")
+	}
+	if s.requestSecret(r, provided, localHash) == nil {
+		return fmt.Errorf("bad signature")
+	}
+
+	return nil
+}
+
+// requestHash is the hash the request is signed with: the one named by X-Hmac-Hash, hmac_hash without it.
+// The header may only name hmac_hash or one of hmac_allowed_hashes.
+func (s *HMACAuthenticator) requestHash(r *http.Request) (func() hash.Hash, error) {
+	name := r.Header.Get(hmacHdrHash)
+	if name == "" {
+		name = s.defaultHash
+	}
+	h, ok := hmacHashes[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported %s %q", hmacHdrHash, name)
+	}
+	if !s.allowedHashes[strings.ToLower(name)] {
+		return nil, fmt.Errorf("%s %q not allowed", hmacHdrHash, name)
+	}
+	return h, nil
+}
+
+// requestSecret returns the secret of the request's api key that produced the provided signature
+// over the canonical request, nil when none did.
+func (s *HMACAuthenticator) requestSecret(r *http.Request, provided []byte, bodyHash string) []byte {
+	newHash, err := s.requestHash(r)
+	if err != nil {
+		return nil
+	}
 	// Canonical path: prefer EscapedPath to preserve encoding, avoid Clean()
 	pathWithQuery := r.URL.EscapedPath()
 	if raw := r.URL.RawQuery; raw != "" {
 		pathWithQuery = pathWithQuery + "?" + raw
 	}
 
-	canonical := strings.Join([]string{
+	lines := []string{
 		r.Method,
 		pathWithQuery,
-		tsStr,
-		localHash,
-	}, "\n")
+		r.Header.Get(hmacHdrTimestamp),
+		bodyHash,
+	}
+	if name := r.Header.Get(hmacHdrHash); name != "" {
+		lines = append(lines, name)
+	}
+	canonical := strings.Join(lines, "\n")
 
-	// expected signature (raw bytes)
-	mac := hmac.New(sha256.New, []byte(secret))
-	_, _ = mac.Write([]byte(canonical))
-	expected := mac.Sum(nil)
+	for _, secret := range s.accessSecrets[r.Header.Get(hdrAPIKey)] {
+		// expected signature (raw bytes)
+		mac := hmac.New(newHash, secret)
+		_, _ = mac.Write([]byte(canonical))
+		if hmac.Equal(provided, mac.Sum(nil)) {
+			return secret
+		}
+	}
+	return nil
+}
 
-	provided, err := hex.DecodeString(sigHex)
+// SignResponse signs "TIMESTAMP \n SHA256_HEX(body)" with the secret of the request's api key and the hash
+// the request was signed with, the request must have been verified with the HMAC scheme.
+func (s *HMACAuthenticator) SignResponse(r *http.Request, timestamp string, body []byte) (string, bool) {
+	if !s.Supports(r) {
+		return "", false
+	}
+	provided, err := hex.DecodeString(strings.TrimPrefix(r.Header.Get(hdrAuthz), hmacScheme+" "))
 	if err != nil {
-		return fmt.Errorf("bad signature encoding")
+		return "", false
 	}
-	if !hmac.Equal(provided, expected) {
-		return fmt.Errorf("bad signature")
+	// the verified X-Content-Sha256 stands for the request body, already consumed by the handler
+	secret := s.requestSecret(r, provided, strings.ToLower(r.Header.Get(hmacHdrBodySHA256)))
+	if secret == nil {
+		return "", false
 	}
-
-	return nil
+	newHash, _ := s.requestHash(r) // valid since the request secret was found
+	sum := sha256.Sum256(body)
+	mac := hmac.New(newHash, secret)
+	_, _ = mac.Write([]byte(timestamp + "\n" + hex.EncodeToString(sum[:])))
+	return hex.EncodeToString(mac.Sum(nil)), true
 }
 
 func (s *HMACAuthenticator) WithAuthChi(next http.Handler) http.Handler {
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"crypto/hmac"
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"fs-access-api/internal/adapters/out/security"
 	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"hash"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -79,7 +82,7 @@ var _ = Describe("HMACAuthenticator.Verify", func() {
 	BeforeEach(func() {
 		sec := config.AuthenticatorConfig{
 			WindowSeconds: 300,
-			AccessKeys:    map[string]string{apiKeyID: secretHex},
+			AccessKeys:    map[string]config.AccessKey{apiKeyID: {Secret: secretHex}},
 		}
 		var err error
 		auth, err = security.NewHMACAuthenticator(sec)
@@ -121,6 +124,18 @@ var _ = Describe("HMACAuthenticator.Verify", func() {
 		Expect(err).To(HaveOccurred())
 	})
 
+	It("checks the timestamp window against the injected clock", func() {
+		clock := ports.NewFakeClock(time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC))
+		auth.SetClock(clock)
+		ts := clock.Now().Format(time.RFC3339)
+
+		Expect(auth.Verify(newHmacSignedRequest(http.MethodGet, "http://example.test/api/users", nil, apiKeyID, secretHex, ts))).To(Succeed())
+		clock.Advance(299 * time.Second)
+		Expect(auth.Verify(newHmacSignedRequest(http.MethodGet, "http://example.test/api/users", nil, apiKeyID, secretHex, ts))).To(Succeed())
+		clock.Advance(2 * time.Second)
+		Expect(auth.Verify(newHmacSignedRequest(http.MethodGet, "http://example.test/api/users", nil, apiKeyID, secretHex, ts))).NotTo(Succeed())
+	})
+
 	It("rejects when X-Content-Sha256 doesn't match the actual body", func() {
 		ts := time.Now().UTC().Format(time.RFC3339)
 
@@ -150,6 +165,173 @@ var _ = Describe("HMACAuthenticator.Verify", func() {
 	})
 })
 
+var _ = Describe("HMACAuthenticator with a rotated secret", func() {
+	const (
+		apiKeyID = "test-key"
+		oldHex   = "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+		newHex   = "ffeeddccbbaa99887766554433221100ffeeddccbbaa99887766554433221100"
+		otherHex = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	)
+
+	var auth *security.HMACAuthenticator
+
+	BeforeEach(func() {
+		var err error
+		auth, err = security.NewHMACAuthenticator(config.AuthenticatorConfig{
+			WindowSeconds: 300,
+			AccessKeys:    map[string]config.AccessKey{apiKeyID: {Secrets: []string{oldHex, newHex}}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("verifies requests signed with the old and the new secret during the overlap", func() {
+		ts := time.Now().UTC().Format(time.RFC3339)
+		for _, secret := range []string{oldHex, newHex} {
+			req := newHmacSignedRequest(http.MethodPost, "http://example.test/api/users", []byte(`{}`), apiKeyID, secret, ts)
+			Expect(auth.Verify(req)).To(Succeed())
+		}
+		req := newHmacSignedRequest(http.MethodPost, "http://example.test/api/users", []byte(`{}`), apiKeyID, otherHex, ts)
+		Expect(auth.Verify(req)).To(MatchError("bad signature"))
+	})
+
+	It("signs the response with the secret the request was signed with", func() {
+		ts := time.Now().UTC().Format(time.RFC3339)
+		body := []byte(`{"ok":true}`)
+		for _, secret := range []string{oldHex, newHex} {
+			req := newHmacSignedRequest(http.MethodGet, "http://example.test/api/users", nil, apiKeyID, secret, ts)
+			sig, ok := auth.SignResponse(req, ts, body)
+			Expect(ok).To(BeTrue())
+			m := hmac.New(sha256.New, mustDecodeHex(secret))
+			m.Write([]byte(ts + "\n" + sha256Hex(body)))
+			Expect(sig).To(Equal(hex.EncodeToString(m.Sum(nil))))
+		}
+	})
+})
+
+var _ = Describe("HMACAuthenticator.SignResponse", func() {
+	const (
+		apiKeyID  = "test-key"
+		secretHex = "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+	)
+
+	var auth *security.HMACAuthenticator
+
+	BeforeEach(func() {
+		var err error
+		auth, err = security.NewHMACAuthenticator(config.AuthenticatorConfig{
+			AccessKeys: map[string]config.AccessKey{apiKeyID: {Secret: secretHex}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("signs TIMESTAMP \\n SHA256_HEX(body) with the request's key", func() {
+		ts := time.Now().UTC().Format(time.RFC3339)
+		req := newHmacSignedRequest(http.MethodGet, "http://example.test/api/users", nil, apiKeyID, secretHex, ts)
+		body := []byte(`[{"username":"alice"}]`)
+
+		sig, ok := auth.SignResponse(req, "2026-01-02T03:04:05Z", body)
+		Expect(ok).To(BeTrue())
+		m := hmac.New(sha256.New, mustDecodeHex(secretHex))
+		m.Write([]byte("2026-01-02T03:04:05Z\n" + sha256Hex(body)))
+		Expect(sig).To(Equal(hex.EncodeToString(m.Sum(nil))))
+
+		tampered, _ := auth.SignResponse(req, "2026-01-02T03:04:05Z", []byte(`[]`))
+		Expect(tampered).NotTo(Equal(sig))
+	})
+
+	It("does not sign requests of other schemes or unknown keys", func() {
+		bearer, _ := http.NewRequest(http.MethodGet, "http://example.test/api/users", nil)
+		bearer.Header.Set("X-Api-Key", apiKeyID)
+		bearer.Header.Set("Authorization", "Bearer "+secretHex)
+		_, ok := auth.SignResponse(bearer, "2026-01-02T03:04:05Z", nil)
+		Expect(ok).To(BeFalse())
+
+		ts := time.Now().UTC().Format(time.RFC3339)
+		unknown := newHmacSignedRequest(http.MethodGet, "http://example.test/api/users", nil, "other-key", secretHex, ts)
+		_, ok = auth.SignResponse(unknown, ts, nil)
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("HMACAuthenticator hash selection", func() {
+	const (
+		apiKeyID  = "test-key"
+		secretHex = "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+	)
+
+	newAuth := func(hmacHash string, allowed ...string) *security.HMACAuthenticator {
+		auth, err := security.NewHMACAuthenticator(config.AuthenticatorConfig{
+			WindowSeconds:     300,
+			AccessKeys:        map[string]config.AccessKey{apiKeyID: {Secret: secretHex}},
+			HmacHash:          hmacHash,
+			HmacAllowedHashes: allowed,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return auth
+	}
+	// signed signs the request with newHash over the canonical lines, X-Hmac-Hash is sent and signed when named
+	signed := func(newHash func() hash.Hash, named string) *http.Request {
+		ts := time.Now().UTC().Format(time.RFC3339)
+		req := newHmacSignedRequest(http.MethodGet, "http://example.test/api/users", nil, apiKeyID, secretHex, ts)
+		msg := "GET\n/api/users\n" + ts + "\n" + sha256Hex(nil)
+		if named != "" {
+			req.Header.Set("X-Hmac-Hash", named)
+			msg += "\n" + named
+		}
+		m := hmac.New(newHash, mustDecodeHex(secretHex))
+		m.Write([]byte(msg))
+		req.Header.Set("Authorization", "HMAC "+hex.EncodeToString(m.Sum(nil)))
+		return req
+	}
+
+	It("verifies with the configured hash", func() {
+		Expect(newAuth("").Verify(signed(sha256.New, ""))).To(Succeed())
+		Expect(newAuth(config.HmacHashSHA256).Verify(signed(sha512.New, ""))).To(MatchError("bad signature"))
+
+		sha512Auth := newAuth(config.HmacHashSHA512)
+		Expect(sha512Auth.Verify(signed(sha512.New, ""))).To(Succeed())
+		Expect(sha512Auth.Verify(signed(sha256.New, ""))).To(MatchError("bad signature"))
+	})
+
+	It("verifies with the hash named by X-Hmac-Hash, which is signed too", func() {
+		auth := newAuth(config.HmacHashSHA256, config.HmacHashSHA512)
+		Expect(auth.Verify(signed(sha512.New, "sha512"))).To(Succeed())
+		Expect(auth.Verify(signed(sha256.New, "sha256"))).To(Succeed())
+
+		swapped := signed(sha512.New, "sha512")
+		swapped.Header.Set("X-Hmac-Hash", "sha256")
+		Expect(auth.Verify(swapped)).To(MatchError("bad signature"))
+
+		Expect(auth.Verify(signed(sha256.New, "md5"))).To(MatchError(ContainSubstring("unsupported X-Hmac-Hash")))
+	})
+
+	It("refuses an X-Hmac-Hash other than hmac_hash unless hmac_allowed_hashes lists it", func() {
+		sha512Auth := newAuth(config.HmacHashSHA512)
+		Expect(sha512Auth.Verify(signed(sha512.New, "sha512"))).To(Succeed())
+		Expect(sha512Auth.Verify(signed(sha256.New, "sha256"))).To(MatchError(`X-Hmac-Hash "sha256" not allowed`))
+
+		Expect(newAuth(config.HmacHashSHA512, config.HmacHashSHA256).Verify(signed(sha256.New, "sha256"))).To(Succeed())
+	})
+
+	It("signs the response with the hash of the request", func() {
+		auth := newAuth(config.HmacHashSHA256, config.HmacHashSHA512)
+		body := []byte(`{"ok":true}`)
+		sig, ok := auth.SignResponse(signed(sha512.New, "sha512"), "2026-01-02T03:04:05Z", body)
+		Expect(ok).To(BeTrue())
+		m := hmac.New(sha512.New, mustDecodeHex(secretHex))
+		m.Write([]byte("2026-01-02T03:04:05Z\n" + sha256Hex(body)))
+		Expect(sig).To(Equal(hex.EncodeToString(m.Sum(nil))))
+	})
+
+	It("refuses an unknown configured hash", func() {
+		_, err := security.NewHMACAuthenticator(config.AuthenticatorConfig{
+			AccessKeys: map[string]config.AccessKey{apiKeyID: {Secret: secretHex}},
+			HmacHash:   "md5",
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
 var _ = Describe("HMACAuthenticator.WithAuthChi middleware", func() {
 	const (
 		apiKeyID  = "test-key"
@@ -162,7 +344,7 @@ var _ = Describe("HMACAuthenticator.WithAuthChi middleware", func() {
 	BeforeEach(func() {
 		sec := config.AuthenticatorConfig{
 			WindowSeconds: 300,
-			AccessKeys:    map[string]string{apiKeyID: secretHex},
+			AccessKeys:    map[string]config.AccessKey{apiKeyID: {Secret: secretHex}},
 		}
 		var err error
 		auth, err = security.NewHMACAuthenticator(sec)
@@ -24,7 +24,7 @@ var _ = Describe("MultiAuthenticator.Verify", func() {
 		sec := config.AuthenticatorConfig{
 			EnabledAuthenticators: []string{"bearer", "hmac"},
 			WindowSeconds:         300,
-			AccessKeys:            map[string]string{apiKeyID: secretHex},
+			AccessKeys:            map[string]config.AccessKey{apiKeyID: {Secret: secretHex}},
 		}
 		var err error
 		auth, err = security.NewMultiAuthenticator(sec)
@@ -63,7 +63,7 @@ var _ = Describe("BearerAuthenticator.WithAuthChi middleware", func() {
 		sec := config.AuthenticatorConfig{
 			EnabledAuthenticators: []string{"bearer", "hmac"},
 			WindowSeconds:         300,
-			AccessKeys:            map[string]string{apiKeyID: secretHex},
+			AccessKeys:            map[string]config.AccessKey{apiKeyID: {Secret: secretHex}},
 		}
 		var err error
 		auth, err = security.NewMultiAuthenticator(sec)
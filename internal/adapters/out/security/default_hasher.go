@@ -1,6 +1,7 @@
 package security
 
 import (
+	"cmp"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha1"
@@ -30,6 +31,10 @@ type DefaultHasher struct {
 	defaultCrypter crypt.Crypter
 	defaultRounds  int
 	defaultSaltLen int
+	minRounds      int
+	argon2         argon2Params
+	legacy         []ports.LegacyVerifier
+	disabled       map[ports.HashAlgo]bool
 }
 
 // Enforce compile-time conformance to the interface
@@ -55,12 +60,61 @@ func NewDefaultHasherFromConfig(cfg config.HasherConfig) (*DefaultHasher, error)
 	if err != nil {
 		return nil, err
 	}
+	minRounds := max(cfg.MinRounds, minHardRounds)
+	if minRounds > maxHardRounds {
+		return nil, fmt.Errorf("min rounds %d exceeds the hard limit %d", minRounds, maxHardRounds)
+	}
+	if usesRounds(alg) && cfg.DefaultRounds < minRounds {
+		return nil, fmt.Errorf("default rounds %d are below the configured minimum %d", cfg.DefaultRounds, minRounds)
+	}
 
-	algId, crypter, err := resolveCrypter(alg)
-	if err != nil {
+	// checked before the conversions, which would wrap out of range values into valid ones
+	if cfg.Argon2Memory < 0 || cfg.Argon2Memory > maxArgon2Memory || cfg.Argon2Time < 0 || cfg.Argon2Time > maxArgon2Time ||
+		cfg.Argon2Parallelism < 0 || cfg.Argon2Parallelism > 255 {
+		return nil, fmt.Errorf("argon2id parameters must be within memory 0..%d KiB, time 0..%d, parallelism 0..255 (0 for the default)",
+			maxArgon2Memory, maxArgon2Time)
+	}
+	argon2 := argon2Params{
+		memory:      uint32(cmp.Or(cfg.Argon2Memory, defaultArgon2Memory)),
+		time:        uint32(cmp.Or(cfg.Argon2Time, defaultArgon2Time)),
+		parallelism: uint8(cmp.Or(cfg.Argon2Parallelism, defaultArgon2Parallelism)),
+	}
+	if err := argon2.validate(); err != nil {
 		return nil, err
 	}
 
+	// DefaultHash salts its hashes, so only the crypt(3) algorithms and argon2id can be the default
+	var algId int
+	var crypter crypt.Crypter
+	if alg == ports.AlgoArgon2id {
+		if cfg.DefaultSaltLen < minArgon2SaltLen {
+			return nil, fmt.Errorf("default salt length %d is below the argon2id minimum %d", cfg.DefaultSaltLen, minArgon2SaltLen)
+		}
+	} else if algId, crypter, err = resolveCrypter(alg); err != nil {
+		return nil, fmt.Errorf("default algorithm %s is not a crypt algorithm: %w", alg, err)
+	}
+
+	disabled := make(map[ports.HashAlgo]bool, len(cfg.DisabledAlgorithms))
+	for _, name := range cfg.DisabledAlgorithms {
+		d, err := ports.ParseHashAlgo(name)
+		if err != nil {
+			return nil, fmt.Errorf("disabled algorithm %q: %w", name, err)
+		}
+		disabled[d] = true
+	}
+	if disabled[alg] {
+		return nil, fmt.Errorf("default algorithm %s is disabled", alg)
+	}
+
+	legacy := make([]ports.LegacyVerifier, 0, len(cfg.LegacyVerifiers))
+	for _, name := range cfg.LegacyVerifiers {
+		verifier, err := NewLegacyVerifier(name)
+		if err != nil {
+			return nil, err
+		}
+		legacy = append(legacy, verifier)
+	}
+
 	return &DefaultHasher{
 		rr:             rr,
 		defaultAlg:     alg,
@@ -68,11 +122,26 @@ func NewDefaultHasherFromConfig(cfg config.HasherConfig) (*DefaultHasher, error)
 		defaultCrypter: crypter,
 		defaultRounds:  cfg.DefaultRounds,
 		defaultSaltLen: cfg.DefaultSaltLen,
+		minRounds:      minRounds,
+		argon2:         argon2,
+		legacy:         legacy,
+		disabled:       disabled,
 	}, nil
 }
 
+// Hard rounds limits, a safety net below any configured floor.
+const (
+	minHardRounds = 1000
+	maxHardRounds = 1000000
+)
+
+// usesRounds tells the algorithms honoring rounds, the floor applies only to them.
+func usesRounds(alg ports.HashAlgo) bool {
+	return alg == ports.AlgoCryptSHA256 || alg == ports.AlgoCryptSHA512
+}
+
 func validateParams(rounds int, saltLen int) error {
-	if rounds < 1000 || rounds > 1000000 { //999999999 {
+	if rounds < minHardRounds || rounds > maxHardRounds { //999999999 {
 		return fmt.Errorf("rounds must be positive between 1000 and 999999999")
 	}
 	if saltLen <= 0 || saltLen > 16 {
@@ -94,14 +163,32 @@ func prepareSaltSpec(rr io.Reader, algId int, rounds int, saltLen int) (saltSpec
 	return fmt.Sprintf("$%d$rounds=%d$%s", algId, rounds, salt), nil
 }
 
+// SupportedAlgorithms lists the algorithms Hash computes, the disabled ones left out.
 func (c *DefaultHasher) SupportedAlgorithms() []ports.HashAlgo {
-	return []ports.HashAlgo{
-		ports.AlgoCryptMD5, ports.AlgoCryptSHA256, ports.AlgoCryptSHA512,
+	all := []ports.HashAlgo{
+		ports.AlgoCryptMD5, ports.AlgoCryptSHA256, ports.AlgoCryptSHA512, ports.AlgoArgon2id,
 		ports.AlgoRawMD5, ports.AlgoRawSHA1, ports.AlgoRawSHA256, ports.AlgoRawSHA512}
+	supported := make([]ports.HashAlgo, 0, len(all))
+	for _, alg := range all {
+		if !c.disabled[alg] {
+			supported = append(supported, alg)
+		}
+	}
+	return supported
 }
 
-// Hash returns a crypt string like `$5|6$rounds=5000$<salt>$<hash>`
+// Hash returns a crypt string like `$5|6$rounds=5000$<salt>$<hash>`, argon2id ignores rounds
+// (its cost is configured) and hashes to a PHC string like `$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>`
 func (c *DefaultHasher) Hash(plain string, alg ports.HashAlgo, rounds *int, saltLen *int) (hash string, err error) {
+	if c.disabled[alg] {
+		return "", fmt.Errorf("%w: %s is disabled", ports.ErrUnsupportedAlgorithm, alg)
+	}
+	if alg == ports.AlgoArgon2id {
+		if saltLen == nil {
+			saltLen = &c.defaultSaltLen
+		}
+		return hashArgon2id(plain, c.argon2, *saltLen, c.rr)
+	}
 	if alg.IsCrypt() {
 		algId, crypter, err := resolveCrypter(alg)
 		if err != nil {
@@ -110,6 +197,9 @@ func (c *DefaultHasher) Hash(plain string, alg ports.HashAlgo, rounds *int, salt
 		if rounds == nil {
 			rounds = &c.defaultRounds
 		}
+		if usesRounds(alg) && *rounds < c.minRounds {
+			return "", fmt.Errorf("rounds %d are below the configured minimum %d", *rounds, c.minRounds)
+		}
 		if saltLen == nil {
 			saltLen = &c.defaultSaltLen
 		}
@@ -136,8 +226,11 @@ func (c *DefaultHasher) Hash(plain string, alg ports.HashAlgo, rounds *int, salt
 	}
 }
 
-// DefaultHash returns a crypt string like `$5|6$rounds=5000$<salt>$<hash>`
+// DefaultHash returns a crypt string like `$5|6$rounds=5000$<salt>$<hash>` or an argon2id PHC string
 func (c *DefaultHasher) DefaultHash(plain string) (hash string, err error) {
+	if c.defaultAlg == ports.AlgoArgon2id {
+		return hashArgon2id(plain, c.argon2, c.defaultSaltLen, c.rr)
+	}
 	saltSpec, err := prepareSaltSpec(c.rr, c.defaultAlgId, c.defaultRounds, c.defaultSaltLen)
 	if err != nil {
 		return "", err
@@ -146,8 +239,26 @@ func (c *DefaultHasher) DefaultHash(plain string) (hash string, err error) {
 }
 
 // Verify compares a stored hash against the provided plaintext (or special cases).
-// Supports crypt(3) ($1$/$apr1$/$5$/$6$) and raw hex MD5/SHA1/SHA256/SHA512.
+// Supports crypt(3) ($1$/$apr1$/$5$/$6$), argon2id PHC strings and raw hex MD5/SHA1/SHA256/SHA512.
+// When that fails, the configured legacy verifiers matching the hash get a chance,
+// a success is reported with ports.LegacyHashAlgo(name).
 func (c *DefaultHasher) Verify(hashed, plain string) (verified bool, alg ports.HashAlgo, err error) {
+	verified, alg, err = c.verifyKnown(hashed, plain)
+	if verified {
+		return verified, alg, nil
+	}
+	for _, lv := range c.legacy {
+		if !lv.Matches(hashed) {
+			continue
+		}
+		// the hash is in this legacy format, its verdict is final
+		ok, lerr := lv.Verify(hashed, plain)
+		return ok, ports.LegacyHashAlgo(lv.Name()), lerr
+	}
+	return verified, alg, err
+}
+
+func (c *DefaultHasher) verifyKnown(hashed, plain string) (verified bool, alg ports.HashAlgo, err error) {
 	alg, err = ports.DetectHashAlgo(hashed)
 	if err != nil {
 		return false, alg, err
@@ -160,6 +271,9 @@ func (c *DefaultHasher) Verify(hashed, plain string) (verified bool, alg ports.H
 		return sha256_crypt.New().Verify(hashed, []byte(plain)) == nil, alg, nil
 	case ports.AlgoCryptMD5:
 		return md5_crypt.New().Verify(hashed, []byte(plain)) == nil, alg, nil
+	case ports.AlgoArgon2id:
+		verified, err = verifyArgon2id(hashed, plain, c.argon2)
+		return verified, alg, err
 
 	// raw hex digests
 	case ports.AlgoRawMD5:
@@ -39,7 +39,7 @@ func testHashAlg(hasher ports.Hasher, alg ports.HashAlgo, plain string) {
 
 	verifyHashAlg(hasher, alg, hash2, plain)
 
-	if alg.IsCrypt() {
+	if alg.IsSalted() {
 		Expect(hash1).ToNot(Equal(hash2), "Hashing should be salted and produce different values, alg: "+string(alg))
 	} else {
 		Expect(hash1).To(Equal(hash2), "Hashing should produce same values, alg: "+string(alg))
@@ -109,3 +109,150 @@ var _ = Describe("Hasher", func() {
 	})
 
 })
+
+var _ = Describe("Hasher min_rounds floor", func() {
+	var hasher ports.Hasher
+
+	BeforeEach(func() {
+		var err error
+		hasher, err = security.NewDefaultHasherFromConfig(config.HasherConfig{
+			DefaultAlgorithm: "crypt-sha512",
+			DefaultRounds:    100000,
+			DefaultSaltLen:   16,
+			MinRounds:        100000,
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("rejects a below-floor request", func() {
+		_, err := hasher.Hash(password, ports.AlgoCryptSHA256, ptr(99999), nil)
+		Expect(err).To(MatchError(ContainSubstring("rounds 99999 are below the configured minimum 100000")))
+	})
+
+	It("accepts an at-floor request", func() {
+		hash, err := hasher.Hash(password, ports.AlgoCryptSHA512, ptr(100000), nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hash).To(HavePrefix("$6$rounds=100000$"))
+	})
+
+	It("doesn't apply to algorithms without rounds", func() {
+		_, err := hasher.Hash(password, ports.AlgoCryptMD5, ptr(5000), nil)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("refuses default rounds below the floor", func() {
+		_, err := security.NewDefaultHasherFromConfig(config.HasherConfig{
+			DefaultAlgorithm: "crypt-sha256", DefaultRounds: 5000, DefaultSaltLen: 16, MinRounds: 10000,
+		})
+		Expect(err).To(MatchError(ContainSubstring("default rounds 5000 are below the configured minimum 10000")))
+	})
+
+	It("refuses a raw default algorithm instead of failing at the first hash", func() {
+		_, err := security.NewDefaultHasherFromConfig(config.HasherConfig{
+			DefaultAlgorithm: "raw-sha256", DefaultRounds: 5000, DefaultSaltLen: 16,
+		})
+		Expect(err).To(MatchError(ports.ErrUnsupportedAlgorithm))
+		Expect(err.Error()).To(ContainSubstring("default algorithm raw-sha256 is not a crypt algorithm"))
+	})
+})
+
+var _ = Describe("Hasher disabled_algorithms", func() {
+	var hasher ports.Hasher
+
+	BeforeEach(func() {
+		var err error
+		hasher, err = security.NewDefaultHasherFromConfig(config.HasherConfig{
+			DefaultAlgorithm: "crypt-sha256", DefaultRounds: 5000, DefaultSaltLen: 16,
+			DisabledAlgorithms: []string{"raw-md5", "crypt-md5"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("leaves disabled algorithms out of the supported ones", func() {
+		Expect(hasher.SupportedAlgorithms()).NotTo(ContainElements(ports.AlgoRawMD5, ports.AlgoCryptMD5))
+		Expect(hasher.SupportedAlgorithms()).To(ContainElements(ports.AlgoCryptSHA256, ports.AlgoRawSHA256))
+	})
+
+	It("refuses to hash with a disabled algorithm", func() {
+		_, err := hasher.Hash(password, ports.AlgoRawMD5, nil, nil)
+		Expect(err).To(MatchError(ports.ErrUnsupportedAlgorithm))
+		_, err = hasher.Hash(password, ports.AlgoCryptMD5, nil, nil)
+		Expect(err).To(MatchError(ports.ErrUnsupportedAlgorithm))
+	})
+
+	It("still verifies stored hashes of a disabled algorithm", func() {
+		ok, alg, err := hasher.Verify(md5Sum, password)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(alg).To(Equal(ports.AlgoRawMD5))
+	})
+
+	It("refuses to disable the default algorithm or an unknown one", func() {
+		_, err := security.NewDefaultHasherFromConfig(config.HasherConfig{
+			DefaultAlgorithm: "crypt-sha256", DefaultRounds: 5000, DefaultSaltLen: 16,
+			DisabledAlgorithms: []string{"crypt-sha256"},
+		})
+		Expect(err).To(MatchError(ContainSubstring("default algorithm crypt-sha256 is disabled")))
+
+		_, err = security.NewDefaultHasherFromConfig(config.HasherConfig{
+			DefaultAlgorithm: "crypt-sha256", DefaultRounds: 5000, DefaultSaltLen: 16,
+			DisabledAlgorithms: []string{"rot13"},
+		})
+		Expect(err).To(MatchError(ContainSubstring(`disabled algorithm "rot13"`)))
+	})
+})
+
+var _ = Describe("Hasher argon2id", func() {
+	newHasher := func(cfg config.HasherConfig) (ports.Hasher, error) {
+		cfg.DefaultAlgorithm = "argon2id"
+		cfg.DefaultRounds = 5000
+		cfg.DefaultSaltLen = 16
+		return security.NewDefaultHasherFromConfig(cfg)
+	}
+
+	It("hashes to a PHC string with the configured cost and verifies it", func() {
+		hasher, err := newHasher(config.HasherConfig{Argon2Memory: 1024, Argon2Time: 2, Argon2Parallelism: 1})
+		Expect(err).ToNot(HaveOccurred())
+		hash, err := hasher.DefaultHash(password)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hash).To(MatchRegexp(`^\$argon2id\$v=19\$m=1024,t=2,p=1\$[A-Za-z0-9+/]{22}\$[A-Za-z0-9+/]{43}$`))
+		verifyHashAlg(hasher, ports.AlgoArgon2id, hash, password)
+
+		ok, _, err := hasher.Verify(hash, "wrong")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("verifies a reference hash", func() {
+		hasher, err := security.NewDefaultHasher()
+		Expect(err).ToNot(HaveOccurred())
+		// argon2id, password "password", salt "somesalt", m=65536 t=2 p=1 (reference test vector)
+		verifyHashAlg(hasher, ports.AlgoArgon2id,
+			"$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", "password")
+	})
+
+	It("refuses stored hashes and configs past the memory limit", func() {
+		hasher, err := security.NewDefaultHasher()
+		Expect(err).ToNot(HaveOccurred())
+		_, _, err = hasher.Verify("$argon2id$v=19$m=4194304,t=1,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", "password")
+		Expect(err).To(MatchError(ContainSubstring("argon2id memory must be between")))
+
+		// within the hard limits, above the configured cost
+		small, err := newHasher(config.HasherConfig{Argon2Memory: 1024, Argon2Time: 2, Argon2Parallelism: 1})
+		Expect(err).ToNot(HaveOccurred())
+		_, _, err = small.Verify("$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", "password")
+		Expect(err).To(MatchError(ports.ErrInvalidInput))
+		Expect(err).To(MatchError(ContainSubstring("exceeds the configured m=1024,t=2,p=1")))
+
+		_, err = newHasher(config.HasherConfig{Argon2Memory: 4 * 1024 * 1024})
+		Expect(err).To(MatchError(ContainSubstring("argon2id parameters must be within")))
+		_, err = newHasher(config.HasherConfig{Argon2Parallelism: -1})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("echoes the salt length of the hash in bytes", func() {
+		rounds, saltLen := ports.CryptHashParams("$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc")
+		Expect(rounds).To(BeNil())
+		Expect(saltLen).To(Equal(ptr(8)))
+	})
+})
@@ -0,0 +1,47 @@
+package security
+
+import (
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"fs-access-api/internal/app/ports"
+	"strings"
+)
+
+// legacyVerifiers registers the available legacy formats by their config name.
+var legacyVerifiers = map[string]func() ports.LegacyVerifier{
+	"ldap-ssha": func() ports.LegacyVerifier { return ldapSSHAVerifier{} },
+}
+
+func NewLegacyVerifier(name string) (ports.LegacyVerifier, error) {
+	factory, ok := legacyVerifiers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown legacy verifier %q", name)
+	}
+	return factory(), nil
+}
+
+// ldapSSHAVerifier handles the OpenLDAP `{SSHA}base64(sha1(password+salt)+salt)` format.
+type ldapSSHAVerifier struct{}
+
+const ldapSSHAPrefix = "{SSHA}"
+
+func (ldapSSHAVerifier) Name() string { return "ldap-ssha" }
+
+func (ldapSSHAVerifier) Matches(hashed string) bool {
+	return strings.HasPrefix(hashed, ldapSSHAPrefix)
+}
+
+func (ldapSSHAVerifier) Verify(hashed, plain string) (bool, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(hashed, ldapSSHAPrefix))
+	if err != nil {
+		return false, fmt.Errorf("invalid {SSHA} encoding: %w", err)
+	}
+	if len(raw) <= sha1.Size {
+		return false, fmt.Errorf("invalid {SSHA} hash: missing salt")
+	}
+	digest, salt := raw[:sha1.Size], raw[sha1.Size:]
+	sum := sha1.Sum(append([]byte(plain), salt...))
+	return subtle.ConstantTimeCompare(sum[:], digest) == 1, nil
+}
@@ -0,0 +1,54 @@
+package security_test
+
+import (
+	"fs-access-api/internal/adapters/out/security"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Legacy verifiers", func() {
+	// {SSHA} of "legacy-pass" with the salt "NaCl1234"
+	const sshaHash = "{SSHA}gXR1YDyOMzFo9neZ7p1tG6kCz4BOYUNsMTIzNA=="
+
+	newHasher := func(legacy ...string) *security.DefaultHasher {
+		h, err := security.NewDefaultHasherFromConfig(config.HasherConfig{
+			DefaultAlgorithm: "crypt-sha256",
+			DefaultRounds:    5000,
+			DefaultSaltLen:   16,
+			LegacyVerifiers:  legacy,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return h
+	}
+
+	It("verifies a configured legacy format and reports it as legacy", func() {
+		ok, alg, err := newHasher("ldap-ssha").Verify(sshaHash, "legacy-pass")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(alg).To(Equal(ports.LegacyHashAlgo("ldap-ssha")))
+		Expect(alg.IsLegacy()).To(BeTrue())
+	})
+
+	It("rejects a wrong password in a legacy format", func() {
+		ok, _, err := newHasher("ldap-ssha").Verify(sshaHash, "nope")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("doesn't know the legacy format unless configured", func() {
+		ok, _, err := newHasher().Verify(sshaHash, "legacy-pass")
+		Expect(err).To(MatchError(ports.ErrUnsupportedAlgorithm))
+		Expect(ok).To(BeFalse())
+	})
+
+	It("fails on unknown verifier names", func() {
+		_, err := security.NewDefaultHasherFromConfig(config.HasherConfig{
+			DefaultAlgorithm: "crypt-sha256", DefaultRounds: 5000, DefaultSaltLen: 16,
+			LegacyVerifiers: []string{"nope"},
+		})
+		Expect(err).To(MatchError(ContainSubstring(`unknown legacy verifier "nope"`)))
+	})
+})
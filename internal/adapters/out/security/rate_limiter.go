@@ -0,0 +1,52 @@
+package security
+
+import (
+	"fs-access-api/internal/app/config"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter keeps one token bucket per key (e.g. api key id).
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{buckets: map[string]*tokenBucket{}, now: time.Now}
+}
+
+// Allow takes a token from the key's bucket; when it's empty it reports how long until the next token.
+func (l *RateLimiter) Allow(key string, limit config.RateLimitConfig) (bool, time.Duration) {
+	if limit.RequestsPerSecond <= 0 {
+		return true, 0
+	}
+	burst := float64(limit.Burst)
+	if burst <= 0 {
+		burst = math.Max(1, math.Ceil(limit.RequestsPerSecond))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*limit.RequestsPerSecond)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := (1 - b.tokens) / limit.RequestsPerSecond
+	return false, time.Duration(math.Ceil(wait * float64(time.Second)))
+}
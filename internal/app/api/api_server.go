@@ -1,36 +1,160 @@
 package api
 
 import (
+	"crypto/rand"
 	"errors"
+	"fmt"
 	"fs-access-api/internal/app/config"
 	"fs-access-api/internal/app/ports"
+	"io"
+	"log"
+	"math"
+	"regexp"
+	"sync/atomic"
+	"unicode/utf8"
 )
 
 // Enforce compile-time conformance to a generated interface
 var _ ports.ApiServer = (*DefaultApiServer)(nil)
 
 type DefaultApiServer struct {
-	storageCfg  config.StorageConfig
-	hasher      ports.Hasher
-	accountRepo ports.AccountRepository
-	fs          ports.FsStorageService
+	storageCfg    config.StorageConfig
+	securityCfg   config.SecurityConfig
+	commonCfg     config.AccountRepositoryCommonConfig
+	hasher        ports.Hasher
+	accountRepo   ports.AccountRepository
+	fs            ports.FsStorageService
+	loginFailures *loginFailures
+	homeDrift     atomic.Pointer[ports.HomeDriftReport]
+	// dummyHash is verified for unknown users under security.constant_time_auth, empty otherwise
+	dummyHash string
+	clock     ports.Clock
+	// paths resolves the homes and top dirs with the storage policies
+	paths ports.HomePaths
+	// homes indexes the absolute user homes under storage.enforce_globally_unique_home
+	homes homeIndex
 }
 
-func NewDefaultApiServer(cfg config.StorageConfig, hasher ports.Hasher, accountRepo ports.AccountRepository, fs ports.FsStorageService) (*DefaultApiServer, error) {
+func NewDefaultApiServer(cfg config.StorageConfig, securityCfg config.SecurityConfig, commonCfg config.AccountRepositoryCommonConfig, hasher ports.Hasher, accountRepo ports.AccountRepository, fs ports.FsStorageService) (*DefaultApiServer, error) {
 	if accountRepo == nil {
 		return nil, errors.New("accountRepo is nil")
 	}
 	if fs == nil {
 		return nil, errors.New("file system service is nil")
 	}
+	if securityCfg.ReturnHashInLookup {
+		log.Printf("WARNING: security.return_hash_in_lookup is enabled, the authz lookup discloses the stored password hashes")
+	}
+	dummyHash := ""
+	if securityCfg.ConstantTimeAuth {
+		var err error
+		if dummyHash, err = hasher.DefaultHash(rand.Text()); err != nil {
+			return nil, fmt.Errorf("cannot compute the constant time auth dummy hash: %w", err)
+		}
+	}
+	paths, err := cfg.HomePaths()
+	if err != nil {
+		return nil, err
+	}
 	return &DefaultApiServer{
-		storageCfg:  cfg,
-		hasher:      hasher,
-		accountRepo: accountRepo,
-		fs:          fs,
+		storageCfg:    cfg,
+		securityCfg:   securityCfg,
+		commonCfg:     commonCfg,
+		hasher:        hasher,
+		accountRepo:   accountRepo,
+		fs:            fs,
+		loginFailures: newLoginFailures(securityCfg.MaxFailedLogins, securityCfg.LockoutDuration),
+		dummyHash:     dummyHash,
+		clock:         ports.SystemClock,
+		paths:         paths,
 	}, nil
 }
 
+// SetClock replaces the system clock, e.g. with a ports.FakeClock in tests.
+func (s *DefaultApiServer) SetClock(clock ports.Clock) {
+	s.clock = clock
+}
+
+// Close closes the account repository when it holds resources (the WAL, the database handle).
+func (s *DefaultApiServer) Close() error {
+	if closer, ok := s.accountRepo.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 func (s *DefaultApiServer) HealthCheck() error {
 	return s.accountRepo.HealthCheck()
 }
+
+func (s *DefaultApiServer) RepositoryInfo() (string, ports.RepoCapabilities, error) {
+	info, err := s.accountRepo.GetInfo()
+	if err != nil {
+		return "", ports.RepoCapabilities{}, err
+	}
+	return info, s.accountRepo.Capabilities(), nil
+}
+
+// Capabilities reports what the account repository supports without querying it.
+func (s *DefaultApiServer) Capabilities() ports.RepoCapabilities {
+	return s.accountRepo.Capabilities()
+}
+
+// requireWritable fails with ErrUnsupportedAction when the account repository cannot store changes.
+func (s *DefaultApiServer) requireWritable() error {
+	if s.accountRepo.Capabilities().ReadOnly {
+		return fmt.Errorf("%w: the account repository is read-only", ports.ErrUnsupportedAction)
+	}
+	return nil
+}
+
+// maxNameLength is the size of the username and groupname columns.
+const maxNameLength = 128
+
+// validateName rejects user and group names the account repository columns can't hold.
+func validateName(kind, name string) error {
+	if n := utf8.RuneCountInString(name); n > maxNameLength {
+		return ports.InvalidField(kind, "%s has %d characters, the limit is %d", kind, n, maxNameLength)
+	}
+	return nil
+}
+
+// maxEmailLength is the size of the email column (RFC 5321 path limit).
+const maxEmailLength = 254
+
+// emailPattern is a basic local@domain.tld check, deliverability is the mail server's business.
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s.]+$`)
+
+// validateEmail rejects malformed or over-long emails, nil clears the email and is always valid.
+func validateEmail(email *string) error {
+	if email == nil {
+		return nil
+	}
+	if len(*email) > maxEmailLength {
+		return ports.InvalidField("email", "email has %d bytes, the limit is %d", len(*email), maxEmailLength)
+	}
+	if !emailPattern.MatchString(*email) {
+		return ports.InvalidField("email", "email %q is not a valid address", *email)
+	}
+	return nil
+}
+
+// validateQuotaBytes rejects a group quota the SQL repositories can't store, they keep it in a signed BIGINT.
+func validateQuotaBytes(quota *uint64) error {
+	if quota != nil && *quota > math.MaxInt64 {
+		return ports.InvalidField("quota_bytes", "quota_bytes %d exceeds the limit %d", *quota, int64(math.MaxInt64))
+	}
+	return nil
+}
+
+// validateDescription rejects descriptions over the configured limit, instead of relying on the DB to truncate them.
+func (s *DefaultApiServer) validateDescription(description *string) error {
+	limit := s.commonCfg.MaxDescriptionLength
+	if description == nil || limit <= 0 {
+		return nil
+	}
+	if n := utf8.RuneCountInString(*description); n > limit {
+		return ports.InvalidField("description", "description has %d characters, the limit is %d", n, limit)
+	}
+	return nil
+}
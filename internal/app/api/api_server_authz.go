@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"fs-access-api/internal/app/ports"
+	"log"
 )
 
 type AuthzLookupResult struct {
@@ -23,10 +24,13 @@ func (s *DefaultApiServer) AuthzLookupUser(username string) (uai *ports.UserAuth
 		return nil, "", fmt.Errorf("cannot read user: %w", err)
 	}
 
-	if uhi.Locked {
+	if uhi.IsLocked(s.commonCfg.ExpirationGracePeriod, s.clock.Now()) {
 		return nil, "", ports.ErrLockedUser
 	}
 
+	if !s.securityCfg.ReturnHashInLookup {
+		uhi.Password = "" // the hash leaves the server only when explicitly enabled
+	}
 	return &uhi, s.storageCfg.HomesBaseDir, nil
 }
 
@@ -38,22 +42,56 @@ func (s *DefaultApiServer) AuthzAuthUser(username, password string) error {
 	ua, err := s.accountRepo.GetUserAuthzInfo(username)
 	if err != nil {
 		if errors.Is(err, ports.ErrNotFound) {
+			s.verifyDummyHash(password)
 			return ports.ErrInvalidCredentials
 		}
 		return fmt.Errorf("cannot read user: %w", err)
 	}
 
-	if ua.Locked {
+	now := s.clock.Now()
+	if ua.IsLocked(s.commonCfg.ExpirationGracePeriod, now) || s.loginFailures.isLocked(username, now) {
 		return ports.ErrLockedUser
 	}
 
-	ok, _, err := s.hasher.Verify(ua.Password, password)
+	ok, alg, err := s.hasher.Verify(ua.Password, password)
 	if err != nil {
 		return fmt.Errorf("password verifier error: %w", err)
 	}
 	if !ok {
+		s.loginFailures.registerFailure(username, s.clock.Now())
 		return ports.ErrInvalidCredentials
 	}
 
+	s.loginFailures.reset(username)
+	if alg.IsLegacy() && !s.accountRepo.Capabilities().ReadOnly {
+		// migration path: the login already succeeded, a failed rehash is retried on the next one
+		if err = s.rehashPassword(username, password); err != nil {
+			log.Printf("cannot rehash %s password of user '%s': %v", alg, username, err)
+		}
+	}
 	return nil
 }
+
+// verifyDummyHash spends on an unknown user the time verifying a known one takes,
+// when security.constant_time_auth is enabled.
+func (s *DefaultApiServer) verifyDummyHash(password string) {
+	if s.dummyHash != "" {
+		_, _, _ = s.hasher.Verify(s.dummyHash, password)
+	}
+}
+
+// rehashPassword replaces the stored hash with one of the default algorithm.
+func (s *DefaultApiServer) rehashPassword(username, password string) error {
+	user, err := s.accountRepo.GetUser(username)
+	if err != nil {
+		return err
+	}
+	hash, err := s.hasher.DefaultHash(password)
+	if err != nil {
+		return err
+	}
+	user.Password = hash
+	user.PasswordIsHash = true
+	_, err = s.accountRepo.UpdateUser(user)
+	return err
+}
@@ -2,7 +2,10 @@ package api_test
 
 import (
 	"errors"
+	"fs-access-api/internal/app/api"
+	"fs-access-api/internal/app/config"
 	"fs-access-api/internal/app/ports"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -70,3 +73,159 @@ var _ = Describe("Authz API (unit)", Ordered, func() {
 		})
 	})
 })
+
+var _ = Describe("Authz API lockout after failed logins (unit)", func() {
+	var apis ports.ApiServer
+	var clock *ports.FakeClock
+
+	BeforeEach(func() {
+		apis = newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.Security.MaxFailedLogins = 3
+			cfg.Security.LockoutDuration = 5 * time.Minute
+		})
+		clock = ports.NewFakeClock(time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC))
+		apis.(*api.DefaultApiServer).SetClock(clock)
+	})
+
+	fail := func(n int) {
+		for i := 0; i < n; i++ {
+			Expect(apis.AuthzAuthUser("operator-a", "test-wrong")).To(MatchError(ports.ErrInvalidCredentials))
+		}
+	}
+
+	It("locks the user after N consecutive failures until the cooldown expires", func() {
+		fail(3)
+		// even the right password is rejected while locked
+		Expect(apis.AuthzAuthUser("operator-a", "test")).To(MatchError(ports.ErrLockedUser))
+		clock.Advance(5*time.Minute - time.Second)
+		Expect(apis.AuthzAuthUser("operator-a", "test")).To(MatchError(ports.ErrLockedUser))
+		clock.Advance(2 * time.Second)
+		Expect(apis.AuthzAuthUser("operator-a", "test")).To(Succeed())
+	})
+
+	It("resets the counter on a successful login before N failures", func() {
+		fail(2)
+		Expect(apis.AuthzAuthUser("operator-a", "test")).To(Succeed())
+		fail(2)
+		Expect(apis.AuthzAuthUser("operator-a", "test")).To(Succeed())
+	})
+
+	It("does not affect other users", func() {
+		fail(3)
+		Expect(apis.AuthzAuthUser("operator-b", "test")).To(Succeed())
+	})
+})
+
+var _ = Describe("Authz API expiration against the clock (unit)", func() {
+	var apis ports.ApiServer
+	var clock *ports.FakeClock
+	var expiration time.Time
+
+	setup := func(grace time.Duration) {
+		apis = newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.AccountRepository.Common.ExpirationGracePeriod = grace
+		})
+		expiration = time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		clock = ports.NewFakeClock(expiration.Add(-time.Minute))
+		apis.(*api.DefaultApiServer).SetClock(clock)
+		Expect(apis.UpdateUser("operator-a", func(u ports.UserInfo) (ports.UserInfo, error) {
+			u.Expiration = &expiration
+			return u, nil
+		})).To(Succeed())
+	}
+
+	It("locks the user as soon as the clock crosses the expiration", func() {
+		setup(0)
+		Expect(apis.AuthzAuthUser("operator-a", "test")).To(Succeed())
+		_, _, err := apis.AuthzLookupUser("operator-a")
+		Expect(err).NotTo(HaveOccurred())
+
+		clock.Advance(time.Minute + time.Second)
+		Expect(apis.AuthzAuthUser("operator-a", "test")).To(MatchError(ports.ErrLockedUser))
+		_, _, err = apis.AuthzLookupUser("operator-a")
+		Expect(err).To(MatchError(ports.ErrLockedUser))
+	})
+
+	It("keeps the user active through the grace period", func() {
+		setup(time.Hour)
+		clock.Advance(time.Minute + 30*time.Minute)
+		Expect(apis.AuthzAuthUser("operator-a", "test")).To(Succeed())
+		clock.Advance(time.Hour)
+		Expect(apis.AuthzAuthUser("operator-a", "test")).To(MatchError(ports.ErrLockedUser))
+	})
+})
+
+var _ = Describe("Authz API legacy password migration (unit)", func() {
+	// {SSHA} of "legacy-pass" with the salt "NaCl1234"
+	const sshaHash = "{SSHA}gXR1YDyOMzFo9neZ7p1tG6kCz4BOYUNsMTIzNA=="
+	var apis ports.ApiServer
+
+	BeforeEach(func() {
+		apis = newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.Security.Hasher.LegacyVerifiers = []string{"ldap-ssha"}
+		})
+		_, _, err := apis.EnsureUser(ports.UserInfo{
+			Username:       "legacy",
+			Groupname:      "default",
+			Home:           "legacy",
+			Password:       sshaHash,
+			PasswordIsHash: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("falls back to the legacy verifier, then rehashes to the default algorithm", func() {
+		Expect(apis.AuthzAuthUser("legacy", "legacy-pass")).To(Succeed())
+
+		u, err := apis.GetUser("legacy")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(u.Password).To(HavePrefix("$5$rounds=5000$"))
+
+		// the new hash keeps working, without the legacy fallback
+		Expect(apis.AuthzAuthUser("legacy", "legacy-pass")).To(Succeed())
+		verified, alg, err := apis.VerifyHash(u.Password, "legacy-pass")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verified).To(BeTrue())
+		Expect(alg).To(Equal(ports.AlgoCryptSHA256))
+	})
+
+	It("keeps the legacy hash when the password is wrong", func() {
+		Expect(apis.AuthzAuthUser("legacy", "nope")).To(MatchError(ports.ErrInvalidCredentials))
+		u, err := apis.GetUser("legacy")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(u.Password).To(Equal(sshaHash))
+	})
+})
+
+var _ = Describe("Authz API expiration grace period (unit)", func() {
+	newApis := func(grace time.Duration) ports.ApiServer {
+		apis := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.AccountRepository.Common.ExpirationGracePeriod = grace
+		})
+		_, _, err := apis.EnsureUser(ports.UserInfo{
+			Username:       "late",
+			Groupname:      "default",
+			Home:           "late",
+			Password:       "098f6bcd4621d373cade4e832627b4f6", // test
+			PasswordIsHash: true,
+			Expiration:     ptr(time.Now().Add(-time.Hour).UTC().Truncate(time.Second)),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return apis
+	}
+
+	It("keeps a user expired within the grace period active", func() {
+		apis := newApis(2 * time.Hour)
+		Expect(apis.AuthzAuthUser("late", "test")).To(Succeed())
+		uai, _, err := apis.AuthzLookupUser("late")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uai.Locked).To(BeFalse())
+	})
+
+	It("locks a user expired for longer than the grace period", func() {
+		apis := newApis(30 * time.Minute)
+		Expect(apis.AuthzAuthUser("late", "test")).To(MatchError(ports.ErrLockedUser))
+		_, _, err := apis.AuthzLookupUser("late")
+		Expect(err).To(MatchError(ports.ErrLockedUser))
+	})
+})
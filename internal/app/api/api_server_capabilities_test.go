@@ -0,0 +1,65 @@
+package api_test
+
+import (
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/api"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// readOnlyRepo is an inmem repository reporting itself read-only.
+type readOnlyRepo struct {
+	*accounts.InMemAccountRepository
+}
+
+func (readOnlyRepo) Capabilities() ports.RepoCapabilities {
+	return ports.RepoCapabilities{ReadOnly: true}
+}
+
+var _ = Describe("Read-only account repository (unit)", func() {
+	var apis ports.ApiServer
+
+	BeforeEach(func() {
+		common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+		inner, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10}, common, true)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = inner.AddGroup(ports.GroupInfo{Groupname: "proj", GID: 3000, Home: "proj"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = inner.AddUser(ports.UserInfo{Username: "alice", UID: 3001, Groupname: "proj", Home: "alice", Password: "$5$x$y", PasswordIsHash: true})
+		Expect(err).NotTo(HaveOccurred())
+		apis, err = api.NewDefaultApiServer(config.StorageConfig{}, config.SecurityConfig{}, common, nil, readOnlyRepo{inner}, &flakyFsStorage{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("serves reads and reports the capabilities", func() {
+		users, err := apis.ListUsers()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(users).To(HaveLen(1))
+		info, caps, err := apis.RepositoryInfo()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info).To(Equal("in-memory"))
+		Expect(caps.ReadOnly).To(BeTrue())
+	})
+
+	It("rejects every mutation with ErrUnsupportedAction", func() {
+		_, _, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "other", GID: 3100, Home: "other"})
+		Expect(err).To(MatchError(ports.ErrUnsupportedAction))
+		Expect(err.Error()).To(ContainSubstring("read-only"))
+		Expect(apis.UpdateGroup("proj", func(g ports.GroupInfo) (ports.GroupInfo, error) { return g, nil })).To(MatchError(ports.ErrUnsupportedAction))
+		Expect(apis.DeleteGroup("proj", false)).To(MatchError(ports.ErrUnsupportedAction))
+
+		_, _, err = apis.EnsureUser(ports.UserInfo{Username: "bob", Groupname: "proj", Home: "bob", Password: "$5$x$y", PasswordIsHash: true})
+		Expect(err).To(MatchError(ports.ErrUnsupportedAction))
+		Expect(apis.UpdateUser("alice", func(u ports.UserInfo) (ports.UserInfo, error) { return u, nil })).To(MatchError(ports.ErrUnsupportedAction))
+		Expect(apis.DeleteUser("alice")).To(MatchError(ports.ErrUnsupportedAction))
+		_, _, _, err = apis.DeleteUsers(ports.UserFilter{Usernames: []string{"alice"}}, false)
+		Expect(err).To(MatchError(ports.ErrUnsupportedAction))
+
+		users, err := apis.ListUsers()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(users).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,66 @@
+//go:build unix
+
+package api_test
+
+import (
+	"sync/atomic"
+
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/adapters/out/fs"
+	"fs-access-api/internal/adapters/out/security"
+	"fs-access-api/internal/app/api"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// countingHasher counts the password verifications.
+type countingHasher struct {
+	ports.Hasher
+	verified atomic.Int32
+}
+
+func (h *countingHasher) Verify(hashed, plain string) (bool, ports.HashAlgo, error) {
+	h.verified.Add(1)
+	return h.Hasher.Verify(hashed, plain)
+}
+
+var _ = Describe("security.constant_time_auth (unit)", func() {
+	newServer := func(constantTime bool) (*api.DefaultApiServer, *countingHasher) {
+		inner, err := security.NewDefaultHasher()
+		Expect(err).NotTo(HaveOccurred())
+		hasher := &countingHasher{Hasher: inner}
+		storageCfg := config.StorageConfig{HomesBaseDir: "/homes", CreateHomesBaseDir: true, DefaultUserTopDirs: []config.TopDirConfig{}}
+		common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+		storage, err := fs.NewDefaultFsStorageService(storageCfg, fs.NewInMemFilesystemService(), true)
+		Expect(err).NotTo(HaveOccurred())
+		repo, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10}, common, true)
+		Expect(err).NotTo(HaveOccurred())
+		apis, err := api.NewDefaultApiServer(storageCfg, config.SecurityConfig{ConstantTimeAuth: constantTime}, common, hasher, repo, storage)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = apis.EnsureGroup(ports.GroupInfo{Groupname: "proj", GID: 3000, Home: "proj"})
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = apis.EnsureUser(ports.UserInfo{Username: "alice", UID: 3001, Groupname: "proj", Home: "alice", Password: "Secr3t!"})
+		Expect(err).NotTo(HaveOccurred())
+		hasher.verified.Store(0)
+		return apis, hasher
+	}
+
+	It("verifies a dummy hash for unknown users, like for known ones", func() {
+		apis, hasher := newServer(true)
+		Expect(apis.AuthzAuthUser("alice", "wrong")).To(MatchError(ports.ErrInvalidCredentials))
+		Expect(hasher.verified.Load()).To(Equal(int32(1)))
+
+		Expect(apis.AuthzAuthUser("nobody", "wrong")).To(MatchError(ports.ErrInvalidCredentials))
+		Expect(hasher.verified.Load()).To(Equal(int32(2)))
+	})
+
+	It("skips the verification for unknown users when disabled", func() {
+		apis, hasher := newServer(false)
+		Expect(apis.AuthzAuthUser("nobody", "wrong")).To(MatchError(ports.ErrInvalidCredentials))
+		Expect(hasher.verified.Load()).To(BeZero())
+	})
+})
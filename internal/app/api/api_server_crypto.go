@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"fs-access-api/internal/app/ports"
+	"slices"
 )
 
 func (s *DefaultApiServer) GenerateSecret(requestedSize *int) (size int, secret []byte, err error) {
@@ -33,3 +34,34 @@ func (s *DefaultApiServer) ComputeHash(plaintext string, algorithm ports.HashAlg
 func (s *DefaultApiServer) VerifyHash(hash, plaintext string) (verified bool, algorithm ports.HashAlgo, err error) {
 	return s.hasher.Verify(hash, plaintext)
 }
+
+// AuditPasswordHashes classifies every stored password with DetectHashAlgo and lists the users
+// whose hash is weaker than security.hasher.audit_min_algorithm.
+func (s *DefaultApiServer) AuditPasswordHashes() (ports.HashAudit, error) {
+	minAlg, err := ports.ParseHashAlgo(s.securityCfg.Hasher.AuditMinAlgorithm)
+	if err != nil {
+		return ports.HashAudit{}, fmt.Errorf("invalid audit minimum algorithm: %w", err)
+	}
+	users, err := s.accountRepo.ListUsers()
+	if err != nil {
+		return ports.HashAudit{}, err
+	}
+	audit := ports.HashAudit{
+		Total:        len(users),
+		ByAlgorithm:  map[ports.HashAlgo]int{},
+		MinAlgorithm: minAlg,
+		WeakUsers:    []string{},
+	}
+	for _, user := range users {
+		alg, err := ports.DetectHashAlgo(user.Password)
+		if err != nil {
+			alg = ports.AlgoUnknown
+		}
+		audit.ByAlgorithm[alg]++
+		if alg.WeakerThan(minAlg) {
+			audit.WeakUsers = append(audit.WeakUsers, user.Username)
+		}
+	}
+	slices.Sort(audit.WeakUsers)
+	return audit, nil
+}
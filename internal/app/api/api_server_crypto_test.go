@@ -2,6 +2,7 @@ package api_test
 
 import (
 	"fs-access-api/internal/adapters/out/security"
+	"fs-access-api/internal/app/config"
 	"fs-access-api/internal/app/ports"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -94,3 +95,52 @@ var _ = Describe("Crypto API (unit)", Ordered, func() {
 		Expect(secret).NotTo(BeEmpty())
 	})
 })
+
+var _ = Describe("Hash audit (unit)", func() {
+	It("counts algorithms and lists users below the configured minimum", func() {
+		apis := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.AccountRepository.LoadInitialData = false
+			cfg.Security.Hasher.AuditMinAlgorithm = string(ports.AlgoCryptSHA256)
+		})
+		hasher, err := security.NewDefaultHasher()
+		Expect(err).NotTo(HaveOccurred())
+		hash := func(alg ports.HashAlgo) string {
+			h, err := hasher.Hash("secret", alg, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			return h
+		}
+
+		_, _, err = apis.EnsureGroup(ports.GroupInfo{Groupname: "audit", GID: 4100, Home: "audit"})
+		Expect(err).NotTo(HaveOccurred())
+		for i, u := range []struct {
+			name     string
+			password string
+			isHash   bool
+		}{
+			{"strong-default", "plain-secret", false}, // hashed with the default crypt-sha256
+			{"strong-sha512", hash(ports.AlgoCryptSHA512), true},
+			{"weak-md5", hash(ports.AlgoRawMD5), true},
+			{"weak-crypt-md5", hash(ports.AlgoCryptMD5), true},
+			{"weak-unknown", "not-a-hash", true},
+		} {
+			_, _, err := apis.EnsureUser(ports.UserInfo{
+				Username: u.name, UID: uint32(2100 + i), Groupname: "audit", Home: u.name,
+				Password: u.password, PasswordIsHash: u.isHash,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		audit, err := apis.AuditPasswordHashes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(audit.Total).To(Equal(5))
+		Expect(audit.MinAlgorithm).To(Equal(ports.AlgoCryptSHA256))
+		Expect(audit.ByAlgorithm).To(Equal(map[ports.HashAlgo]int{
+			ports.AlgoCryptSHA256: 1,
+			ports.AlgoCryptSHA512: 1,
+			ports.AlgoRawMD5:      1,
+			ports.AlgoCryptMD5:    1,
+			ports.AlgoUnknown:     1,
+		}))
+		Expect(audit.WeakUsers).To(Equal([]string{"weak-crypt-md5", "weak-md5", "weak-unknown"}))
+	})
+})
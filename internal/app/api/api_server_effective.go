@@ -0,0 +1,41 @@
+package api
+
+import (
+	"fs-access-api/internal/app/ports"
+	"io/fs"
+)
+
+// EffectiveUserPolicy aggregates the configuration and the user's group into the settings applying to the user.
+func (s *DefaultApiServer) EffectiveUserPolicy(name string) (ports.EffectiveUserPolicy, error) {
+	user, err := s.accountRepo.GetUser(name)
+	if err != nil {
+		return ports.EffectiveUserPolicy{}, err
+	}
+	group, err := s.accountRepo.GetGroup(user.Groupname)
+	if err != nil {
+		return ports.EffectiveUserPolicy{}, err
+	}
+	// the path is returned even for a home the storage would refuse, which is worth seeing here
+	homeDir, _ := s.paths.UserHome(group.Home, user.Home)
+	topDirs := make([]ports.EffectiveTopDir, 0, len(s.storageCfg.DefaultUserTopDirs))
+	for _, d := range s.storageCfg.DefaultUserTopDirs {
+		mode := d.EffectiveMode()
+		if d.EffectiveSetgid() {
+			mode |= fs.ModeSetgid
+		}
+		topDirs = append(topDirs, ports.EffectiveTopDir{Name: d.Name, Mode: mode})
+	}
+	return ports.EffectiveUserPolicy{
+		Username:                 user.Username,
+		Groupname:                group.Groupname,
+		HomeDir:                  homeDir,
+		TopDirs:                  topDirs,
+		GroupQuotaBytes:          group.QuotaBytes,
+		GroupQuotaEnforced:       s.storageCfg.EnforceGroupQuotas && group.QuotaBytes != nil,
+		Locked:                   user.IsLocked(s.commonCfg.ExpirationGracePeriod, s.clock.Now()),
+		Expiration:               user.Expiration,
+		ExpirationGracePeriod:    s.commonCfg.ExpirationGracePeriod,
+		ForbidUsernameInPassword: s.securityCfg.PasswordPolicy.ForbidUsernameInPassword,
+		PasswordAlgorithm:        s.securityCfg.Hasher.DefaultAlgorithm,
+	}, nil
+}
@@ -2,18 +2,37 @@ package api
 
 import (
 	"errors"
+	"fmt"
 	"fs-access-api/internal/app/ports"
+	"path/filepath"
+	"strings"
 )
 
 func (s *DefaultApiServer) ListGroups() ([]ports.GroupInfo, error) {
 	return s.accountRepo.ListGroups()
 }
 
+func (s *DefaultApiServer) ListEmptyGroups() ([]ports.GroupInfo, error) {
+	return s.accountRepo.ListEmptyGroups()
+}
+
 func (s *DefaultApiServer) GetGroup(name string) (ports.GroupInfo, error) {
 	return s.accountRepo.GetGroup(name)
 }
 
 func (s *DefaultApiServer) EnsureGroup(rg ports.GroupInfo) (pg ports.GroupInfo, created bool, err error) {
+	if err = s.requireWritable(); err != nil {
+		return ports.GroupInfo{}, false, err
+	}
+	if err = validateName("groupname", rg.Groupname); err != nil {
+		return ports.GroupInfo{}, false, err
+	}
+	if err = s.validateDescription(rg.Description); err != nil {
+		return ports.GroupInfo{}, false, err
+	}
+	if err = validateQuotaBytes(rg.QuotaBytes); err != nil {
+		return ports.GroupInfo{}, false, err
+	}
 	pg, err = s.GetGroup(rg.Groupname)
 	create := false
 	if err != nil {
@@ -25,6 +44,12 @@ func (s *DefaultApiServer) EnsureGroup(rg ports.GroupInfo) (pg ports.GroupInfo,
 	}
 	if create {
 		// Create
+		if _, err = s.paths.GroupHome(rg.Home); err != nil {
+			return ports.GroupInfo{}, false, err
+		}
+		if err = s.checkGroupHomeOverlap(rg); err != nil {
+			return ports.GroupInfo{}, false, err
+		}
 		pg, err = s.accountRepo.AddGroup(rg)
 		if err != nil {
 			return ports.GroupInfo{}, false, err
@@ -43,6 +68,9 @@ func (s *DefaultApiServer) EnsureGroup(rg ports.GroupInfo) (pg ports.GroupInfo,
 }
 
 func (s *DefaultApiServer) UpdateGroup(name string, mutate func(obj ports.GroupInfo) (ports.GroupInfo, error)) error {
+	if err := s.requireWritable(); err != nil {
+		return err
+	}
 	pg, err := s.accountRepo.GetGroup(name)
 	if err != nil {
 		return err
@@ -51,24 +79,84 @@ func (s *DefaultApiServer) UpdateGroup(name string, mutate func(obj ports.GroupI
 	if err != nil {
 		return err
 	}
+	mg.Version = pg.Version // the write fails if the group changed since it was read
+	if err = s.validateDescription(mg.Description); err != nil {
+		return err
+	}
+	if err = validateQuotaBytes(mg.QuotaBytes); err != nil {
+		return err
+	}
 	_, err = s.accountRepo.UpdateGroup(mg)
+	if mg.Home != pg.Home {
+		s.homes.invalidate()
+	}
 	return err
 }
 
-func (s *DefaultApiServer) DeleteGroup(name string) error {
-	_, err := s.accountRepo.GetGroup(name)
+// DeleteGroup deletes the group, then removes its home if asked to and it is empty;
+// a home that can't be removed is reported, the group stays deleted.
+func (s *DefaultApiServer) DeleteGroup(name string, purge bool) error {
+	if err := s.requireWritable(); err != nil {
+		return err
+	}
+	group, err := s.accountRepo.GetGroup(name)
 	if err != nil {
-		return ports.ErrNotFound
+		return err
+	}
+	if purge {
+		// the home goes first, so a home that isn't empty (ErrConflict) leaves everything as it was
+		if err = s.fs.RemoveGroupHome(group); err != nil {
+			return fmt.Errorf("group %q kept with its home: %w", name, err)
+		}
 	}
 	err = s.accountRepo.DeleteGroup(name)
+	s.homes.invalidate()
+	if err != nil && purge {
+		// the removed home was empty, give it back to the group still stored
+		if prepErr := s.fs.PrepareGroupHome(group); prepErr != nil {
+			return fmt.Errorf("%w (and its home couldn't be restored: %v)", err, prepErr)
+		}
+	}
+	return err
+}
+
+// checkGroupHomeOverlap fails with ErrConflict under storage.enforce_non_overlapping_group_homes
+// when the group home resolves to, above or below the home of another group.
+func (s *DefaultApiServer) checkGroupHomeOverlap(group ports.GroupInfo) error {
+	if !s.storageCfg.EnforceNonOverlappingGroupHomes {
+		return nil
+	}
+	groups, err := s.accountRepo.ListGroups()
 	if err != nil {
 		return err
 	}
+	home := filepath.Join(s.storageCfg.HomesBaseDir, group.Home)
+	for _, g := range groups {
+		if g.Groupname == group.Groupname {
+			continue
+		}
+		other := filepath.Join(s.storageCfg.HomesBaseDir, g.Home)
+		if pathWithin(home, other) || pathWithin(other, home) {
+			return fmt.Errorf("%w: group home %q overlaps the home %q of group %q", ports.ErrConflict, group.Home, g.Home, g.Groupname)
+		}
+	}
 	return nil
 }
 
+// pathWithin tells whether path is dir itself or lies below it.
+func pathWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// sameHome compares relative homes by the directory they resolve to,
+// so "bob", "./bob" and "bob/" are equal while "bob" and "bob2" are not.
+func sameHome(a, b string) bool {
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
 func sameGroupData(a, b ports.GroupInfo) bool {
-	if a.Groupname != b.Groupname || a.GID != b.GID || a.Home != b.Home {
+	if a.Groupname != b.Groupname || a.GID != b.GID || !sameHome(a.Home, b.Home) {
 		return false
 	}
 	if (a.Description == nil && b.Description != nil) || (a.Description != nil && b.Description == nil) {
@@ -77,6 +165,12 @@ func sameGroupData(a, b ports.GroupInfo) bool {
 	if a.Description != nil && b.Description != nil && *a.Description != *b.Description {
 		return false
 	}
+	if (a.QuotaBytes == nil) != (b.QuotaBytes == nil) {
+		return false
+	}
+	if a.QuotaBytes != nil && b.QuotaBytes != nil && *a.QuotaBytes != *b.QuotaBytes {
+		return false
+	}
 
 	return true
 }
@@ -1,7 +1,12 @@
 package api_test
 
 import (
+	"errors"
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/api"
+	"fs-access-api/internal/app/config"
 	"fs-access-api/internal/app/ports"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -19,7 +24,7 @@ var _ = Describe("Groups API (unit)", Ordered, func() {
 
 	AfterAll(func() {
 		// best-effort cleanup (ignore error)
-		_ = apis.DeleteGroup(gname)
+		_ = apis.DeleteGroup(gname, false)
 	})
 
 	It("EnsureGroup: create then idempotent", func() {
@@ -88,7 +93,7 @@ var _ = Describe("Groups API (unit)", Ordered, func() {
 	})
 
 	It("DeleteGroup: removes the group; GetGroup -> not found", func() {
-		err := apis.DeleteGroup(gname)
+		err := apis.DeleteGroup(gname, false)
 		Expect(err).NotTo(HaveOccurred())
 
 		_, err = apis.GetGroup(gname)
@@ -101,7 +106,121 @@ var _ = Describe("Groups API (unit)", Ordered, func() {
 
 	It("DeleteGroup: idempotent delete", func() {
 		// deleting again should not crash; allow not-found as success semantics as long as no panic
-		err := apis.DeleteGroup(gname)
+		err := apis.DeleteGroup(gname, false)
 		Expect(err).To(SatisfyAny(BeNil(), MatchError(ContainSubstring("not found"))))
 	})
 })
+
+var _ = Describe("Groups API description length (unit)", func() {
+	var apis ports.ApiServer
+
+	BeforeEach(func() {
+		apis = newTestServerFromConfig(TestConfigPath)
+	})
+
+	It("round-trips a description longer than 255 characters", func() {
+		long := strings.Repeat("ż", 1000)
+		_, _, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "rich-meta", GID: 4100, Home: "rich-meta", Description: &long})
+		Expect(err).NotTo(HaveOccurred())
+
+		g, err := apis.GetGroup("rich-meta")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(g.Description).NotTo(BeNil())
+		Expect(*g.Description).To(Equal(long))
+	})
+
+	It("rejects an over-limit description as invalid input", func() {
+		tooLong := strings.Repeat("x", 4097)
+		_, _, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "too-rich", GID: 4101, Home: "too-rich", Description: &tooLong})
+		Expect(err).To(MatchError(ports.ErrInvalidInput))
+
+		err = apis.UpdateGroup("group-a", func(g ports.GroupInfo) (ports.GroupInfo, error) {
+			g.Description = &tooLong
+			return g, nil
+		})
+		Expect(err).To(MatchError(ports.ErrInvalidInput))
+
+		err = apis.UpdateUser("operator-a", func(u ports.UserInfo) (ports.UserInfo, error) {
+			u.Description = &tooLong
+			return u, nil
+		})
+		Expect(err).To(MatchError(ports.ErrInvalidInput))
+	})
+})
+
+var _ = Describe("Groups API non-overlapping homes (unit)", func() {
+	newServer := func(enforce bool) ports.ApiServer {
+		common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000, AutoCreatePersonalGroup: true}
+		repo, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10}, common, true)
+		Expect(err).NotTo(HaveOccurred())
+		storageCfg := config.StorageConfig{HomesBaseDir: "/homes", EnforceNonOverlappingGroupHomes: enforce}
+		apis, err := api.NewDefaultApiServer(storageCfg, config.SecurityConfig{}, common, nil, repo, &flakyFsStorage{})
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = apis.EnsureGroup(ports.GroupInfo{Groupname: "team-a", GID: 3000, Home: "teams/a"})
+		Expect(err).NotTo(HaveOccurred())
+		return apis
+	}
+
+	It("rejects a group home nesting with another one under the flag", func() {
+		apis := newServer(true)
+		for i, home := range []string{"teams", "teams/a/sub", "./teams/a/"} {
+			_, _, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "other", GID: uint32(3100 + i), Home: home})
+			Expect(err).To(MatchError(ports.ErrConflict), home)
+			Expect(err.Error()).To(ContainSubstring(`group "team-a"`))
+		}
+		_, err := apis.GetGroup("other")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+
+		_, created, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "team-b", GID: 3001, Home: "teams/b"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+		_, created, err = apis.EnsureGroup(ports.GroupInfo{Groupname: "team-a", GID: 3000, Home: "teams/a"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeFalse())
+	})
+
+	It("rejects a personal group whose home nests with another one under the flag", func() {
+		apis := newServer(true)
+		_, _, err := apis.EnsureUser(ports.UserInfo{Username: "teams", UID: 3200, Groupname: "teams", Home: ".", Password: "$5$x$y", PasswordIsHash: true})
+		Expect(err).To(MatchError(ports.ErrConflict))
+		Expect(err.Error()).To(ContainSubstring(`personal group "teams"`))
+		_, err = apis.GetGroup("teams")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+		_, err = apis.GetUser("teams")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+
+		_, created, err := apis.EnsureUser(ports.UserInfo{Username: "solo", UID: 3201, Groupname: "solo", Home: ".", Password: "$5$x$y", PasswordIsHash: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+	})
+
+	It("accepts nested group homes without the flag", func() {
+		apis := newServer(false)
+		_, created, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "teams", GID: 3001, Home: "teams"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+	})
+})
+
+// unreachableGroupsRepo fails the group reads like a database outage would.
+type unreachableGroupsRepo struct {
+	ports.AccountRepository
+}
+
+func (unreachableGroupsRepo) GetGroup(string) (ports.GroupInfo, error) {
+	return ports.GroupInfo{}, errors.New("dial tcp 10.0.0.5:3306: connection refused")
+}
+
+var _ = Describe("Groups API repository failures (unit)", func() {
+	It("DeleteGroup: reports a failed read as is, not as not found", func() {
+		common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+		repo, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10}, common, true)
+		Expect(err).NotTo(HaveOccurred())
+		apis, err := api.NewDefaultApiServer(config.StorageConfig{HomesBaseDir: "/homes"}, config.SecurityConfig{}, common, nil, unreachableGroupsRepo{repo}, &flakyFsStorage{})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = apis.DeleteGroup("team", true)
+		Expect(err).To(MatchError(ContainSubstring("connection refused")))
+		Expect(err).NotTo(MatchError(ports.ErrNotFound))
+	})
+})
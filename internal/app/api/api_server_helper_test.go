@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"context"
 	"fs-access-api/internal/app"
 	"fs-access-api/internal/app/config"
 	"fs-access-api/internal/app/ports"
@@ -16,6 +17,11 @@ func ptr[T any](v T) *T { return &v }
 
 // --- Seedable server ---
 func newTestServerFromConfig(configPath string) ports.ApiServer {
+	return newTestServerFromConfigWith(configPath, nil)
+}
+
+// newTestServerFromConfigWith lets a test adjust the loaded config before the server is built.
+func newTestServerFromConfigWith(configPath string, mutate func(cfg *config.ProgramConfig)) ports.ApiServer {
 	data, err := os.ReadFile(configPath)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -29,10 +35,14 @@ func newTestServerFromConfig(configPath string) ports.ApiServer {
 	cfg, err := config.LoadConfigString(dataStr)
 	Expect(err).NotTo(HaveOccurred())
 
+	if mutate != nil {
+		mutate(cfg)
+	}
+
 	err = os.MkdirAll(cfg.Storage.HomesBaseDir, 0755)
 	Expect(err).NotTo(HaveOccurred())
 
-	rs, err := app.BuildApiServer(cfg, true)
+	rs, err := app.BuildApiServer(context.Background(), cfg, true)
 	Expect(err).NotTo(HaveOccurred())
 
 	return rs
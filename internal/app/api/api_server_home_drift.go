@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"fs-access-api/internal/app/ports"
+	"log"
+	"time"
+)
+
+// CheckHomeDrift compares every user home with the owner and mode it was prepared with, waiting
+// storage.home_drift_check.user_pause between the users, and keeps the result as the last report.
+// A canceled ctx interrupts the check, the last report is then left as it was.
+func (s *DefaultApiServer) CheckHomeDrift(ctx context.Context) (ports.HomeDriftReport, error) {
+	users, err := s.accountRepo.ListUsers()
+	if err != nil {
+		return ports.HomeDriftReport{}, err
+	}
+	groups := make(map[string]ports.GroupInfo)
+	report := ports.HomeDriftReport{Drifts: []ports.HomeDrift{}}
+	pause := time.NewTimer(0)
+	defer pause.Stop()
+	for _, user := range users {
+		select {
+		case <-ctx.Done():
+			return ports.HomeDriftReport{}, ctx.Err()
+		case <-pause.C:
+		}
+		pause.Reset(s.storageCfg.HomeDriftCheck.UserPause)
+
+		group, ok := groups[user.Groupname]
+		if !ok {
+			if group, err = s.accountRepo.GetGroup(user.Groupname); err != nil {
+				log.Printf("Home drift check: cannot read group '%s' of user '%s': %v", user.Groupname, user.Username, err)
+				report.Errors++
+				continue
+			}
+			groups[user.Groupname] = group
+		}
+		drifts, err := s.fs.CheckUserHome(user, group)
+		if err != nil {
+			log.Printf("Home drift check: cannot check the home of user '%s': %v", user.Username, err)
+			report.Errors++
+			continue
+		}
+		report.UsersChecked++
+		report.Drifts = append(report.Drifts, drifts...)
+	}
+	now := s.clock.Now().UTC()
+	report.CheckedAt = &now
+	s.homeDrift.Store(&report)
+	return report, nil
+}
+
+// LastHomeDriftReport returns the report of the last completed CheckHomeDrift, empty before the first one.
+func (s *DefaultApiServer) LastHomeDriftReport() ports.HomeDriftReport {
+	if report := s.homeDrift.Load(); report != nil {
+		return *report
+	}
+	return ports.HomeDriftReport{Drifts: []ports.HomeDrift{}}
+}
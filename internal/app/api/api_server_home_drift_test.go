@@ -0,0 +1,78 @@
+package api_test
+
+import (
+	"context"
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/adapters/out/fs"
+	"fs-access-api/internal/app/api"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Home drift check (unit)", func() {
+	var (
+		fsm  *fs.InMemFilesystemService
+		apis ports.ApiServer
+	)
+
+	BeforeEach(func() {
+		common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+		repo, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10}, common, true)
+		Expect(err).NotTo(HaveOccurred())
+		storageCfg := config.StorageConfig{HomesBaseDir: "/homes", CreateHomesBaseDir: true, DefaultUserTopDirs: []config.TopDirConfig{{Name: "_test"}},
+			HomeDriftCheck: config.HomeDriftCheckConfig{UserPause: time.Millisecond}}
+		fsm = fs.NewInMemFilesystemService()
+		storage, err := fs.NewDefaultFsStorageService(storageCfg, fsm, true)
+		Expect(err).NotTo(HaveOccurred())
+		apis, err = api.NewDefaultApiServer(storageCfg, config.SecurityConfig{}, common, nil, repo, storage)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = apis.EnsureGroup(ports.GroupInfo{Groupname: "proj", GID: 3000, Home: "proj"})
+		Expect(err).NotTo(HaveOccurred())
+		for i, name := range []string{"alice", "bob", "carol"} {
+			_, _, err = apis.EnsureUser(ports.UserInfo{Username: name, UID: uint32(3001 + i), Groupname: "proj", Home: name, Password: "$5$x$y", PasswordIsHash: true})
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	It("reports no drift for freshly prepared homes", func() {
+		Expect(apis.LastHomeDriftReport().CheckedAt).To(BeNil())
+		report, err := apis.CheckHomeDrift(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.UsersChecked).To(Equal(3))
+		Expect(report.Drifts).To(BeEmpty())
+		Expect(report.CheckedAt).NotTo(BeNil())
+		Expect(apis.LastHomeDriftReport()).To(Equal(report))
+	})
+
+	It("detects a home with a wrong mode, a wrong owner and a missing one", func() {
+		Expect(fsm.Chmod("/homes/proj/alice", 0o777)).To(Succeed())
+		Expect(fsm.Chown("/homes/proj/bob", 0, 3000)).To(Succeed())
+		Expect(fsm.RemoveAll("/homes/proj/carol")).To(Succeed())
+
+		report, err := apis.CheckHomeDrift(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.UsersChecked).To(Equal(3))
+		Expect(report.Errors).To(BeZero())
+		Expect(report.Drifts).To(ConsistOf(
+			ports.HomeDrift{Username: "alice", Path: "/homes/proj/alice", Kind: ports.HomeDriftMode, Expected: "0751", Actual: "0777"},
+			ports.HomeDrift{Username: "bob", Path: "/homes/proj/bob", Kind: ports.HomeDriftOwner, Expected: "3002:3000", Actual: "0:3000"},
+			ports.HomeDrift{Username: "carol", Path: "/homes/proj/carol", Kind: ports.HomeDriftMissing},
+		))
+		// checking doesn't repair anything
+		_, err = fsm.ReadDir("/homes/proj/carol")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("gives up on a canceled context keeping the last report", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := apis.CheckHomeDrift(ctx)
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(apis.LastHomeDriftReport().CheckedAt).To(BeNil())
+	})
+})
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"fs-access-api/internal/app/ports"
+	"sync"
+)
+
+// homeIndex maps the absolute user homes to their users for storage.enforce_globally_unique_home.
+// Other instances write the repository behind its back, so it's only a hint: homeOwner confirms
+// every hit against the repository and rebuilds it on every miss.
+type homeIndex struct {
+	mu     sync.Mutex
+	byHome map[string]string // nil when it has to be rebuilt
+}
+
+func (x *homeIndex) invalidate() {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.byHome = nil
+}
+
+// add records a user added after the check, the index stays valid.
+func (x *homeIndex) add(home, username string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.byHome != nil {
+		x.byHome[home] = username
+	}
+}
+
+func (x *homeIndex) lookup(home string) (string, bool) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	username, ok := x.byHome[home]
+	return username, ok
+}
+
+func (x *homeIndex) set(byHome map[string]string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.byHome = byHome
+}
+
+// loadHomeIndex resolves the absolute home of every user, users of a missing group are skipped.
+func (s *DefaultApiServer) loadHomeIndex() (map[string]string, error) {
+	groups, err := s.accountRepo.ListGroups()
+	if err != nil {
+		return nil, err
+	}
+	groupHomes := make(map[string]string, len(groups))
+	for _, g := range groups {
+		groupHomes[g.Groupname] = g.Home
+	}
+	users, err := s.accountRepo.ListUsers()
+	if err != nil {
+		return nil, err
+	}
+	byHome := make(map[string]string, len(users))
+	for _, u := range users {
+		if groupHome, ok := groupHomes[u.Groupname]; ok {
+			byHome[u.AbsoluteHomeDir(s.storageCfg.HomesBaseDir, groupHome)] = u.Username
+		}
+	}
+	return byHome, nil
+}
+
+// checkGloballyUniqueHome fails with ErrConflict under storage.enforce_globally_unique_home when the
+// home of a user about to be created resolves to the home of another user, whatever their groups.
+func (s *DefaultApiServer) checkGloballyUniqueHome(user ports.UserInfo) error {
+	if !s.storageCfg.EnforceGloballyUniqueHome {
+		return nil
+	}
+	group, err := s.accountRepo.GetGroup(user.Groupname)
+	if errors.Is(err, ports.ErrNotFound) {
+		return nil // reported when the home is prepared
+	}
+	if err != nil {
+		return err
+	}
+	home := user.AbsoluteHomeDir(s.storageCfg.HomesBaseDir, group.Home)
+	other, taken, err := s.homeOwner(home)
+	if err != nil {
+		return fmt.Errorf("cannot index the user homes: %w", err)
+	}
+	if taken && other != user.Username {
+		return fmt.Errorf("%w: home of user %q resolves to %q, already the home of user %q", ports.ErrHomeTaken, user.Username, home, other)
+	}
+	return nil
+}
+
+// homeOwner returns the user whose home resolves to home as the repository stores it now:
+// a hit of the index is confirmed with the owner's stored home, a miss (or a stale hit) rebuilds it.
+func (s *DefaultApiServer) homeOwner(home string) (string, bool, error) {
+	if owner, ok := s.homes.lookup(home); ok {
+		current, err := s.storedHome(owner)
+		if err == nil && current == home {
+			return owner, true, nil
+		}
+		if err != nil && !errors.Is(err, ports.ErrNotFound) {
+			return "", false, err
+		}
+	}
+	byHome, err := s.loadHomeIndex()
+	if err != nil {
+		return "", false, err
+	}
+	s.homes.set(byHome)
+	owner, ok := byHome[home]
+	return owner, ok, nil
+}
+
+// storedHome resolves the absolute home of a stored user.
+func (s *DefaultApiServer) storedHome(username string) (string, error) {
+	user, err := s.accountRepo.GetUser(username)
+	if err != nil {
+		return "", err
+	}
+	group, err := s.accountRepo.GetGroup(user.Groupname)
+	if err != nil {
+		return "", err
+	}
+	return user.AbsoluteHomeDir(s.storageCfg.HomesBaseDir, group.Home), nil
+}
+
+// indexHome records the home of a user just added, when the index is in use.
+func (s *DefaultApiServer) indexHome(user ports.UserInfo) {
+	if !s.storageCfg.EnforceGloballyUniqueHome {
+		return
+	}
+	if group, err := s.accountRepo.GetGroup(user.Groupname); err == nil {
+		s.homes.add(user.AbsoluteHomeDir(s.storageCfg.HomesBaseDir, group.Home), user.Username)
+	} else {
+		s.homes.invalidate()
+	}
+}
@@ -0,0 +1,132 @@
+package api_test
+
+import (
+	"errors"
+	"fmt"
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/api"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// flakyFsStorage fails PrepareUserHomeDetailed with err for its first `failures` calls.
+type flakyFsStorage struct {
+	ports.FsStorageService // nil, only the home preparation is exercised
+	failures               int
+	err                    error
+	calls                  int
+	removedGroupHomes      []string
+}
+
+func (f *flakyFsStorage) PrepareGroupHome(ports.GroupInfo) error { return nil }
+
+func (f *flakyFsStorage) RemoveGroupHome(group ports.GroupInfo) error {
+	f.removedGroupHomes = append(f.removedGroupHomes, group.Home)
+	return nil
+}
+
+func (f *flakyFsStorage) PrepareUserHomeDetailed(ports.UserInfo, ports.GroupInfo) (ports.HomePrepResult, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return ports.HomePrepResult{}, fmt.Errorf("mkdir /homes/proj/alice: %w", f.err)
+	}
+	return ports.HomePrepResult{}, nil
+}
+
+var _ = Describe("EnsureUser home preparation retries (unit)", func() {
+	var repo *accounts.InMemAccountRepository
+
+	newServer := func(fs *flakyFsStorage, common config.AccountRepositoryCommonConfig, rollback bool) ports.ApiServer {
+		storageCfg := config.StorageConfig{
+			HomesBaseDir:          "/homes",
+			PrepareHomeRetries:    2,
+			PrepareHomeRetryDelay: time.Millisecond,
+			RollbackOnHomeFailure: rollback,
+		}
+		var err error
+		repo, err = accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10}, common, true)
+		Expect(err).NotTo(HaveOccurred())
+		apis, err := api.NewDefaultApiServer(storageCfg, config.SecurityConfig{}, common, nil, repo, fs)
+		Expect(err).NotTo(HaveOccurred())
+		return apis
+	}
+	common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+	alice := ports.UserInfo{Username: "alice", UID: 3001, Groupname: "proj", Home: "alice", Password: "$5$x$y", PasswordIsHash: true}
+
+	It("retries a transient failure until the home is prepared", func() {
+		fs := &flakyFsStorage{failures: 2, err: syscall.EAGAIN}
+		apis := newServer(fs, common, true)
+		_, _, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "proj", GID: 3000, Home: "proj"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, created, err := apis.EnsureUser(alice)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+		Expect(fs.calls).To(Equal(3))
+	})
+
+	It("gives up after the configured retries and rolls the new user back", func() {
+		fs := &flakyFsStorage{failures: 10, err: syscall.EBUSY}
+		apis := newServer(fs, common, true)
+		_, _, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "proj", GID: 3000, Home: "proj"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = apis.EnsureUser(alice)
+		Expect(errors.Is(err, syscall.EBUSY)).To(BeTrue())
+		Expect(fs.calls).To(Equal(3))
+		_, err = repo.GetUser("alice")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+	})
+
+	It("doesn't retry a permanent failure and rolls back the user and its personal group", func() {
+		fs := &flakyFsStorage{failures: 1, err: syscall.EACCES}
+		apis := newServer(fs, config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000, AutoCreatePersonalGroup: true}, true)
+		bob := ports.UserInfo{Username: "bob", UID: 3002, Groupname: "bob", Home: ".", Password: "$5$x$y", PasswordIsHash: true}
+
+		_, _, err := apis.EnsureUser(bob)
+		Expect(errors.Is(err, syscall.EACCES)).To(BeTrue())
+		Expect(fs.calls).To(Equal(1))
+		_, err = repo.GetUser("bob")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+		_, err = repo.GetGroup("bob")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+		Expect(fs.removedGroupHomes).To(Equal([]string{"bob"}))
+	})
+
+	It("removes the personal group with its home when the user can't be added", func() {
+		fs := &flakyFsStorage{}
+		apis := newServer(fs, config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000, AutoCreatePersonalGroup: true}, true)
+		_, _, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "proj", GID: 3000, Home: "proj"})
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = apis.EnsureUser(alice)
+		Expect(err).NotTo(HaveOccurred())
+		bob := ports.UserInfo{Username: "bob", UID: alice.UID, Groupname: "bob", Home: ".", Password: "$5$x$y", PasswordIsHash: true}
+
+		_, _, err = apis.EnsureUser(bob)
+		Expect(err).To(MatchError(ports.ErrUIDTaken))
+		_, err = repo.GetGroup("bob")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+		Expect(fs.removedGroupHomes).To(Equal([]string{"bob"}))
+	})
+
+	It("keeps the user without rollback so a later ensure prepares the home", func() {
+		fs := &flakyFsStorage{failures: 1, err: syscall.EACCES}
+		apis := newServer(fs, common, false)
+		_, _, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "proj", GID: 3000, Home: "proj"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = apis.EnsureUser(alice)
+		Expect(err).To(HaveOccurred())
+		_, err = repo.GetUser("alice")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, created, err := apis.EnsureUser(alice)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeFalse())
+	})
+})
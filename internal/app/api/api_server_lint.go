@@ -0,0 +1,55 @@
+package api
+
+import (
+	"fmt"
+	"fs-access-api/internal/app/ports"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// minAdvisedPasswordLength is the length below which an accepted plaintext password gets a warning.
+const minAdvisedPasswordLength = 12
+
+var plainHomeChars = regexp.MustCompile(`^[A-Za-z0-9._/-]+$`)
+
+// LintGroup returns advisories about group input that is accepted but suspicious.
+func (s *DefaultApiServer) LintGroup(group ports.GroupInfo) (warnings []string) {
+	return lintHome("group", group.Home)
+}
+
+// LintUser returns advisories about user input that is accepted but suspicious,
+// it expects the request as sent (i.e. the password not hashed yet).
+func (s *DefaultApiServer) LintUser(user ports.UserInfo) (warnings []string) {
+	warnings = lintHome("user", user.Home)
+	if filepath.Clean(user.Home) == "." {
+		warnings = append(warnings, "user home is the group home itself, it's shared with other members of the group")
+	}
+	if user.PasswordIsHash {
+		if alg, err := ports.DetectHashAlgo(user.Password); err == nil && !alg.IsSalted() {
+			warnings = append(warnings, fmt.Sprintf("password hash is an unsalted %s digest", alg))
+		}
+	} else if n := utf8.RuneCountInString(user.Password); n < minAdvisedPasswordLength {
+		warnings = append(warnings, fmt.Sprintf("password has %d characters, at least %d are advised", n, minAdvisedPasswordLength))
+	}
+	if now := s.clock.Now(); user.Expiration != nil && user.Expiration.Before(now) {
+		if user.IsLocked(s.commonCfg.ExpirationGracePeriod, now) {
+			warnings = append(warnings, "expiration is in the past, the user is locked")
+		} else {
+			warnings = append(warnings, "expiration is in the past, the user is locked once the expiration grace period ends")
+		}
+	}
+	return warnings
+}
+
+func lintHome(kind, home string) (warnings []string) {
+	if home != "" && !plainHomeChars.MatchString(home) {
+		warnings = append(warnings, fmt.Sprintf("%s home %q contains characters other than letters, digits, '.', '_', '-' and '/'", kind, home))
+	}
+	if slices.Contains(strings.Split(filepath.ToSlash(home), "/"), "..") {
+		warnings = append(warnings, fmt.Sprintf("%s home %q contains '..' segments", kind, home))
+	}
+	return warnings
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// loginFailures tracks consecutive failed logins per user (in memory, per instance): the instances
+// behind a load balancer count apart and a restart clears the lockouts, nothing is persisted.
+type loginFailures struct {
+	maxFailures int
+	duration    time.Duration
+	mu          sync.Mutex
+	entries     map[string]*loginFailureEntry
+}
+
+type loginFailureEntry struct {
+	count       int
+	lockedUntil time.Time
+}
+
+func newLoginFailures(maxFailures int, duration time.Duration) *loginFailures {
+	return &loginFailures{
+		maxFailures: maxFailures,
+		duration:    duration,
+		entries:     make(map[string]*loginFailureEntry),
+	}
+}
+
+func (f *loginFailures) enabled() bool {
+	return f.maxFailures > 0
+}
+
+// isLocked reports whether the user is within the lockout cooldown.
+func (f *loginFailures) isLocked(username string, now time.Time) bool {
+	if !f.enabled() {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	e, ok := f.entries[username]
+	if !ok || e.lockedUntil.IsZero() {
+		return false
+	}
+	if now.Before(e.lockedUntil) {
+		return true
+	}
+	// cooldown expired, start counting from scratch
+	delete(f.entries, username)
+	return false
+}
+
+// registerFailure counts a failed attempt and reports whether it locked the user.
+func (f *loginFailures) registerFailure(username string, now time.Time) bool {
+	if !f.enabled() {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	e, ok := f.entries[username]
+	if !ok {
+		e = &loginFailureEntry{}
+		f.entries[username] = e
+	}
+	e.count++
+	if e.count >= f.maxFailures {
+		e.lockedUntil = now.Add(f.duration)
+		return true
+	}
+	return false
+}
+
+func (f *loginFailures) reset(username string) {
+	if !f.enabled() {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.entries, username)
+}
@@ -0,0 +1,88 @@
+package api_test
+
+import (
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/adapters/out/security"
+	"fs-access-api/internal/app/api"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Password policy (unit)", func() {
+	var (
+		repo  *accounts.InMemAccountRepository
+		apis  ports.ApiServer
+		alice ports.UserInfo
+	)
+
+	newApis := func(policy config.PasswordPolicyConfig) ports.ApiServer {
+		common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+		var err error
+		repo, err = accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10}, common, true)
+		Expect(err).NotTo(HaveOccurred())
+		hasher, err := security.NewDefaultHasher()
+		Expect(err).NotTo(HaveOccurred())
+		s, err := api.NewDefaultApiServer(config.StorageConfig{HomesBaseDir: "/homes"}, config.SecurityConfig{PasswordPolicy: policy},
+			common, hasher, repo, &flakyFsStorage{})
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = s.EnsureGroup(ports.GroupInfo{Groupname: "proj", GID: 3000, Home: "proj"})
+		Expect(err).NotTo(HaveOccurred())
+		return s
+	}
+
+	setPassword := func(password string, isHash bool) error {
+		return apis.UpdateUser("alice", func(u ports.UserInfo) (ports.UserInfo, error) {
+			u.Password, u.PasswordIsHash = password, isHash
+			return u, nil
+		})
+	}
+
+	Context("with forbid_username_in_password", func() {
+		BeforeEach(func() {
+			apis = newApis(config.PasswordPolicyConfig{ForbidUsernameInPassword: true})
+			alice = ports.UserInfo{Username: "alice", UID: 3001, Groupname: "proj", Home: "alice", Password: "alice"}
+		})
+
+		It("rejects a password equal to the username without storing the user", func() {
+			_, _, err := apis.EnsureUser(alice)
+			Expect(err).To(MatchError(ports.ErrWeakPassword))
+			Expect(err).To(MatchError(ports.ErrInvalidInput))
+			_, err = repo.GetUser("alice")
+			Expect(err).To(MatchError(ports.ErrNotFound))
+		})
+
+		It("rejects passwords trivially derived from the username", func() {
+			for _, password := range []string{"ALICE", "ecila", "Alice123!", "2024alice"} {
+				alice.Password = password
+				_, _, err := apis.EnsureUser(alice)
+				Expect(err).To(MatchError(ports.ErrWeakPassword), password)
+			}
+		})
+
+		It("accepts a distinct password and checks the password updates", func() {
+			alice.Password = "Corr3ct-Horse"
+			_, created, err := apis.EnsureUser(alice)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			Expect(setPassword("alice1", false)).To(MatchError(ports.ErrWeakPassword))
+			Expect(setPassword("Batt3ry-Staple", false)).To(Succeed())
+		})
+
+		It("doesn't apply to password hashes", func() {
+			alice.Password, alice.PasswordIsHash = "$5$x$y", true
+			_, _, err := apis.EnsureUser(alice)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	It("allows the username as password when the policy is off", func() {
+		apis = newApis(config.PasswordPolicyConfig{})
+		_, created, err := apis.EnsureUser(ports.UserInfo{Username: "alice", UID: 3001, Groupname: "proj", Home: "alice", Password: "alice"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+	})
+})
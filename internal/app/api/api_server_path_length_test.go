@@ -0,0 +1,104 @@
+package api_test
+
+import (
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/api"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Name and home path length limits (unit)", func() {
+	var (
+		repo  *accounts.InMemAccountRepository
+		fs    *flakyFsStorage
+		apis  ports.ApiServer
+		alice ports.UserInfo
+	)
+
+	BeforeEach(func() {
+		common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+		var err error
+		repo, err = accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10}, common, true)
+		Expect(err).NotTo(HaveOccurred())
+		fs = &flakyFsStorage{}
+		apis, err = api.NewDefaultApiServer(config.StorageConfig{HomesBaseDir: "/homes", MaxPathLength: 64}, config.SecurityConfig{}, common, nil, repo, fs)
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = apis.EnsureGroup(ports.GroupInfo{Groupname: "proj", GID: 3000, Home: "proj"})
+		Expect(err).NotTo(HaveOccurred())
+		alice = ports.UserInfo{Username: "alice", UID: 3001, Groupname: "proj", Home: "alice", Password: "$5$x$y", PasswordIsHash: true}
+	})
+
+	It("accepts a home under the limit", func() {
+		_, created, err := apis.EnsureUser(alice)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+		Expect(fs.calls).To(Equal(1))
+	})
+
+	It("rejects a home over the limit before storing the user or touching the filesystem", func() {
+		alice.Home = strings.Repeat("a", 64)
+		_, _, err := apis.EnsureUser(alice)
+		Expect(err).To(MatchError(ports.ErrInvalidInput))
+		Expect(err.Error()).To(ContainSubstring("storage.max_path_length is 64"))
+		Expect(fs.calls).To(BeZero())
+		_, err = repo.GetUser("alice")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+	})
+
+	It("rejects user and group names over 128 characters", func() {
+		alice.Username = strings.Repeat("u", 129)
+		_, _, err := apis.EnsureUser(alice)
+		Expect(err).To(MatchError(ContainSubstring("username has 129 characters")))
+
+		_, _, err = apis.EnsureGroup(ports.GroupInfo{Groupname: strings.Repeat("g", 129), GID: 3100, Home: "g"})
+		Expect(err).To(MatchError(ports.ErrInvalidInput))
+		Expect(err.Error()).To(ContainSubstring("groupname has 129 characters"))
+	})
+})
+
+var _ = Describe("Home depth limit (unit)", func() {
+	var (
+		repo *accounts.InMemAccountRepository
+		fs   *flakyFsStorage
+		apis ports.ApiServer
+	)
+
+	BeforeEach(func() {
+		common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+		var err error
+		repo, err = accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10}, common, true)
+		Expect(err).NotTo(HaveOccurred())
+		fs = &flakyFsStorage{}
+		apis, err = api.NewDefaultApiServer(config.StorageConfig{HomesBaseDir: "/homes", MaxHomeDepth: 2}, config.SecurityConfig{}, common, nil, repo, fs)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects a too deep group home before storing the group", func() {
+		_, _, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "proj", GID: 3000, Home: "a/b/c"})
+		Expect(err).To(MatchError(ports.ErrInvalidInput))
+		Expect(err.Error()).To(ContainSubstring("storage.max_home_depth is 2"))
+		_, err = repo.GetGroup("proj")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+	})
+
+	It("rejects a too deep user home before storing the user or touching the filesystem", func() {
+		_, _, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "proj", GID: 3000, Home: "a/b"})
+		Expect(err).NotTo(HaveOccurred())
+		alice := ports.UserInfo{Username: "alice", UID: 3001, Groupname: "proj", Home: "x/y/alice", Password: "$5$x$y", PasswordIsHash: true}
+		_, _, err = apis.EnsureUser(alice)
+		Expect(err).To(MatchError(ports.ErrInvalidInput))
+		Expect(err.Error()).To(ContainSubstring("storage.max_home_depth is 2"))
+		Expect(fs.calls).To(BeZero())
+		_, err = repo.GetUser("alice")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+
+		alice.Home = "x/alice"
+		_, created, err := apis.EnsureUser(alice)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+	})
+})
@@ -0,0 +1,117 @@
+//go:build unix
+
+package api_test
+
+import (
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/adapters/out/fs"
+	"fs-access-api/internal/app/api"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"math"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Group quotas (unit)", func() {
+	const homesBaseDir = "/srv/homes"
+	var fsm *fs.InMemFilesystemService
+
+	// newQuotaServer wires the API server over in-memory storage the test can fill with files.
+	newQuotaServer := func(enforce bool) *api.DefaultApiServer {
+		storageCfg := config.StorageConfig{
+			HomesBaseDir:       homesBaseDir,
+			CreateHomesBaseDir: true,
+			DefaultUserTopDirs: []config.TopDirConfig{{Name: "_test"}},
+			EnforceGroupQuotas: enforce,
+		}
+		common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+		var err error
+		fsm = fs.NewInMemFilesystemService()
+		storage, err := fs.NewDefaultFsStorageService(storageCfg, fsm, true)
+		Expect(err).NotTo(HaveOccurred())
+		repo, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10}, common, true)
+		Expect(err).NotTo(HaveOccurred())
+		apis, err := api.NewDefaultApiServer(storageCfg, config.SecurityConfig{}, common, nil, repo, storage)
+		Expect(err).NotTo(HaveOccurred())
+
+		group, _, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "proj", GID: 3000, Home: "proj", QuotaBytes: ptr(uint64(1000))})
+		Expect(err).NotTo(HaveOccurred())
+		for _, u := range []ports.UserInfo{
+			{Username: "alice", UID: 3001, Groupname: "proj", Home: "alice"},
+			{Username: "bob", UID: 3002, Groupname: "proj", Home: "bob"},
+		} {
+			_, err = repo.AddUser(u)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(storage.PrepareUserHome(u, group)).To(Succeed())
+		}
+		return apis
+	}
+
+	writeFile := func(user string, size int64) {
+		Expect(fsm.WriteFile(filepath.Join(homesBaseDir, "proj", user, "_test", "data.bin"), size)).To(Succeed())
+	}
+
+	It("lets members create directories while the group stays below its quota", func() {
+		apis := newQuotaServer(true)
+		writeFile("alice", 600)
+		writeFile("bob", 399)
+
+		created, err := apis.EnsureUserDir("bob", "reports")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+	})
+
+	It("rejects a new directory of any member once the group reached its quota", func() {
+		apis := newQuotaServer(true)
+		writeFile("alice", 600)
+		writeFile("bob", 400)
+
+		created, err := apis.EnsureUserDir("bob", "reports")
+		Expect(err).To(MatchError(ports.ErrQuotaExceeded))
+		Expect(created).To(BeFalse())
+		dirs, _, err := apis.ListUserDirs("bob")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dirs).NotTo(ContainElement("reports"))
+
+		By("still acknowledging directories that already exist")
+		created, err = apis.EnsureUserDir("alice", "_test")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeFalse())
+	})
+
+	It("ignores quotas unless enforcement is enabled", func() {
+		apis := newQuotaServer(false)
+		writeFile("alice", 5000)
+
+		created, err := apis.EnsureUserDir("bob", "reports")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+	})
+
+	It("treats a changed quota as a conflicting group", func() {
+		apis := newQuotaServer(true)
+		_, _, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "proj", GID: 3000, Home: "proj", QuotaBytes: ptr(uint64(2000))})
+		Expect(err).To(MatchError(ports.ErrConflict))
+	})
+
+	It("rejects a quota above math.MaxInt64 as invalid input", func() {
+		apis := newQuotaServer(true)
+		tooLarge := uint64(math.MaxInt64) + 1
+		_, _, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "huge", GID: 3100, Home: "huge", QuotaBytes: &tooLarge})
+		Expect(err).To(MatchError(ports.ErrInvalidInput))
+		Expect(err.Error()).To(ContainSubstring("quota_bytes"))
+
+		err = apis.UpdateGroup("proj", func(g ports.GroupInfo) (ports.GroupInfo, error) {
+			g.QuotaBytes = &tooLarge
+			return g, nil
+		})
+		Expect(err).To(MatchError(ports.ErrInvalidInput))
+
+		_, created, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "max", GID: 3101, Home: "max", QuotaBytes: ptr(uint64(math.MaxInt64))})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+	})
+})
@@ -0,0 +1,14 @@
+package api
+
+// ResolveHomePath exposes the server's home path resolution with the storage policies applied,
+// the configured homes base dir is used when none is given.
+func (s *DefaultApiServer) ResolveHomePath(homesBaseDir *string, groupHome, userHome string, topDir *string) (string, error) {
+	paths := s.paths
+	if homesBaseDir != nil {
+		paths.HomesBaseDir = *homesBaseDir
+	}
+	if topDir != nil {
+		return paths.TopDir(groupHome, userHome, *topDir)
+	}
+	return paths.UserHome(groupHome, userHome)
+}
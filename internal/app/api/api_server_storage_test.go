@@ -0,0 +1,78 @@
+package api_test
+
+import (
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Storage API (unit)", Ordered, func() {
+	var apis ports.ApiServer
+	base := ptr("/srv/homes")
+
+	BeforeAll(func() {
+		apis = newTestServerFromConfig(TestConfigPath)
+	})
+
+	It("ResolveHomePath: matches AbsoluteHomeDir", func() {
+		u := ports.UserInfo{Home: "alice"}
+		p, err := apis.ResolveHomePath(base, "grpA", "alice", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(Equal(u.AbsoluteHomeDir(*base, "grpA")))
+		Expect(p).To(Equal("/srv/homes/grpA/alice"))
+	})
+
+	It("ResolveHomePath: uses the configured homes base dir when omitted", func() {
+		p, err := apis.ResolveHomePath(nil, "grpA", "alice", ptr("_test"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filepath.IsAbs(p)).To(BeTrue())
+		Expect(p).To(HaveSuffix("/grpA/alice/_test"))
+	})
+
+	It("ResolveHomePath: normalizes relative segments that stay inside", func() {
+		p, err := apis.ResolveHomePath(base, "../homes/grpB", "../grpB/alice/../alice", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(Equal("/srv/homes/grpB/alice"))
+	})
+
+	DescribeTable("ResolveHomePath: rejects absolute and escaping paths",
+		func(groupHome, userHome string, topDir *string, msg string) {
+			_, err := apis.ResolveHomePath(base, groupHome, userHome, topDir)
+			Expect(err).To(MatchError(ports.ErrInvalidInput))
+			Expect(err.Error()).To(ContainSubstring(msg))
+		},
+		Entry("absolute group home", "/etc", "alice", nil, "absolute group home"),
+		Entry("group home escapes root", filepath.Join("..", "escape"), "alice", nil, " escapes "),
+		Entry("absolute user home", "grpA", "/etc", nil, "absolute user home"),
+		Entry("user home escapes group", "grpA", filepath.Join("..", "..", "escape"), nil, " escapes "),
+		Entry("absolute top dir", "grpA", "alice", ptr("/etc"), "absolute top dir"),
+		Entry("top dir escapes user home", "grpA", "alice", ptr("../../escape"), " escapes "),
+		Entry("nested top dir", "grpA", "alice", ptr("a/b"), "non-top-level"),
+	)
+})
+
+var _ = Describe("Storage API policies (unit)", func() {
+	base := ptr("/srv/homes")
+
+	DescribeTable("ResolveHomePath: applies the storage policies like the storage operations",
+		func(mutate func(cfg *config.StorageConfig), groupHome, userHome string, topDir *string, msg string) {
+			apis := newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) { mutate(&cfg.Storage) })
+			_, err := apis.ResolveHomePath(base, groupHome, userHome, topDir)
+			Expect(err).To(MatchError(ports.ErrInvalidInput))
+			Expect(err.Error()).To(ContainSubstring(msg))
+		},
+		Entry("require_user_home_subdir", func(cfg *config.StorageConfig) { cfg.RequireUserHomeSubdir = true },
+			"grpA", ".", nil, "resolves to the group home"),
+		Entry("max_home_depth of the group home", func(cfg *config.StorageConfig) { cfg.MaxHomeDepth = 1 },
+			"a/b", "alice", nil, "storage.max_home_depth is 1"),
+		Entry("max_home_depth of the user home", func(cfg *config.StorageConfig) { cfg.MaxHomeDepth = 1 },
+			"grpA", "x/alice", nil, "storage.max_home_depth is 1"),
+		Entry("max_path_length", func(cfg *config.StorageConfig) { cfg.MaxPathLength = 20 },
+			"grpA", "alice", nil, "storage.max_path_length is 20"),
+		Entry("top_dir_name_pattern", func(cfg *config.StorageConfig) { cfg.TopDirNamePattern = "^[a-z]+$" },
+			"grpA", "alice", ptr("Upper"), "Upper"),
+	)
+})
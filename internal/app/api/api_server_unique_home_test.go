@@ -0,0 +1,96 @@
+package api_test
+
+import (
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/api"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Globally unique user homes (unit)", func() {
+	var repo *accounts.InMemAccountRepository
+
+	// newServer seeds group "team" (home "shared") and group "ops" whose home is the "team" home
+	// of a member of "team", so a user of "ops" with home "." lands in that member's home
+	newServer := func(enforce bool) ports.ApiServer {
+		common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+		var err error
+		repo, err = accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10}, common, true)
+		Expect(err).NotTo(HaveOccurred())
+		storageCfg := config.StorageConfig{HomesBaseDir: "/homes", EnforceGloballyUniqueHome: enforce}
+		apis, err := api.NewDefaultApiServer(storageCfg, config.SecurityConfig{}, common, nil, repo, &flakyFsStorage{})
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = apis.EnsureGroup(ports.GroupInfo{Groupname: "team", GID: 3000, Home: "shared"})
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = apis.EnsureGroup(ports.GroupInfo{Groupname: "ops", GID: 3001, Home: "shared/alice"})
+		Expect(err).NotTo(HaveOccurred())
+		return apis
+	}
+	user := func(name, group, home string) ports.UserInfo {
+		return ports.UserInfo{Username: name, Groupname: group, Home: home, Password: "$5$x$y", PasswordIsHash: true}
+	}
+
+	It("rejects a user of another group resolving to an existing home -> conflict", func() {
+		apis := newServer(true)
+		_, _, err := apis.EnsureUser(user("alice", "team", "alice"))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = apis.EnsureUser(user("bob", "ops", "."))
+		Expect(err).To(MatchError(ports.ErrHomeTaken))
+		Expect(err.Error()).To(ContainSubstring(`already the home of user "alice"`))
+		_, err = repo.GetUser("bob")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+
+		_, created, err := apis.EnsureUser(user("alice", "team", "alice"))
+		Expect(err).NotTo(HaveOccurred(), "ensuring the owner again is idempotent")
+		Expect(created).To(BeFalse())
+	})
+
+	It("frees the home of a deleted user", func() {
+		apis := newServer(true)
+		_, _, err := apis.EnsureUser(user("alice", "team", "alice"))
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = apis.EnsureUser(user("bob", "ops", "."))
+		Expect(err).To(MatchError(ports.ErrHomeTaken))
+
+		Expect(apis.DeleteUser("alice")).To(Succeed())
+		_, created, err := apis.EnsureUser(user("bob", "ops", "."))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+	})
+
+	It("sees the users another instance added behind its back", func() {
+		apis := newServer(true)
+		_, _, err := apis.EnsureUser(user("carol", "team", "carol"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = repo.AddUser(ports.UserInfo{Username: "alice", UID: 4000, Groupname: "team", Home: "alice", Password: "x", PasswordIsHash: true})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = apis.EnsureUser(user("bob", "ops", "."))
+		Expect(err).To(MatchError(ports.ErrHomeTaken))
+	})
+
+	It("frees the home of a user another instance deleted behind its back", func() {
+		apis := newServer(true)
+		_, _, err := apis.EnsureUser(user("alice", "team", "alice"))
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = apis.EnsureUser(user("bob", "ops", "."))
+		Expect(err).To(MatchError(ports.ErrHomeTaken))
+		Expect(repo.DeleteUser("alice")).To(Succeed())
+
+		_, created, err := apis.EnsureUser(user("bob", "ops", "."))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+	})
+
+	It("allows the collision by default", func() {
+		apis := newServer(false)
+		_, _, err := apis.EnsureUser(user("alice", "team", "alice"))
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = apis.EnsureUser(user("bob", "ops", "."))
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -2,18 +2,48 @@ package api
 
 import (
 	"errors"
+	"fmt"
+	"fs-access-api/internal/app/config"
 	"fs-access-api/internal/app/ports"
+	"log"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+	"unicode"
 )
 
 func (s *DefaultApiServer) ListUsers() ([]ports.UserInfo, error) {
 	return s.accountRepo.ListUsers()
 }
 
+func (s *DefaultApiServer) ListUsersPage(offset, limit int, sort string) ([]ports.UserInfo, int, error) {
+	return s.accountRepo.ListUsersPage(offset, limit, sort)
+}
+
 func (s *DefaultApiServer) GetUser(username string) (ports.UserInfo, error) {
 	return s.accountRepo.GetUser(username)
 }
 
 func (s *DefaultApiServer) EnsureUser(ru ports.UserInfo) (pu ports.UserInfo, created bool, err error) {
+	if err = s.requireWritable(); err != nil {
+		return ports.UserInfo{}, false, err
+	}
+	if err = validateName("username", ru.Username); err != nil {
+		return ports.UserInfo{}, false, err
+	}
+	if err = validateName("groupname", ru.Groupname); err != nil {
+		return ports.UserInfo{}, false, err
+	}
+	if err = s.validateDescription(ru.Description); err != nil {
+		return ports.UserInfo{}, false, err
+	}
+	if err = validateEmail(ru.Email); err != nil {
+		return ports.UserInfo{}, false, err
+	}
+	if err = s.checkPrehashed(ru.PasswordIsHash); err != nil {
+		return ports.UserInfo{}, false, err
+	}
 	create := false
 	pu, err = s.GetUser(ru.Username)
 	if err != nil {
@@ -23,6 +53,7 @@ func (s *DefaultApiServer) EnsureUser(ru ports.UserInfo) (pu ports.UserInfo, cre
 			return pu, false, err
 		}
 	}
+	var userAdded bool
 	if create {
 		// Create
 		if ru.UID == 0 {
@@ -33,8 +64,29 @@ func (s *DefaultApiServer) EnsureUser(ru ports.UserInfo) (pu ports.UserInfo, cre
 			}
 			ru.UID = uid
 		}
+		var personalGroup bool
+		if personalGroup, err = s.ensurePersonalGroup(ru); err != nil {
+			return ports.UserInfo{}, false, err
+		}
+		if personalGroup {
+			// no transactions across repository calls: undo the group if the user isn't added
+			defer func() {
+				if err != nil && !userAdded {
+					s.undoPersonalGroup(ru.Groupname)
+				}
+			}()
+		}
+		if err = s.checkUIDGIDPolicy(ru); err != nil {
+			return ports.UserInfo{}, false, err
+		}
+		if err = s.checkUserHomePath(ru); err != nil {
+			return ports.UserInfo{}, false, err
+		}
+		if err = s.checkGloballyUniqueHome(ru); err != nil {
+			return ports.UserInfo{}, false, err
+		}
 		var hash string
-		hash, err = s.preparePassword(ru.Password, ru.PasswordIsHash)
+		hash, err = s.preparePassword(ru.Username, ru.Password, ru.PasswordIsHash)
 		if err != nil {
 			return ports.UserInfo{}, false, err
 		}
@@ -45,6 +97,8 @@ func (s *DefaultApiServer) EnsureUser(ru ports.UserInfo) (pu ports.UserInfo, cre
 		if err != nil {
 			return ports.UserInfo{}, false, err
 		}
+		userAdded = true
+		s.indexHome(pu)
 	} else {
 		// Idempotency check
 		ru.UID = pu.UID
@@ -59,13 +113,151 @@ func (s *DefaultApiServer) EnsureUser(ru ports.UserInfo) (pu ports.UserInfo, cre
 		return ports.UserInfo{}, false, err
 	}
 
-	if err = s.fs.PrepareUserHome(pu, group); err != nil {
+	prep, err := s.prepareUserHome(pu, group)
+	if err != nil {
+		if userAdded && s.storageCfg.RollbackOnHomeFailure {
+			if delErr := s.accountRepo.DeleteUser(pu.Username); delErr != nil {
+				return ports.UserInfo{}, false, fmt.Errorf("%w (rolling back the user failed: %v)", err, delErr)
+			}
+			userAdded = false // lets the personal group be undone too
+			s.homes.invalidate()
+		}
 		return ports.UserInfo{}, false, err
 	}
+	if !create && len(prep.CreatedDirs) > 0 {
+		log.Printf("User '%s' existed, recreated its missing home dirs: %s", pu.Username, strings.Join(prep.CreatedDirs, ", "))
+	}
 	return pu, create, nil
 }
 
+// prepareUserHome retries PrepareUserHomeDetailed on transient filesystem errors with exponential backoff,
+// the result of a retry also lists the directories created by the failed attempts.
+func (s *DefaultApiServer) prepareUserHome(user ports.UserInfo, group ports.GroupInfo) (ports.HomePrepResult, error) {
+	res, err := s.fs.PrepareUserHomeDetailed(user, group)
+	created := res.CreatedDirs
+	for attempt := 0; attempt < s.storageCfg.PrepareHomeRetries && isTransientFsError(err); attempt++ {
+		time.Sleep(s.storageCfg.PrepareHomeRetryDelay << attempt)
+		res, err = s.fs.PrepareUserHomeDetailed(user, group)
+		created = append(created, res.CreatedDirs...)
+	}
+	res.CreatedDirs = created
+	res.ExistingDirs = slices.DeleteFunc(res.ExistingDirs, func(dir string) bool { return slices.Contains(created, dir) })
+	return res, err
+}
+
+// isTransientFsError tells an error worth retrying: interrupted or would-block calls, busy resources, timeouts.
+func isTransientFsError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, syscall.EBUSY) {
+		return true
+	}
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) && temporary.Temporary() {
+		return true
+	}
+	var timeout interface{ Timeout() bool }
+	return errors.As(err, &timeout) && timeout.Timeout()
+}
+
+// ensurePersonalGroup creates the group named after the user (GID = UID, home = username)
+// if auto_create_personal_group is enabled and the group is missing; it reports whether it did.
+func (s *DefaultApiServer) ensurePersonalGroup(user ports.UserInfo) (bool, error) {
+	if !s.commonCfg.AutoCreatePersonalGroup || user.Groupname != user.Username {
+		return false, nil
+	}
+	_, err := s.accountRepo.GetGroup(user.Groupname)
+	if err == nil || !errors.Is(err, ports.ErrNotFound) {
+		return false, err
+	}
+	groups, err := s.accountRepo.ListGroups()
+	if err != nil {
+		return false, err
+	}
+	for _, g := range groups {
+		if g.GID == user.UID {
+			return false, ports.InvalidField("uid", "personal group %q needs GID %d, already used by group %q",
+				user.Groupname, user.UID, g.Groupname)
+		}
+	}
+	group := ports.GroupInfo{Groupname: user.Groupname, GID: user.UID, Home: user.Username}
+	if _, err = s.paths.GroupHome(group.Home); err != nil {
+		return false, fmt.Errorf("home of personal group %q: %w", group.Groupname, err)
+	}
+	if err = s.checkGroupHomeOverlap(group); err != nil {
+		return false, fmt.Errorf("personal group %q: %w", group.Groupname, err)
+	}
+	group, err = s.accountRepo.AddGroup(group)
+	if err != nil {
+		return false, err
+	}
+	if err = s.fs.PrepareGroupHome(group); err != nil {
+		_ = s.accountRepo.DeleteGroup(group.Groupname)
+		return false, err
+	}
+	return true, nil
+}
+
+// undoPersonalGroup removes a personal group ensurePersonalGroup just created with its still empty home,
+// a home that can't be removed is logged and the group is deleted anyway.
+func (s *DefaultApiServer) undoPersonalGroup(groupname string) {
+	group, err := s.accountRepo.GetGroup(groupname)
+	if err != nil {
+		return
+	}
+	if err = s.fs.RemoveGroupHome(group); err != nil {
+		log.Printf("Personal group '%s' rolled back, its home is kept: %v", groupname, err)
+	}
+	_ = s.accountRepo.DeleteGroup(groupname)
+}
+
+// checkUserHomePath rejects a home the storage policies refuse (storage.max_path_length,
+// storage.max_home_depth, ...) before the user is stored, PrepareUserHome would refuse it anyway.
+func (s *DefaultApiServer) checkUserHomePath(user ports.UserInfo) error {
+	group, err := s.accountRepo.GetGroup(user.Groupname)
+	if errors.Is(err, ports.ErrNotFound) {
+		return nil // reported when the home is prepared
+	}
+	if err != nil {
+		return err
+	}
+	if _, err = s.paths.UserHome(group.Home, user.Home); err != nil {
+		return fmt.Errorf("home of user %q: %w", user.Username, err)
+	}
+	return nil
+}
+
+// checkUIDGIDPolicy enforces the configured UID/GID alignment for a user about to be created.
+func (s *DefaultApiServer) checkUIDGIDPolicy(user ports.UserInfo) error {
+	if s.commonCfg.UIDGIDPolicy != config.UIDGIDPolicyRequirePersonalGroup {
+		return nil
+	}
+	group, err := s.accountRepo.GetGroup(user.Groupname)
+	if err != nil {
+		return err
+	}
+	if group.GID != user.UID {
+		return ports.InvalidField("uid", "uid_gid_policy requires a personal group: user %q has UID %d but group %q has GID %d",
+			user.Username, user.UID, group.Groupname, group.GID)
+	}
+	users, err := s.accountRepo.ListUsers()
+	if err != nil {
+		return err
+	}
+	for _, other := range users {
+		if other.Groupname == user.Groupname && other.Username != user.Username {
+			return ports.InvalidField("groupname", "uid_gid_policy requires a personal group: group %q already has member %q",
+				group.Groupname, other.Username)
+		}
+	}
+	return nil
+}
+
 func (s *DefaultApiServer) UpdateUser(username string, mutate func(obj ports.UserInfo) (ports.UserInfo, error)) error {
+	if err := s.requireWritable(); err != nil {
+		return err
+	}
 	pg, err := s.accountRepo.GetUser(username)
 	if err != nil {
 		return err
@@ -74,7 +266,17 @@ func (s *DefaultApiServer) UpdateUser(username string, mutate func(obj ports.Use
 	if err != nil {
 		return err
 	}
-	hash, err := s.preparePassword(mg.Password, mg.PasswordIsHash)
+	mg.Version = pg.Version // the write fails if the user changed since it was read
+	if err = s.validateDescription(mg.Description); err != nil {
+		return err
+	}
+	if err = validateEmail(mg.Email); err != nil {
+		return err
+	}
+	if err = s.checkPrehashed(mg.PasswordIsHash); err != nil {
+		return err
+	}
+	hash, err := s.preparePassword(mg.Username, mg.Password, mg.PasswordIsHash)
 	if err != nil {
 		return err
 	}
@@ -82,33 +284,140 @@ func (s *DefaultApiServer) UpdateUser(username string, mutate func(obj ports.Use
 	mg.PasswordIsHash = true
 
 	_, err = s.accountRepo.UpdateUser(mg)
+	if mg.Home != pg.Home || mg.Groupname != pg.Groupname {
+		s.homes.invalidate()
+	}
+	return err
+}
+
+// TouchUser re-prepares the user's home, creating the missing default top dirs, then refreshes its UpdatedAt.
+func (s *DefaultApiServer) TouchUser(username string) (ports.UserInfo, error) {
+	if err := s.requireWritable(); err != nil {
+		return ports.UserInfo{}, err
+	}
+	user, err := s.accountRepo.GetUser(username)
+	if err != nil {
+		return ports.UserInfo{}, err
+	}
+	group, err := s.accountRepo.GetGroup(user.Groupname)
+	if err != nil {
+		return ports.UserInfo{}, err
+	}
+	prep, err := s.prepareUserHome(user, group)
+	if err != nil {
+		return ports.UserInfo{}, err
+	}
+	if len(prep.CreatedDirs) > 0 {
+		log.Printf("User '%s' touched, recreated its missing home dirs: %s", username, strings.Join(prep.CreatedDirs, ", "))
+	}
+	return s.accountRepo.TouchUser(username)
+}
+
+// ExpireUser sets the user's expiration to now, locking it like any other expiration.
+// The caller names no version, so a concurrent change (ErrVersionMismatch) is retried once on the fresh user.
+func (s *DefaultApiServer) ExpireUser(username string) error {
+	expire := func(user ports.UserInfo) (ports.UserInfo, error) {
+		now := s.clock.Now().UTC()
+		user.Expiration = &now
+		return user, nil
+	}
+	err := s.UpdateUser(username, expire)
+	if errors.Is(err, ports.ErrVersionMismatch) {
+		err = s.UpdateUser(username, expire)
+	}
 	return err
 }
 
 func (s *DefaultApiServer) DeleteUser(username string) error {
+	if err := s.requireWritable(); err != nil {
+		return err
+	}
 	_, err := s.accountRepo.GetUser(username)
 	if err != nil {
 		return err
 	}
 	err = s.accountRepo.DeleteUser(username)
+	s.homes.invalidate()
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *DefaultApiServer) ListUserDirs(username string) (dirs []string, err error) {
+// DeleteUsers deletes every user matching the (non-empty) filter at once, then purges their homes
+// if asked to; homes failing to purge are reported, the users stay deleted. affected is the count
+// reported by the account repository.
+func (s *DefaultApiServer) DeleteUsers(filter ports.UserFilter, purge bool) (deleted []string, affected int, purgeFailed []string, err error) {
+	if err = s.requireWritable(); err != nil {
+		return nil, 0, nil, err
+	}
+	if filter.IsEmpty() {
+		return nil, 0, nil, ports.InvalidField("filter", "the filter must set at least one criterion")
+	}
+	users, err := s.accountRepo.ListUsers()
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	var matched []ports.UserInfo
+	deleted = []string{}
+	for _, u := range users {
+		if filter.Matches(u) {
+			matched = append(matched, u)
+			deleted = append(deleted, u.Username)
+		}
+	}
+	affected, err = s.accountRepo.DeleteUsers(deleted)
+	s.homes.invalidate()
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	purgeFailed = []string{}
+	if !purge {
+		return deleted, affected, purgeFailed, nil
+	}
+	groups := map[string]ports.GroupInfo{}
+	for _, u := range matched {
+		group, ok := groups[u.Groupname]
+		if !ok {
+			if group, err = s.accountRepo.GetGroup(u.Groupname); err != nil {
+				log.Printf("cannot purge home of deleted user %q: %v", u.Username, err)
+				purgeFailed = append(purgeFailed, u.Username)
+				continue
+			}
+			groups[u.Groupname] = group
+		}
+		if err = s.fs.PurgeUserHome(u, group); err != nil {
+			log.Printf("cannot purge home of deleted user %q: %v", u.Username, err)
+			purgeFailed = append(purgeFailed, u.Username)
+		}
+	}
+	return deleted, affected, purgeFailed, nil
+}
+
+func (s *DefaultApiServer) ListUserDirs(username string) (dirs []string, truncated bool, err error) {
 	fu, err := s.accountRepo.GetUser(username)
 	if err != nil {
-		return []string{}, err
+		return []string{}, false, err
 	}
 	fg, err := s.accountRepo.GetGroup(fu.Groupname)
 	if err != nil {
-		return []string{}, err
+		return []string{}, false, err
 	}
 	return s.fs.ListUserTopDirs(fu, fg)
 }
 
+func (s *DefaultApiServer) ListUserDirsDetailed(username string) (dirs []ports.DirInfo, truncated bool, err error) {
+	fu, err := s.accountRepo.GetUser(username)
+	if err != nil {
+		return []ports.DirInfo{}, false, err
+	}
+	fg, err := s.accountRepo.GetGroup(fu.Groupname)
+	if err != nil {
+		return []ports.DirInfo{}, false, err
+	}
+	return s.fs.ListUserTopDirsDetailed(fu, fg)
+}
+
 func (s *DefaultApiServer) DeleteUserDir(username string, dirname string) error {
 	fu, err := s.accountRepo.GetUser(username)
 	if err != nil {
@@ -122,6 +431,10 @@ func (s *DefaultApiServer) DeleteUserDir(username string, dirname string) error
 }
 
 func (s *DefaultApiServer) EnsureUserDir(username string, dirname string) (created bool, err error) {
+	// refused before touching the filesystem, CreateUserTopDir would refuse it anyway
+	if err = ports.CheckTopDirName(dirname, s.paths.TopDirName); err != nil {
+		return false, err
+	}
 	fu, err := s.accountRepo.GetUser(username)
 	if err != nil {
 		return false, err
@@ -130,7 +443,8 @@ func (s *DefaultApiServer) EnsureUserDir(username string, dirname string) (creat
 	if err != nil {
 		return false, err
 	}
-	dirs, err := s.fs.ListUserTopDirs(fu, fg)
+	// past storage.max_dir_entries an existing dirname may be missed and then reported as created
+	dirs, _, err := s.fs.ListUserTopDirs(fu, fg)
 	if err != nil {
 		return false, err
 	}
@@ -143,12 +457,32 @@ func (s *DefaultApiServer) EnsureUserDir(username string, dirname string) (creat
 			}
 		}
 	}
+	if !exists {
+		if err := s.checkGroupQuota(fg); err != nil {
+			return false, err
+		}
+	}
 	err = s.fs.CreateUserTopDir(fu, fg, dirname)
 	return !exists && err == nil, err
 }
 
+// checkGroupQuota refuses to let the group grow once its members' usage reached the group quota.
+func (s *DefaultApiServer) checkGroupQuota(group ports.GroupInfo) error {
+	if !s.storageCfg.EnforceGroupQuotas || group.QuotaBytes == nil {
+		return nil
+	}
+	usage, err := s.fs.GroupUsageBytes(group)
+	if err != nil {
+		return fmt.Errorf("cannot compute usage of group %q: %w", group.Groupname, err)
+	}
+	if usage >= *group.QuotaBytes {
+		return fmt.Errorf("%w: group %q uses %d of %d bytes", ports.ErrQuotaExceeded, group.Groupname, usage, *group.QuotaBytes)
+	}
+	return nil
+}
+
 func (s *DefaultApiServer) sameUserData(up, ur ports.UserInfo, reqPasswordIsHashed bool) bool {
-	if up.Username != ur.Username || up.Groupname != ur.Groupname || up.Home != ur.Home || up.Disabled != ur.Disabled {
+	if up.Username != ur.Username || up.Groupname != ur.Groupname || !sameHome(up.Home, ur.Home) || up.Disabled != ur.Disabled {
 		return false
 	}
 
@@ -166,6 +500,13 @@ func (s *DefaultApiServer) sameUserData(up, ur ports.UserInfo, reqPasswordIsHash
 		return false
 	}
 
+	if (up.Email == nil) != (ur.Email == nil) {
+		return false
+	}
+	if up.Email != nil && ur.Email != nil && *up.Email != *ur.Email {
+		return false
+	}
+
 	if reqPasswordIsHashed {
 		if up.Password != ur.Password {
 			return false
@@ -179,7 +520,7 @@ func (s *DefaultApiServer) sameUserData(up, ur ports.UserInfo, reqPasswordIsHash
 	return true
 }
 
-func (s *DefaultApiServer) preparePassword(password string, passwordIsHash bool) (string, error) {
+func (s *DefaultApiServer) preparePassword(username, password string, passwordIsHash bool) (string, error) {
 	// Password - handle both plain and hashed values
 	if password == "" {
 		return "", errors.New("password is required")
@@ -187,6 +528,47 @@ func (s *DefaultApiServer) preparePassword(password string, passwordIsHash bool)
 	if passwordIsHash {
 		return password, nil
 	} else {
+		if err := s.checkPasswordPolicy(username, password); err != nil {
+			return "", err
+		}
 		return s.hasher.DefaultHash(password)
 	}
 }
+
+// checkPasswordPolicy applies security.password_policy to a plaintext password.
+func (s *DefaultApiServer) checkPasswordPolicy(username, password string) error {
+	if s.securityCfg.PasswordPolicy.ForbidUsernameInPassword && derivedFromUsername(username, password) {
+		return &ports.FieldError{Field: "password", Reason: "the password must not be derived from the username", Err: ports.ErrWeakPassword}
+	}
+	return nil
+}
+
+// checkPrehashed applies security.require_prehashed_passwords.
+func (s *DefaultApiServer) checkPrehashed(passwordIsHash bool) error {
+	if s.securityCfg.RequirePrehashedPasswords && !passwordIsHash {
+		return fmt.Errorf("%w refused: hash it on the client or with POST /api/crypto/hash and send it with password_is_hash: true",
+			ports.ErrPlaintextPassword)
+	}
+	return nil
+}
+
+// derivedFromUsername reports whether the password is the username, ignoring case, reversed or wrapped
+// in non-letters (e.g. "Alice", "ecila", "alice123!").
+func derivedFromUsername(username, password string) bool {
+	u, p := strings.ToLower(username), strings.ToLower(password)
+	if p == u {
+		return true
+	}
+	notLetter := func(r rune) bool { return !unicode.IsLetter(r) }
+	u, p = strings.TrimFunc(u, notLetter), strings.TrimFunc(p, notLetter)
+	if u == "" {
+		return false
+	}
+	return p == u || p == reverse(u)
+}
+
+func reverse(s string) string {
+	r := []rune(s)
+	slices.Reverse(r)
+	return string(r)
+}
@@ -2,6 +2,9 @@ package api_test
 
 import (
 	"errors"
+	"fs-access-api/internal/adapters/out/accounts"
+	"fs-access-api/internal/app/api"
+	"fs-access-api/internal/app/config"
 	"fs-access-api/internal/app/ports"
 	"time"
 
@@ -171,7 +174,7 @@ var _ = Describe("Users API (unit)", Ordered, func() {
 	})
 
 	It("ListUserDirs -> not found", func() {
-		_, err := apis.ListUserDirs(user)
+		_, _, err := apis.ListUserDirs(user)
 		Expect(err).To(HaveOccurred())
 		Expect(errors.Is(err, ports.ErrNotFound)).To(BeTrue())
 	})
@@ -189,7 +192,7 @@ var _ = Describe("Users API (unit)", Ordered, func() {
 		Expect(u.Username).To(Equal(user))
 		Expect(created).To(BeTrue())
 
-		dirs, err := apis.ListUserDirs(user)
+		dirs, _, err := apis.ListUserDirs(user)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(dirs).To(ConsistOf("_test"))
 	})
@@ -200,15 +203,249 @@ var _ = Describe("Users API (unit)", Ordered, func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(created).To(BeTrue())
 
-		dirs, err := apis.ListUserDirs(user)
+		dirs, _, err := apis.ListUserDirs(user)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(dirs).To(ConsistOf("_test", dirName))
 
 		err = apis.DeleteUserDir(user, dirName)
 		Expect(err).NotTo(HaveOccurred())
-		dirs, err = apis.ListUserDirs(user)
+		dirs, _, err = apis.ListUserDirs(user)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(dirs).To(ConsistOf("_test"))
 
 	})
 })
+
+var _ = Describe("Users API uid_gid_policy=require_personal_group (unit)", Ordered, func() {
+	var apis ports.ApiServer
+
+	BeforeAll(func() {
+		apis = newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.AccountRepository.Common.UIDGIDPolicy = config.UIDGIDPolicyRequirePersonalGroup
+		})
+		_, _, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "alice", GID: 6001, Home: "alice"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	user := func(name string, uid uint32, group string) ports.UserInfo {
+		return ports.UserInfo{Username: name, UID: uid, Groupname: group, Home: ".", Password: "Secr3t!"}
+	}
+
+	It("accepts a user whose UID equals the GID of its own group", func() {
+		_, created, err := apis.EnsureUser(user("alice", 6001, "alice"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+	})
+
+	It("keeps EnsureUser idempotent for the compliant user", func() {
+		_, created, err := apis.EnsureUser(user("alice", 6001, "alice"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeFalse())
+	})
+
+	It("rejects a UID that differs from the group GID", func() {
+		_, _, err := apis.EnsureUser(user("bob", 6002, "default"))
+		Expect(err).To(MatchError(ports.ErrInvalidInput))
+		Expect(err.Error()).To(ContainSubstring("has GID"))
+	})
+
+	It("rejects a second member of a personal group", func() {
+		_, _, err := apis.EnsureUser(user("alice2", 6001, "alice"))
+		Expect(err).To(MatchError(ports.ErrInvalidInput))
+		Expect(err.Error()).To(ContainSubstring(`already has member "alice"`))
+	})
+})
+
+var _ = Describe("Users API auto_create_personal_group (unit)", Ordered, func() {
+	var apis ports.ApiServer
+
+	BeforeAll(func() {
+		apis = newTestServerFromConfigWith(TestConfigPath, func(cfg *config.ProgramConfig) {
+			cfg.AccountRepository.Common.AutoCreatePersonalGroup = true
+			cfg.AccountRepository.Common.UIDGIDPolicy = config.UIDGIDPolicyRequirePersonalGroup
+		})
+	})
+
+	user := func(name string, uid uint32) ports.UserInfo {
+		return ports.UserInfo{Username: name, UID: uid, Groupname: name, Home: ".", Password: "Secr3t!"}
+	}
+
+	It("provisions the personal group when creating the user", func() {
+		u, created, err := apis.EnsureUser(user("carol", 7001))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+
+		g, err := apis.GetGroup("carol")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(g.GID).To(Equal(u.UID))
+		Expect(g.Home).To(Equal("carol"))
+	})
+
+	It("keeps EnsureUser idempotent", func() {
+		_, created, err := apis.EnsureUser(user("carol", 7001))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeFalse())
+	})
+
+	It("rejects a GID collision and leaves nothing behind", func() {
+		_, _, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "taken", GID: 7002, Home: "taken"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = apis.EnsureUser(user("dave", 7002))
+		Expect(err).To(MatchError(ports.ErrInvalidInput))
+		Expect(err.Error()).To(ContainSubstring(`already used by group "taken"`))
+		_, err = apis.GetGroup("dave")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+	})
+
+	It("removes the provisioned group when the user can't be created", func() {
+		bad := user("erin", 7003)
+		bad.Password = ""
+		_, _, err := apis.EnsureUser(bad)
+		Expect(err).To(HaveOccurred())
+		_, err = apis.GetGroup("erin")
+		Expect(err).To(MatchError(ports.ErrNotFound))
+	})
+
+	It("doesn't create groups named differently than the user", func() {
+		u := user("frank", 7004)
+		u.Groupname = "missing"
+		_, _, err := apis.EnsureUser(u)
+		Expect(err).To(MatchError(ports.ErrNotFound))
+	})
+})
+
+var _ = Describe("Ensure idempotency with equivalent homes (unit)", Ordered, func() {
+	var apis ports.ApiServer
+
+	BeforeAll(func() {
+		apis = newTestServerFromConfig(TestConfigPath)
+	})
+
+	user := func(home string) ports.UserInfo {
+		return ports.UserInfo{Username: "norm", Groupname: "default", Home: home, Password: "Secr3t!"}
+	}
+
+	It("treats differently written user homes as the same", func() {
+		_, created, err := apis.EnsureUser(user("norm-home"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+
+		for _, home := range []string{"./norm-home", "norm-home/", "sub/../norm-home"} {
+			_, created, err = apis.EnsureUser(user(home))
+			Expect(err).NotTo(HaveOccurred(), home)
+			Expect(created).To(BeFalse())
+		}
+	})
+
+	It("still conflicts on a genuinely different user home", func() {
+		for _, home := range []string{"norm-home2", "norm-home/sub", "."} {
+			_, _, err := apis.EnsureUser(user(home))
+			Expect(err).To(MatchError(ports.ErrConflict), home)
+		}
+	})
+
+	It("treats differently written group homes as the same", func() {
+		_, created, err := apis.EnsureGroup(ports.GroupInfo{Groupname: "norm-group", GID: 4500, Home: "norm-group"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+
+		_, created, err = apis.EnsureGroup(ports.GroupInfo{Groupname: "norm-group", GID: 4500, Home: "./norm-group/"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeFalse())
+
+		_, _, err = apis.EnsureGroup(ports.GroupInfo{Groupname: "norm-group", GID: 4500, Home: "norm-group-2"})
+		Expect(err).To(MatchError(ports.ErrConflict))
+	})
+})
+
+var _ = Describe("Users API email (unit)", Ordered, func() {
+	var apis ports.ApiServer
+
+	BeforeAll(func() {
+		apis = newTestServerFromConfig(TestConfigPath)
+	})
+
+	user := func(email *string) ports.UserInfo {
+		return ports.UserInfo{Username: "mailed", Groupname: "default", Home: "mailed", Password: "Secr3t!", Email: email}
+	}
+
+	It("stores a valid email and conflicts on a different one", func() {
+		_, created, err := apis.EnsureUser(user(ptr("mailed@example.com")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(BeTrue())
+		u, err := apis.GetUser("mailed")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(u.Email).To(HaveValue(Equal("mailed@example.com")))
+
+		_, _, err = apis.EnsureUser(user(ptr("other@example.com")))
+		Expect(err).To(MatchError(ports.ErrConflict))
+	})
+
+	It("rejects malformed emails as invalid input", func() {
+		for _, email := range []string{"", "mailed", "mailed@example", "@example.com", "a b@example.com", "a@b@example.com"} {
+			err := apis.UpdateUser("mailed", func(u ports.UserInfo) (ports.UserInfo, error) {
+				u.Email = &email
+				return u, nil
+			})
+			Expect(err).To(MatchError(ports.ErrInvalidInput), email)
+		}
+	})
+
+	It("clears the email with nil", func() {
+		err := apis.UpdateUser("mailed", func(u ports.UserInfo) (ports.UserInfo, error) {
+			u.Email = nil
+			return u, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		u, err := apis.GetUser("mailed")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(u.Email).To(BeNil())
+	})
+})
+
+// racedUsersRepo fails the first `races` user writes like a concurrent edit would.
+type racedUsersRepo struct {
+	ports.AccountRepository
+	races *int
+}
+
+func (r racedUsersRepo) UpdateUser(user ports.UserInfo) (ports.UserInfo, error) {
+	if *r.races > 0 {
+		*r.races--
+		return ports.UserInfo{}, ports.ErrVersionMismatch
+	}
+	return r.AccountRepository.UpdateUser(user)
+}
+
+var _ = Describe("ExpireUser concurrent changes (unit)", func() {
+	var (
+		apis  ports.ApiServer
+		races int
+	)
+
+	BeforeEach(func() {
+		common := config.AccountRepositoryCommonConfig{MinUID: 2000, MinGID: 2000}
+		repo, err := accounts.NewInMemAccountRepository(config.AccountRepositoryInMemConfig{EntitiesLimit: 10}, common, true)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = repo.AddGroup(ports.GroupInfo{Groupname: "team", GID: 3000, Home: "team"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = repo.AddUser(ports.UserInfo{Username: "carol", UID: 3000, Groupname: "team", Password: "x", PasswordIsHash: true, Home: "carol"})
+		Expect(err).NotTo(HaveOccurred())
+		apis, err = api.NewDefaultApiServer(config.StorageConfig{HomesBaseDir: "/homes"}, config.SecurityConfig{}, common, nil, racedUsersRepo{repo, &races}, &flakyFsStorage{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("expires the fresh user after a single concurrent change", func() {
+		races = 1
+		Expect(apis.ExpireUser("carol")).To(Succeed())
+		u, err := apis.GetUser("carol")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(u.Expiration).NotTo(BeNil())
+	})
+
+	It("reports the version mismatch when the user keeps changing", func() {
+		races = 2
+		Expect(apis.ExpireUser("carol")).To(MatchError(ports.ErrVersionMismatch))
+	})
+})
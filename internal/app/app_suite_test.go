@@ -0,0 +1,13 @@
+package app_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestApp(t *testing.T) {
+	RegisterFailHandler(AbortSuite)
+	RunSpecs(t, "App Suite")
+}
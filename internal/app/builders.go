@@ -1,6 +1,10 @@
 package app
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"fs-access-api/internal/adapters/in/rest"
 	"fs-access-api/internal/adapters/in/rest/openapi"
@@ -13,19 +17,29 @@ import (
 	"fs-access-api/internal/app/ports"
 	"log"
 	"net/http"
-	"time"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
-func BuildApiServer(cfg *config.ProgramConfig, bootstrap bool) (ports.ApiServer, error) {
+// BuildApiServer wires the api server, loading the initial data at bootstrap until ctx is done;
+// ctx also stops retrying an account repository that isn't available yet.
+func BuildApiServer(ctx context.Context, cfg *config.ProgramConfig, bootstrap bool) (ports.ApiServer, error) {
 	hasher, err := security.NewDefaultHasherFromConfig(cfg.Security.Hasher)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create hasher: %v", err)
 	}
 
-	accountRepo, err := createAccountRepo(cfg, bootstrap)
+	var accountRepo ports.AccountRepository
+	err = retryStartup(ctx, cfg.AccountRepository.StartupRetries, cfg.AccountRepository.StartupBackoff, "account repository", func() (err error) {
+		accountRepo, err = createAccountRepo(cfg, bootstrap)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -40,13 +54,13 @@ func BuildApiServer(cfg *config.ProgramConfig, bootstrap bool) (ports.ApiServer,
 		return nil, fmt.Errorf("cannot create filesytem service: %v", err)
 	}
 
-	apiServer, err := api.NewDefaultApiServer(cfg.Storage, hasher, accountRepo, fsStorageService)
+	apiServer, err := api.NewDefaultApiServer(cfg.Storage, cfg.Security, cfg.AccountRepository.Common, hasher, accountRepo, fsStorageService)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create api server: %v", err)
 	}
 
 	if bootstrap && cfg.AccountRepository.LoadInitialData {
-		err = loadInitialData(apiServer, cfg)
+		err = loadInitialData(ctx, apiServer, cfg)
 		if err != nil {
 			return nil, fmt.Errorf("cannot load initial data: %v", err)
 		}
@@ -68,33 +82,58 @@ func CreateFilesystemService(implementation string) (ports.FilesystemService, er
 }
 
 func BuildRestServer(cfg *config.ProgramConfig, bootstrap bool, actionMetrics ports.ActionMetrics) (*rest.DefaultRestServer, error) {
-	apiServer, err := BuildApiServer(cfg, bootstrap)
+	apiServer, err := BuildApiServer(context.Background(), cfg, bootstrap)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create api server: %v", err)
 	}
+	return BuildRestServerFor(cfg, apiServer, actionMetrics)
+}
 
+// BuildRestServerFor serves an api server built by the caller, who keeps it for the background jobs.
+func BuildRestServerFor(cfg *config.ProgramConfig, apiServer ports.ApiServer, actionMetrics ports.ActionMetrics) (*rest.DefaultRestServer, error) {
 	authenticator, err := security.NewMultiAuthenticator(cfg.Security.Authenticator)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create Authenticator: %v", err)
 	}
 
-	restServer, err := rest.NewRestServer(cfg.HttpServer, apiServer, authenticator, actionMetrics)
+	accessPolicy := security.NewKeyAccessPolicy(cfg.Security.Authenticator, cfg.Security.RateLimit)
+
+	fingerprint, err := cfg.Fingerprint()
+	if err != nil {
+		return nil, fmt.Errorf("cannot fingerprint the config: %v", err)
+	}
+
+	restServer, err := rest.NewRestServer(cfg.HttpServer, apiServer, authenticator, accessPolicy, actionMetrics, fingerprint)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create rest server: %v", err)
 	}
 	return restServer, nil
 }
 
+// BuildResponseSigner returns the HMAC signer of security.sign_responses, nil when responses aren't signed.
+func BuildResponseSigner(cfg *config.ProgramConfig) (ports.ResponseSigner, error) {
+	if !cfg.Security.SignResponses {
+		return nil, nil
+	}
+	signer, err := security.NewHMACAuthenticator(cfg.Security.Authenticator)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create response signer: %v", err)
+	}
+	return signer, nil
+}
+
 func createAccountRepo(cfg *config.ProgramConfig, bootstrap bool) (accountRepo ports.AccountRepository, err error) {
+	// the SQL repositories initialize their schema at bootstrap
+	migrate := bootstrap || cfg.AccountRepository.AutoMigrate
 	switch cfg.AccountRepository.Type {
 	case "inmem":
 		accountRepo, err = accounts.NewInMemAccountRepository(cfg.AccountRepository.InMem, cfg.AccountRepository.Common, bootstrap)
 		break
 	case "sqlite":
-		accountRepo, err = accounts.NewSQLiteAccountRepository(cfg.AccountRepository.Sqlite, cfg.AccountRepository.Common, bootstrap)
+		accountRepo, err = accounts.NewSQLiteAccountRepository(cfg.AccountRepository.Sqlite, cfg.AccountRepository.Common, migrate)
 		break
 	case "mysql":
-		accountRepo, err = accounts.NewMySQLAccountRepository(cfg.AccountRepository.MySQL, cfg.AccountRepository.Common, bootstrap)
+		accountRepo, err = accounts.NewMySQLAccountRepository(cfg.AccountRepository.MySQL, cfg.AccountRepository.Common, migrate)
 		break
 	default:
 		return nil, fmt.Errorf("unsupported account repository type: %s", cfg.AccountRepository.Type)
@@ -102,6 +141,10 @@ func createAccountRepo(cfg *config.ProgramConfig, bootstrap bool) (accountRepo p
 	if err != nil {
 		return nil, fmt.Errorf("cannot create account repository with type '%s': %v", cfg.AccountRepository.Type, err)
 	}
+	if ttl := cfg.HttpServer.GetCacheTTL; ttl > 0 {
+		log.Printf("WARNING: http_server.get_cache_ttl (%s) caches the reads per instance, other instances' mutations stay unseen until it expires", ttl)
+		accountRepo = accounts.NewCachedAccountRepository(accountRepo, ttl)
+	}
 	info, err := accountRepo.GetInfo()
 	if err == nil {
 		log.Printf("Account repository info: %s", info)
@@ -111,12 +154,20 @@ func createAccountRepo(cfg *config.ProgramConfig, bootstrap bool) (accountRepo p
 	return accountRepo, nil
 }
 
-func loadInitialData(apiServer ports.ApiServer, cfg *config.ProgramConfig) (err error) {
+// loadInitialData ensures the configured groups one by one, then the users with
+// account_repository.initial_data.workers workers; it stops early when ctx is done.
+func loadInitialData(ctx context.Context, apiServer ports.ApiServer, cfg *config.ProgramConfig) (err error) {
 	log.Printf("Loading initial data...")
+	if err = checkInitialUserGroups(apiServer, cfg); err != nil {
+		return err
+	}
 	icr := 0
 	iex := 0
 	ier := 0
 	for name, entityInfo := range cfg.GetInitialGroups() {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
 		var created bool
 		_, created, err = apiServer.EnsureGroup(*entityInfo)
 		if err != nil {
@@ -133,85 +184,232 @@ func loadInitialData(apiServer ports.ApiServer, cfg *config.ProgramConfig) (err
 	}
 	log.Printf("Groups existed %d, loaded %d, errored: %d", iex, icr, ier)
 
-	icr = 0
-	iex = 0
-	ier = 0
-	for name, entityInfo := range cfg.GetInitialUsers() {
-		g, err := apiServer.GetGroup(entityInfo.Groupname)
-		if err != nil {
-			log.Printf("User '%s' can't be ensured because can't read his group, error: %v", name, err)
-			ier++
+	var parallel, serial []*ports.UserInfo
+	for _, entityInfo := range cfg.GetInitialUsers() {
+		if entityInfo.UID == 0 {
+			serial = append(serial, entityInfo)
+		} else {
+			parallel = append(parallel, entityInfo)
 		}
-		_, err = apiServer.GetUser(name)
-		var created bool
-		_, created, err = apiServer.EnsureUser(*entityInfo)
-		if err != nil {
-			log.Printf("User '%s' can't be ensured, error: %v", name, err)
-			ier++
+	}
+	var counters initialUserCounters
+	users := make(chan *ports.UserInfo)
+	var wg sync.WaitGroup
+	for range max(cfg.AccountRepository.InitialData.Workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for entityInfo := range users {
+				ensureInitialUser(apiServer, cfg, entityInfo, &counters)
+			}
+		}()
+	}
+feed:
+	for _, entityInfo := range parallel {
+		select {
+		case users <- entityInfo:
+		case <-ctx.Done():
+			break feed
 		}
-		if created {
-			log.Printf("User '%s' created, home: %s", name, entityInfo.AbsoluteHomeDir(cfg.Storage.HomesBaseDir, g.Home))
-			icr++
-		} else {
-			log.Printf("User '%s' already existed, home: %s", name, entityInfo.AbsoluteHomeDir(cfg.Storage.HomesBaseDir, g.Home))
-			iex++
+	}
+	close(users)
+	wg.Wait()
+	// users without a uid would race for the same next UID
+	for _, entityInfo := range serial {
+		if ctx.Err() != nil {
+			break
 		}
+		ensureInitialUser(apiServer, cfg, entityInfo, &counters)
+	}
+	log.Printf("Users existed %d, loaded %d, errored: %d", counters.existed.Load(), counters.created.Load(), counters.errored.Load())
+	return ctx.Err()
+}
+
+// checkInitialUserGroups fails when an initial user references a group that is neither
+// an initial group nor stored in the repository, before anything gets ensured.
+func checkInitialUserGroups(apiServer ports.ApiServer, cfg *config.ProgramConfig) error {
+	groups := cfg.GetInitialGroups()
+	var dangling []string
+	for name, entityInfo := range cfg.GetInitialUsers() {
+		if _, ok := groups[entityInfo.Groupname]; ok {
+			continue
+		}
+		_, err := apiServer.GetGroup(entityInfo.Groupname)
+		if errors.Is(err, ports.ErrNotFound) {
+			dangling = append(dangling, fmt.Sprintf("%s -> '%s'", name, entityInfo.Groupname))
+		} else if err != nil {
+			return fmt.Errorf("cannot read group '%s' of initial user '%s': %w", entityInfo.Groupname, name, err)
+		}
+	}
+	if len(dangling) > 0 {
+		slices.Sort(dangling)
+		return fmt.Errorf("initial users reference unknown groups: %s", strings.Join(dangling, ", "))
 	}
-	log.Printf("Users existed %d, loaded %d, errored: %d", iex, icr, ier)
 	return nil
 }
 
-func BuildRouter(server openapi.ServerInterface) *chi.Mux {
+type initialUserCounters struct {
+	created, existed, errored atomic.Int32
+}
+
+func ensureInitialUser(apiServer ports.ApiServer, cfg *config.ProgramConfig, entityInfo *ports.UserInfo, counters *initialUserCounters) {
+	name := entityInfo.Username
+	_, created, err := apiServer.EnsureUser(*entityInfo)
+	if err != nil {
+		log.Printf("User '%s' can't be ensured, error: %v", name, err)
+		counters.errored.Add(1)
+		return
+	}
+	home := "?"
+	if g, err := apiServer.GetGroup(entityInfo.Groupname); err == nil {
+		home = entityInfo.AbsoluteHomeDir(cfg.Storage.HomesBaseDir, g.Home)
+	}
+	if created {
+		log.Printf("User '%s' created, home: %s", name, home)
+		counters.created.Add(1)
+	} else {
+		log.Printf("User '%s' already existed, home: %s", name, home)
+		counters.existed.Add(1)
+	}
+}
+
+// BuildRouter mounts the API operations (wrapped with the given middlewares) and the docs/probe routes.
+func BuildRouter(cfg config.HttpServerConfig, server openapi.ServerInterface, middlewares ...openapi.MiddlewareFunc) *chi.Mux {
 	// Router CHI
 	r := chi.NewRouter()
 
 	// Standard middlewares: request correlation, real client IP, logging, recovery, and server-side request timeout
+	requestID := middleware.RequestID
+	if cfg.RequestIDHeader != "" {
+		requestID = requestIDFromHeader(cfg.RequestIDHeader)
+	}
+	logger := middleware.Logger
+	if cfg.AccessLog.Enabled {
+		logger = rest.AccessLog(cfg.AccessLog, os.Stdout)
+	}
 	r.Use(
-		middleware.RequestID,
+		requestID,
 		middleware.RealIP,
-		middleware.Logger,
+		logger,
 		middleware.Recoverer,
-		middleware.Timeout(60*time.Second),
+		middleware.Timeout(cfg.RequestTimeout),
 	)
+	r.MethodNotAllowed(rest.MethodNotAllowed(r))
 
-	_ = openapi.HandlerFromMux(server, r)
+	apiMiddlewares := append([]openapi.MiddlewareFunc{}, middlewares...)
+	if cfg.StrictContentType {
+		apiMiddlewares = append(apiMiddlewares, rest.RequireAcceptJSON)
+	}
+	_ = openapi.HandlerWithOptions(server, openapi.ChiServerOptions{BaseURL: cfg.BasePath, BaseRouter: r, Middlewares: apiMiddlewares})
 
 	// Health and readiness probes
-	r.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {
+	r.Get(cfg.ProbePath("/healthz"), func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	})
-	r.Get("/readyz", func(w http.ResponseWriter, r *http.Request) {
-		// Basic readiness check; extend with deeper dependencies if needed
-		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ready"))
-	})
+	if probe, ok := server.(rest.ReadinessProbe); ok {
+		r.Get(cfg.ProbePath("/readyz"), probe.Readyz)
+	} else {
+		r.Get(cfg.ProbePath("/readyz"), func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("ready"))
+		})
+	}
 
-	// Index page
-	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html; charset=utf-8")
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write(docs.IndexHTML)
-	})
+	// Index page, the docs link relative to it so it must be served with the trailing slash
+	r.Get(cfg.BasePath+"/", rootHandler(cfg))
+	if cfg.BasePath != "" {
+		r.Get(cfg.BasePath, func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, cfg.BasePath+"/", http.StatusMovedPermanently)
+		})
+	}
 	// ReDoc UI
-	r.Get("/docs/redoc", func(w http.ResponseWriter, r *http.Request) {
+	r.Get(cfg.BasePath+"/docs/redoc", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write(docs.RedocHTML)
 	})
 	// Swagger UI
-	r.Get("/docs/swagger", func(w http.ResponseWriter, r *http.Request) {
+	r.Get(cfg.BasePath+"/docs/swagger", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write(docs.SwaggerHTML)
 	})
 
 	// OpenAPI YAML
-	r.Get("/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+	r.Get(cfg.BasePath+"/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		spec, err := docs.OpenAPIYAMLWithServer(observedBaseURL(r, cfg.TrustForwardedHeaders) + cfg.BasePath)
+		if err != nil {
+			log.Printf("cannot rewrite openapi servers, serving the spec verbatim: %v", err)
+			spec = docs.OpenAPIYAML
+		}
 		w.Header().Set("Content-Type", "application/yaml; charset=utf-8")
-		_, _ = w.Write(docs.OpenAPIYAML)
+		_, _ = w.Write(spec)
 	})
 	return r
 }
+
+// requestIDFromHeader works like middleware.RequestID with a custom header: it keeps the id sent
+// by the client (or generates one) where the logger finds it, and echoes it on the response.
+func requestIDFromHeader(header string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(header)
+			if id == "" {
+				id = rand.Text()
+			}
+			w.Header().Set(header, id)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), middleware.RequestIDKey, id)))
+		})
+	}
+}
+
+func rootHandler(cfg config.HttpServerConfig) http.HandlerFunc {
+	switch cfg.RootResponse {
+	case config.RootResponseJSON:
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"service": cfg.Banner,
+				"openapi": cfg.BasePath + "/openapi.yaml",
+				"swagger": cfg.BasePath + "/docs/swagger",
+				"redoc":   cfg.BasePath + "/docs/redoc",
+				"health":  cfg.BasePath + "/api/health",
+			})
+		}
+	case config.RootResponseRedirect:
+		return func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, cfg.BasePath+"/docs/swagger", http.StatusFound)
+		}
+	case config.RootResponseNone:
+		return http.NotFound
+	default:
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(docs.IndexHTML)
+		}
+	}
+}
+
+// observedBaseURL returns the scheme+host the client used to reach the server.
+// X-Forwarded-Proto/Host are honored only when the proxy in front is trusted.
+func observedBaseURL(r *http.Request, trustForwarded bool) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	host := r.Host
+	if trustForwarded {
+		if p := r.Header.Get("X-Forwarded-Proto"); p != "" {
+			scheme = strings.ToLower(strings.TrimSpace(strings.Split(p, ",")[0]))
+		}
+		if h := r.Header.Get("X-Forwarded-Host"); h != "" {
+			host = strings.TrimSpace(strings.Split(h, ",")[0])
+		}
+	}
+	return scheme + "://" + host
+}
@@ -0,0 +1,345 @@
+package app_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"gopkg.in/yaml.v3"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildRouter", func() {
+	It("cuts off a handler exceeding the configured request timeout", func() {
+		r := app.BuildRouter(config.HttpServerConfig{RequestTimeout: 50 * time.Millisecond}, openapi.Unimplemented{})
+		r.Get("/slow", func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+				w.WriteHeader(http.StatusOK)
+			}
+		})
+
+		start := time.Now()
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+		Expect(rec.Code).To(Equal(http.StatusGatewayTimeout))
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+	})
+})
+
+var _ = Describe("BuildRouter request id header", func() {
+	// serve answers GET /api/health and returns the request id the API handlers saw
+	serve := func(header string, mutate func(r *http.Request)) (*httptest.ResponseRecorder, string) {
+		var seen string
+		capture := func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = middleware.GetReqID(r.Context())
+				next.ServeHTTP(w, r)
+			})
+		}
+		r := app.BuildRouter(config.HttpServerConfig{RequestTimeout: time.Second, RequestIDHeader: header}, openapi.Unimplemented{}, capture)
+		req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+		mutate(req)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec, seen
+	}
+
+	It("propagates the correlation id sent by the client to the response", func() {
+		rec, seen := serve("X-Correlation-ID", func(r *http.Request) { r.Header.Set("X-Correlation-ID", "corr-123") })
+		Expect(rec.Header().Get("X-Correlation-ID")).To(Equal("corr-123"))
+		Expect(seen).To(Equal("corr-123"))
+	})
+
+	It("generates one when the client sends none", func() {
+		rec, seen := serve("X-Correlation-ID", func(*http.Request) {})
+		Expect(rec.Header().Get("X-Correlation-ID")).NotTo(BeEmpty())
+		Expect(seen).To(Equal(rec.Header().Get("X-Correlation-ID")))
+	})
+
+	It("keeps chi's X-Request-Id when not configured", func() {
+		rec, seen := serve("", func(r *http.Request) { r.Header.Set("X-Request-Id", "req-1") })
+		Expect(seen).To(Equal("req-1"))
+		Expect(rec.Header().Get("X-Correlation-ID")).To(BeEmpty())
+	})
+})
+
+var _ = Describe("OpenAPI spec endpoint", func() {
+	serverURLs := func(body []byte) []string {
+		var spec struct {
+			Servers []struct {
+				URL string `yaml:"url"`
+			} `yaml:"servers"`
+			Paths map[string]any `yaml:"paths"`
+		}
+		Expect(yaml.Unmarshal(body, &spec)).To(Succeed())
+		Expect(spec.Paths).NotTo(BeEmpty())
+		var out []string
+		for _, s := range spec.Servers {
+			out = append(out, s.URL)
+		}
+		return out
+	}
+
+	get := func(trust bool, mutate func(r *http.Request)) *httptest.ResponseRecorder {
+		r := app.BuildRouter(config.HttpServerConfig{RequestTimeout: time.Second, TrustForwardedHeaders: trust}, openapi.Unimplemented{})
+		req := httptest.NewRequest(http.MethodGet, "http://api.internal:8080/openapi.yaml", nil)
+		mutate(req)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		return rec
+	}
+
+	It("reflects the request host in the servers block", func() {
+		rec := get(false, func(r *http.Request) {})
+		Expect(serverURLs(rec.Body.Bytes())).To(Equal([]string{"http://api.internal:8080"}))
+	})
+
+	It("honors X-Forwarded-* when trusted", func() {
+		rec := get(true, func(r *http.Request) {
+			r.Header.Set("X-Forwarded-Proto", "https")
+			r.Header.Set("X-Forwarded-Host", "fsaa.example.org")
+		})
+		Expect(serverURLs(rec.Body.Bytes())).To(Equal([]string{"https://fsaa.example.org"}))
+	})
+
+	It("ignores X-Forwarded-* when not trusted", func() {
+		rec := get(false, func(r *http.Request) {
+			r.Header.Set("X-Forwarded-Proto", "https")
+			r.Header.Set("X-Forwarded-Host", "evil.example.org")
+		})
+		Expect(serverURLs(rec.Body.Bytes())).To(Equal([]string{"http://api.internal:8080"}))
+	})
+})
+
+var _ = Describe("Root response", func() {
+	get := func(mode string) *httptest.ResponseRecorder {
+		r := app.BuildRouter(config.HttpServerConfig{RequestTimeout: time.Second, Banner: "fsaa", RootResponse: mode}, openapi.Unimplemented{})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		return rec
+	}
+
+	It("html serves the landing page", func() {
+		rec := get(config.RootResponseHTML)
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Content-Type")).To(HavePrefix("text/html"))
+	})
+
+	It("json serves a service descriptor", func() {
+		rec := get(config.RootResponseJSON)
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Content-Type")).To(Equal("application/json"))
+		var body map[string]string
+		Expect(json.Unmarshal(rec.Body.Bytes(), &body)).To(Succeed())
+		Expect(body).To(HaveKeyWithValue("service", "fsaa"))
+		Expect(body).To(HaveKeyWithValue("openapi", "/openapi.yaml"))
+	})
+
+	It("redirect points to the Swagger UI", func() {
+		rec := get(config.RootResponseRedirect)
+		Expect(rec.Code).To(Equal(http.StatusFound))
+		Expect(rec.Header().Get("Location")).To(Equal("/docs/swagger"))
+	})
+
+	It("none returns 404", func() {
+		rec := get(config.RootResponseNone)
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+})
+
+var _ = Describe("Base path", func() {
+	serve := func(cfg config.HttpServerConfig, target string) *httptest.ResponseRecorder {
+		cfg.RequestTimeout = time.Second
+		r := app.BuildRouter(cfg, openapi.Unimplemented{})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	It("advertises the prefixed server in the spec", func() {
+		rec := serve(config.HttpServerConfig{BasePath: "/fsaa/v1"}, "http://api.internal:8080/fsaa/v1/openapi.yaml")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		var spec struct {
+			Servers []struct {
+				URL string `yaml:"url"`
+			} `yaml:"servers"`
+		}
+		Expect(yaml.Unmarshal(rec.Body.Bytes(), &spec)).To(Succeed())
+		Expect(spec.Servers).To(HaveLen(1))
+		Expect(spec.Servers[0].URL).To(Equal("http://api.internal:8080/fsaa/v1"))
+	})
+
+	It("redirects the bare prefix to the index page", func() {
+		rec := serve(config.HttpServerConfig{BasePath: "/fsaa/v1"}, "/fsaa/v1")
+		Expect(rec.Code).To(Equal(http.StatusMovedPermanently))
+		Expect(rec.Header().Get("Location")).To(Equal("/fsaa/v1/"))
+	})
+
+	It("prefixes the JSON root descriptor links", func() {
+		rec := serve(config.HttpServerConfig{BasePath: "/fsaa/v1", RootResponse: config.RootResponseJSON}, "/fsaa/v1/")
+		var body map[string]string
+		Expect(json.Unmarshal(rec.Body.Bytes(), &body)).To(Succeed())
+		Expect(body).To(HaveKeyWithValue("swagger", "/fsaa/v1/docs/swagger"))
+	})
+
+	DescribeTable("mounts the probes at the root unless prefix_probes is set",
+		func(prefixProbes bool, target string, status int) {
+			rec := serve(config.HttpServerConfig{BasePath: "/fsaa/v1", PrefixProbes: prefixProbes}, target)
+			Expect(rec.Code).To(Equal(status))
+		},
+		Entry("root probe", false, "/healthz", http.StatusOK),
+		Entry("prefixed probe not mounted", false, "/fsaa/v1/healthz", http.StatusNotFound),
+		Entry("prefixed probe", true, "/fsaa/v1/readyz", http.StatusOK),
+		Entry("root probe not mounted", true, "/readyz", http.StatusNotFound),
+	)
+})
+
+var _ = Describe("Strict content type", func() {
+	get := func(strict bool, accept string) int {
+		r := app.BuildRouter(config.HttpServerConfig{RequestTimeout: time.Second, StrictContentType: strict}, openapi.Unimplemented{})
+		req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	DescribeTable("negotiates the Accept header of API operations",
+		func(strict bool, accept string, status int) {
+			Expect(get(strict, accept)).To(Equal(status))
+		},
+		// openapi.Unimplemented answers 501 once a request gets through
+		Entry("lenient ignores Accept", false, "text/html", http.StatusNotImplemented),
+		Entry("strict, no Accept", true, "", http.StatusNotImplemented),
+		Entry("strict, application/json", true, "application/json", http.StatusNotImplemented),
+		Entry("strict, wildcard", true, "text/html, */*;q=0.1", http.StatusNotImplemented),
+		Entry("strict, text/html", true, "text/html", http.StatusNotAcceptable),
+		Entry("strict, json refused with q=0", true, "application/json;q=0", http.StatusNotAcceptable),
+	)
+
+	It("leaves non-API routes alone", func() {
+		r := app.BuildRouter(config.HttpServerConfig{RequestTimeout: time.Second, StrictContentType: true}, openapi.Unimplemented{})
+		req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		req.Header.Set("Accept", "text/plain")
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		Expect(rec.Code).To(Equal(http.StatusOK))
+	})
+})
+
+var _ = Describe("BuildApiServer initial data", func() {
+	// seededConfig bootstraps a fresh sqlite repository with 3 groups and 60 users, 5 of them without a uid
+	seededConfig := func(workers int) *config.ProgramConfig {
+		tmp := GinkgoT().TempDir()
+		cfg, err := config.LoadConfigString(fmt.Sprintf(`
+storage: { implementation: inmem, homes_base_dir: %[1]s/homes, create_homes_base_dir: true, default_user_top_dirs: [ _test ] }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository:
+  type: sqlite
+  sqlite: { db_file_path: %[1]s/db/test.db, create_db_dir: true }
+  load_initial_data: true
+http_server: {}
+`, tmp))
+		Expect(err).NotTo(HaveOccurred())
+		data := &cfg.AccountRepository.InitialData
+		data.Workers = workers
+		data.Groups = map[string]ports.GroupInfo{}
+		data.Users = map[string]ports.UserInfo{}
+		for g := range 3 {
+			data.Groups[fmt.Sprintf("group-%d", g)] = ports.GroupInfo{GID: uint32(4000 + g), Home: fmt.Sprintf("g%d", g)}
+		}
+		for u := range 60 {
+			user := ports.UserInfo{Groupname: fmt.Sprintf("group-%d", u%3), Password: "$5$salt$hash", PasswordIsHash: true, Home: fmt.Sprintf("u%d", u)}
+			if u >= 5 {
+				user.UID = uint32(3000 + u)
+			}
+			data.Users[fmt.Sprintf("user-%02d", u)] = user
+		}
+		return cfg
+	}
+
+	// state lists the seeded accounts without their timestamps
+	state := func(apis ports.ApiServer) ([]ports.GroupInfo, []ports.UserInfo) {
+		groups, err := apis.ListGroups()
+		Expect(err).NotTo(HaveOccurred())
+		users, err := apis.ListUsers()
+		Expect(err).NotTo(HaveOccurred())
+		uids := map[uint32]bool{}
+		for i := range users {
+			Expect(uids).NotTo(HaveKey(users[i].UID))
+			uids[users[i].UID] = true
+			users[i].UpdatedAt = nil
+			if users[i].UID >= 3060 {
+				users[i].UID = 0 // allocated after the highest seeded uid, in map order
+			}
+		}
+		slices.SortFunc(users, func(a, b ports.UserInfo) int { return strings.Compare(a.Username, b.Username) })
+		return groups, users
+	}
+
+	It("seeds the same accounts with parallel workers as sequentially", func() {
+		sequential, err := app.BuildApiServer(context.Background(), seededConfig(1), true)
+		Expect(err).NotTo(HaveOccurred())
+		parallel, err := app.BuildApiServer(context.Background(), seededConfig(8), true)
+		Expect(err).NotTo(HaveOccurred())
+
+		seqGroups, seqUsers := state(sequential)
+		parGroups, parUsers := state(parallel)
+		Expect(seqUsers).To(HaveLen(60))
+		Expect(parGroups).To(Equal(seqGroups))
+		Expect(parUsers).To(Equal(seqUsers))
+	})
+
+	It("refuses an initial user of an unknown group before ensuring anything", func() {
+		cfg := seededConfig(2)
+		user := cfg.AccountRepository.InitialData.Users["user-07"]
+		user.Groupname = "group-typo"
+		cfg.AccountRepository.InitialData.Users["user-07"] = user
+		_, err := app.BuildApiServer(context.Background(), cfg, true)
+		Expect(err).To(MatchError(ContainSubstring("initial users reference unknown groups: user-07 -> 'group-typo'")))
+
+		cfg.AccountRepository.LoadInitialData = false
+		apis, err := app.BuildApiServer(context.Background(), cfg, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apis.ListGroups()).To(BeEmpty())
+	})
+
+	It("accepts an initial user of a group already stored in the repository", func() {
+		cfg := seededConfig(2)
+		_, err := app.BuildApiServer(context.Background(), cfg, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		cfg.AccountRepository.InitialData.Groups = nil
+		cfg.AccountRepository.InitialData.Users = map[string]ports.UserInfo{
+			"late": {Groupname: "group-1", Password: "$5$salt$hash", PasswordIsHash: true, Home: "late"},
+		}
+		apis, err := app.BuildApiServer(context.Background(), cfg, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apis.GetUser("late")).To(HaveField("Groupname", "group-1"))
+	})
+
+	It("stops loading when the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := app.BuildApiServer(ctx, seededConfig(4), true)
+		Expect(err).To(MatchError(ContainSubstring(context.Canceled.Error())))
+	})
+})
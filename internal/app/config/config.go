@@ -1,11 +1,18 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"fs-access-api/internal/app/ports"
+	"io/fs"
 	"log"
 	"os"
+	"path"
 	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/mcuadros/go-defaults"
@@ -23,35 +30,344 @@ type ProgramConfig struct {
 type MetricsContext struct {
 	Namespace   string `yaml:"namespace" default:"fsaa"`
 	Environment string `yaml:"environment"`
+	// TraceExemplars attaches the trace_id of requests carrying a W3C traceparent header as exemplars
+	// to the authz action durations, they're exposed in the OpenMetrics format only.
+	TraceExemplars bool `yaml:"trace_exemplars" default:"false"`
+	// ActionDurationBuckets are the upper bounds (seconds) of the authz action duration histogram.
+	ActionDurationBuckets []float64 `yaml:"action_duration_buckets" default:"[0.010,0.100,0.500,1.0,3.0,5.0,10.0]"`
 }
 type StorageConfig struct {
-	Implementation     string   `yaml:"implementation" default:"unix"`
-	HomesBaseDir       string   `yaml:"homes_base_dir"`
-	CreateHomesBaseDir bool     `yaml:"create_homes_base_dir" default:"false"`
-	DefaultUserTopDirs []string `yaml:"default_user_top_dirs" default:"[_test]"`
+	Implementation     string `yaml:"implementation" default:"unix"`
+	HomesBaseDir       string `yaml:"homes_base_dir"`
+	CreateHomesBaseDir bool   `yaml:"create_homes_base_dir" default:"false"`
+	// CreateHomesBaseDirAlways creates a missing HomesBaseDir on every start, not only when bootstrapping,
+	// for replicas starting on a fresh (e.g. empty mounted) volume.
+	CreateHomesBaseDirAlways bool `yaml:"create_homes_base_dir_always" default:"false"`
+	// DefaultUserTopDirs are created in every prepared user home, [_test] when the key is absent;
+	// an explicit empty list prepares bare homes.
+	DefaultUserTopDirs []TopDirConfig `yaml:"default_user_top_dirs"`
+	// RequireUserHomeSubdir rejects user homes resolving to the group home itself (e.g. "."),
+	// so users of a group can't end up sharing one directory.
+	RequireUserHomeSubdir bool `yaml:"require_user_home_subdir" default:"false"`
+	// EnforceGroupQuotas rejects new user directories once the group usage reached its quota_bytes,
+	// off by default since the usage is aggregated by walking the whole group home.
+	EnforceGroupQuotas bool `yaml:"enforce_group_quotas" default:"false"`
+	// EnforceNonOverlappingGroupHomes rejects (conflict) a new group whose home is, contains or lies within
+	// the home of an existing group, off by default since some setups nest group homes on purpose.
+	EnforceNonOverlappingGroupHomes bool `yaml:"enforce_non_overlapping_group_homes" default:"false"`
+	// EnforceGloballyUniqueHome rejects (conflict) a new user whose absolute home is already the home of
+	// another user, in any group. Off by default: the homes of all users are resolved into a per instance
+	// index, whose hits are confirmed against the repository and which is rebuilt on every miss, so the
+	// users written by other instances sharing the database are seen too.
+	EnforceGloballyUniqueHome bool `yaml:"enforce_globally_unique_home" default:"false"`
+	// EnforceModeOnEnsure re-applies the owner and mode of an existing user home and its default top dirs
+	// whenever the user is ensured (or touched), correcting drift. On when absent, as homes always were;
+	// false leaves existing dirs as they are so sites can loosen their permissions.
+	EnforceModeOnEnsure *bool `yaml:"enforce_mode_on_ensure"`
+	// PrepareHomeRetries retries a user home preparation failing with a transient filesystem error
+	// (EAGAIN, EINTR, EBUSY, timeouts...), waiting PrepareHomeRetryDelay doubled on each attempt.
+	PrepareHomeRetries    int           `yaml:"prepare_home_retries" default:"2"`
+	PrepareHomeRetryDelay time.Duration `yaml:"prepare_home_retry_delay" default:"100ms"`
+	// RollbackOnHomeFailure deletes a user just created by EnsureUser when its home can't be prepared,
+	// otherwise the user is kept and a later ensure retries the home.
+	RollbackOnHomeFailure bool `yaml:"rollback_on_home_failure" default:"false"`
+	// MaxPathLength bounds the resolved absolute paths of homes and top dirs (PATH_MAX counts the NUL),
+	// longer ones are rejected before reaching the filesystem.
+	MaxPathLength int `yaml:"max_path_length" default:"4096"`
+	// MaxHomeDepth bounds the path segments of a group home and of a user home (counted within its group
+	// home), keeping the layout flat. Zero means unlimited.
+	MaxHomeDepth int `yaml:"max_home_depth" default:"0"`
+	// MaxDirEntries caps the entries read from a user home to list its top dirs, a longer listing is cut
+	// and flagged as truncated instead of loading a pathological home into memory. Zero means unlimited.
+	MaxDirEntries int `yaml:"max_dir_entries" default:"0"`
+	// MinFreeBytes and MinFreeInodes refuse new user top dirs once the filesystem of the homes base dir
+	// has less free space or inodes left (507), so a full volume fails clearly. Zero disables a check.
+	MinFreeBytes  uint64 `yaml:"min_free_bytes" default:"0"`
+	MinFreeInodes uint64 `yaml:"min_free_inodes" default:"0"`
+	// TopDirNamePattern restricts the names of the user top dirs created through the API (and the default ones),
+	// "." and ".." are always refused.
+	TopDirNamePattern string `yaml:"top_dir_name_pattern" default:"^[A-Za-z0-9_.-]{1,64}$"`
+	// HomeDriftCheck periodically compares the user homes with the owner and mode they were prepared with.
+	HomeDriftCheck HomeDriftCheckConfig `yaml:"home_drift_check"`
+}
+
+type HomeDriftCheckConfig struct {
+	// Interval between the checks, zero disables the background checker.
+	Interval time.Duration `yaml:"interval" default:"0s"`
+	// UserPause is waited between two user homes, so networked storage isn't hammered by a burst of stats.
+	UserPause time.Duration `yaml:"user_pause" default:"10ms"`
 }
 
 type HttpServerConfig struct {
 	Banner         string `yaml:"banner" default:"ProFTPD Admin API"`
 	ListenAddress  string `yaml:"listen_address" default:":8080"`
 	UnixSocketPath string `yaml:"unix_socket_path"`
-	TelemetryPath  string `yaml:"telemetry_path" default:"/metrics"`
+	// DisableTCP serves the Unix socket only, listen_address is ignored (its default is re-applied when blanked).
+	DisableTCP    bool   `yaml:"disable_tcp" default:"false"`
+	TelemetryPath string `yaml:"telemetry_path" default:"/metrics"`
+	// RequestTimeout bounds handler execution, the server write timeout is derived from it.
+	RequestTimeout time.Duration `yaml:"request_timeout" default:"60s"`
+	// TrustForwardedHeaders honors X-Forwarded-Proto/Host, enable only behind a trusted proxy.
+	TrustForwardedHeaders bool `yaml:"trust_forwarded_headers" default:"false"`
+	// RootResponse controls `GET /`: html, json, redirect (to the Swagger UI) or none (404).
+	RootResponse string `yaml:"root_response" default:"html"`
+	// StrictContentType requires exactly `application/json` (optionally `charset=utf-8`) request bodies
+	// and answers 406 when the client's Accept header doesn't allow JSON.
+	StrictContentType bool `yaml:"strict_content_type" default:"false"`
+	// ReturnWarnings adds non-fatal advisories (e.g. a short password) to ensure user/group responses.
+	ReturnWarnings bool `yaml:"return_warnings" default:"false"`
+	// DefaultPageSize applies to paginated list endpoints when the client sends no `limit`,
+	// MaxPageSize is the hard cap a requested `limit` gets clamped to.
+	DefaultPageSize int `yaml:"default_page_size" default:"100"`
+	MaxPageSize     int `yaml:"max_page_size" default:"1000"`
+	// MaxBatchSize caps the items of a batch request such as `POST /api/users:batch`, larger ones answer 422.
+	MaxBatchSize int `yaml:"max_batch_size" default:"1000"`
+	// GetCacheTTL enables `Cache-Control: max-age` on successful GETs and a server-side cache of
+	// user/group/authz reads; mutations invalidate it and send `no-cache` for one TTL on the resources
+	// they change. 0 disables both. Meant for a single instance: each instance caches on its own and
+	// never sees the mutations made through the others, which it keeps serving (authz included) until
+	// the TTL expires.
+	GetCacheTTL time.Duration `yaml:"get_cache_ttl" default:"0s"`
+	// BasePath mounts the API and its docs under a prefix such as `/fsaa/v1` (empty mounts them at `/`).
+	// HMAC signatures cover the full escaped request path, so clients must sign it including the prefix.
+	// A gateway stripping the prefix before forwarding breaks those signatures, forward the path as is.
+	BasePath string `yaml:"base_path"`
+	// PrefixProbes also mounts /healthz, /readyz and the telemetry path under BasePath, they stay at the root otherwise.
+	PrefixProbes bool `yaml:"prefix_probes" default:"false"`
+	// ReadOnly puts the API in maintenance: mutations answer 503 pointing at `GET /api/status`, reads keep working.
+	ReadOnly bool `yaml:"read_only" default:"false"`
+	// MaintenanceMessage is reported by `GET /api/status` and in the 503 bodies while ReadOnly is set.
+	MaintenanceMessage string `yaml:"maintenance_message"`
+	// RetryAfter is sent (in whole seconds) as the Retry-After of the 503 answers: maintenance, startup,
+	// failed health and readiness checks; rate limited 429s carry the time until the next token instead.
+	RetryAfter time.Duration `yaml:"retry_after" default:"5s"`
+	// RequestIDHeader replaces chi's X-Request-Id (e.g. with X-Correlation-ID): the id is taken from
+	// that request header or generated, logged with the request and echoed on the response.
+	RequestIDHeader string `yaml:"request_id_header"`
+	// NormalizeInput trims surrounding whitespace and trailing dots off user and group names in
+	// request paths and bodies before they reach the API. Passwords are never touched.
+	NormalizeInput bool `yaml:"normalize_input" default:"false"`
+	// ErrorDetail is minimal (500 bodies carry a generic message and the request id, the error itself is
+	// only logged) or full (500 bodies carry the error, which may expose database internals: dev only).
+	ErrorDetail string `yaml:"error_detail" default:"minimal"`
+	// AccessLog replaces chi's request log with one entry per request carrying the method, path, status,
+	// latency, bytes, client IP, request id and the api key that authenticated the request.
+	AccessLog AccessLogConfig `yaml:"access_log"`
+}
+
+type AccessLogConfig struct {
+	Enabled bool `yaml:"enabled" default:"false"`
+	// Format is text (key=value pairs) or json.
+	Format string `yaml:"format" default:"text"`
+	// OmitPrincipal leaves the api key id out of the entries.
+	OmitPrincipal bool `yaml:"omit_principal" default:"false"`
+}
+
+const (
+	AccessLogFormatText = "text"
+	AccessLogFormatJSON = "json"
+)
+
+// TopDirConfig is a default user top dir, configured either as a bare name or as a mapping
+// overriding its mode (0770 when absent) and setgid bit (set when absent).
+type TopDirConfig struct {
+	Name   string       `yaml:"name"`
+	Mode   *fs.FileMode `yaml:"mode"`
+	Setgid *bool        `yaml:"setgid"`
+}
+
+const DefaultTopDirMode fs.FileMode = 0o770
+
+func (d *TopDirConfig) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&d.Name)
+	}
+	type plain TopDirConfig
+	return node.Decode((*plain)(d))
+}
+
+// EffectiveMode returns the permission bits of the top dir, the default ones when not configured.
+func (d TopDirConfig) EffectiveMode() fs.FileMode {
+	if d.Mode == nil {
+		return DefaultTopDirMode
+	}
+	return *d.Mode
+}
+
+// EffectiveSetgid tells whether the top dir gets the setgid bit, so files created in it belong to the group.
+func (d TopDirConfig) EffectiveSetgid() bool {
+	return d.Setgid == nil || *d.Setgid
+}
+
+// EffectiveEnforceModeOnEnsure tells whether existing user dirs get their owner and mode re-applied.
+func (c StorageConfig) EffectiveEnforceModeOnEnsure() bool {
+	return c.EnforceModeOnEnsure == nil || *c.EnforceModeOnEnsure
+}
+
+// HomePaths returns the resolver laying out the homes and top dirs with the storage policies.
+func (c StorageConfig) HomePaths() (ports.HomePaths, error) {
+	paths := ports.HomePaths{
+		HomesBaseDir:          c.HomesBaseDir,
+		RequireUserHomeSubdir: c.RequireUserHomeSubdir,
+		MaxHomeDepth:          c.MaxHomeDepth,
+		MaxPathLength:         c.MaxPathLength,
+	}
+	if c.TopDirNamePattern != "" {
+		var err error
+		if paths.TopDirName, err = regexp.Compile(c.TopDirNamePattern); err != nil {
+			return ports.HomePaths{}, fmt.Errorf("invalid top dir name pattern: %w", err)
+		}
+	}
+	return paths, nil
 }
 
+// ProbePath returns where a probe (or the telemetry endpoint) is mounted.
+func (c HttpServerConfig) ProbePath(p string) string {
+	if c.PrefixProbes {
+		return c.BasePath + p
+	}
+	return p
+}
+
+// EffectivePageSize applies the default to a missing (or non-positive) limit and clamps it to the maximum.
+func (c HttpServerConfig) EffectivePageSize(limit *int) int {
+	if limit == nil || *limit <= 0 {
+		return min(c.DefaultPageSize, c.MaxPageSize)
+	}
+	return min(*limit, c.MaxPageSize)
+}
+
+const (
+	ErrorDetailMinimal = "minimal"
+	ErrorDetailFull    = "full"
+)
+
+const (
+	RootResponseHTML     = "html"
+	RootResponseJSON     = "json"
+	RootResponseRedirect = "redirect"
+	RootResponseNone     = "none"
+)
+
 type SecurityConfig struct {
 	Authenticator AuthenticatorConfig `yaml:"authenticator"`
 	Hasher        HasherConfig        `yaml:"hasher"`
+	// MaxFailedLogins locks the user for LockoutDuration after that many consecutive failed logins (0 disables).
+	// The failures are counted in memory by each instance: behind a load balancer a user gets up to
+	// max_failed_logins attempts per instance, and a restart forgets the counts and the lockouts.
+	MaxFailedLogins int           `yaml:"max_failed_logins" default:"0"`
+	LockoutDuration time.Duration `yaml:"lockout_duration" default:"15m"`
+	// ReadOnlyKeys lists access key ids allowed to call GET/HEAD operations only, whatever their scopes.
+	ReadOnlyKeys []string `yaml:"read_only_keys"`
+	// RateLimit is the token bucket of every access key that doesn't configure its own rate_limit.
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	// SignResponses adds X-Response-Signature/X-Response-Timestamp to the responses of HMAC authenticated requests.
+	SignResponses  bool                 `yaml:"sign_responses"`
+	PasswordPolicy PasswordPolicyConfig `yaml:"password_policy"`
+	// ReturnHashInLookup exposes the stored password hash in the X-FS-Passwd header of the authz lookup,
+	// for frontends verifying passwords themselves (e.g. ProFTPD mod_sql); every disclosure is logged.
+	ReturnHashInLookup bool `yaml:"return_hash_in_lookup"`
+	// RequireDbTLS refuses to start with a database connection configured without TLS (mysql.ignore_ssl).
+	RequireDbTLS bool `yaml:"require_db_tls"`
+	// ConstantTimeAuth verifies the password of unknown users against a dummy hash of the default algorithm,
+	// so authenticating them takes about as long as a known user and doesn't reveal who exists.
+	ConstantTimeAuth bool `yaml:"constant_time_auth"`
+	// RequirePrehashedPasswords refuses the plaintext passwords (password_is_hash: false) of EnsureUser and
+	// SetUserPassword, so they never reach the server; POST /api/crypto/hash stays available.
+	RequirePrehashedPasswords bool `yaml:"require_prehashed_passwords"`
+}
+
+// PasswordPolicyConfig is checked against the plaintext passwords only, hashes are stored as given.
+type PasswordPolicyConfig struct {
+	// ForbidUsernameInPassword rejects passwords equal to the username or trivially derived from it
+	// (case changes, reversal, the username wrapped in digits or punctuation).
+	ForbidUsernameInPassword bool `yaml:"forbid_username_in_password"`
 }
 type AuthenticatorConfig struct {
-	EnabledAuthenticators []string          `yaml:"enabled_authenticators" default:"[hmac,bearer]"`
-	WindowSeconds         int               `yaml:"window_seconds" default:"60"`
-	AccessKeys            map[string]string `yaml:"access_keys"`
+	EnabledAuthenticators []string             `yaml:"enabled_authenticators" default:"[hmac,bearer]"`
+	WindowSeconds         int                  `yaml:"window_seconds" default:"60"`
+	AccessKeys            map[string]AccessKey `yaml:"access_keys"`
+	// MinSecretBytes is the shortest decoded length an access key secret may have.
+	MinSecretBytes int `yaml:"min_secret_bytes" default:"16"`
+	// MaxAccessKeys caps the number of configured access keys, 0 means no limit.
+	MaxAccessKeys int `yaml:"max_access_keys" default:"0"`
+	// HmacHash is the hash of the HMAC signatures (sha256 or sha512) of the requests that don't name one
+	// in the X-Hmac-Hash header.
+	HmacHash string `yaml:"hmac_hash" default:"sha256"`
+	// HmacAllowedHashes are the other hashes a client may name in X-Hmac-Hash, empty means the header
+	// may only name hmac_hash, so a client can't downgrade the configured hash.
+	HmacAllowedHashes []string `yaml:"hmac_allowed_hashes"`
+}
+
+const (
+	HmacHashSHA256 = "sha256"
+	HmacHashSHA512 = "sha512"
+)
+
+// keyedAuthenticators are the authenticators verifying requests against the access keys.
+var keyedAuthenticators = []string{"hmac", "bearer"}
+
+// AccessKey is configured either as a bare hex secret, a list of them or as a mapping with
+// the secret(s), the scopes the key is granted and its own rate limit.
+type AccessKey struct {
+	Secret string `yaml:"secret"`
+	// Secrets are accepted too, so a secret can be rotated: add the new one, roll the clients, drop the old one.
+	Secrets []string `yaml:"secrets"`
+	// Scopes restricts the key to the listed scopes (ports.Scope*), no scopes means full access.
+	Scopes    []string        `yaml:"scopes"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+}
+
+func (k *AccessKey) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Decode(&k.Secret)
+	case yaml.SequenceNode:
+		return node.Decode(&k.Secrets)
+	}
+	type plain AccessKey
+	return node.Decode((*plain)(k))
+}
+
+// AllSecrets lists Secret (when set) followed by Secrets.
+func (k AccessKey) AllSecrets() []string {
+	if k.Secret == "" {
+		return k.Secrets
+	}
+	return append([]string{k.Secret}, k.Secrets...)
+}
+
+// RateLimitConfig describes a token bucket, a zero rate disables limiting.
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	// Burst defaults to the rate rounded up (at least 1).
+	Burst int `yaml:"burst"`
 }
 
 type HasherConfig struct {
+	// DefaultAlgorithm hashes the plaintext passwords, it must be a salted crypt(3) one
+	// (crypt-md5, crypt-sha256 or crypt-sha512) or argon2id: raw digests are refused at load.
 	DefaultAlgorithm string `yaml:"default_algorithm" default:"crypt-sha256"`
 	DefaultRounds    int    `yaml:"default_rounds" default:"5000"`
 	DefaultSaltLen   int    `yaml:"default_salt_len" default:"16"`
+	// MinRounds raises the rounds floor of crypt-sha256/crypt-sha512 above the hard minimum (1000),
+	// requests below it are rejected.
+	MinRounds int `yaml:"min_rounds" default:"1000"`
+	// Argon2Memory (KiB), Argon2Time and Argon2Parallelism are the argon2id cost, the memory is capped
+	// at 1 GiB. They also cap the hashes being verified (stored or sent to the public verify endpoint),
+	// so lowering them fails the logins of the users hashed with a higher cost until they are rehashed.
+	Argon2Memory      int `yaml:"argon2_memory" default:"65536"`
+	Argon2Time        int `yaml:"argon2_time" default:"3"`
+	Argon2Parallelism int `yaml:"argon2_parallelism" default:"4"`
+	// LegacyVerifiers names the legacy formats (e.g. ldap-ssha) tried when a stored hash doesn't verify,
+	// passwords verified this way are rehashed with the default algorithm on login.
+	LegacyVerifiers []string `yaml:"legacy_verifiers"`
+	// AuditMinAlgorithm is the weakest stored hash the hash audit doesn't report,
+	// salted crypt(3) formats rank above raw digests.
+	AuditMinAlgorithm string `yaml:"audit_min_algorithm" default:"crypt-sha256"`
+	// DisabledAlgorithms can't be used to compute hashes anymore, stored hashes in them still verify
+	// so their users can log in and get migrated.
+	DisabledAlgorithms []string `yaml:"disabled_algorithms"`
 }
 
 type AccountRepositoryConfig struct {
@@ -59,23 +375,72 @@ type AccountRepositoryConfig struct {
 	Common          AccountRepositoryCommonConfig `yaml:"common"`
 	LoadInitialData bool                          `yaml:"load_initial_data" default:"false"`
 	InitialData     AccountRepositoryInitialData  `yaml:"initial_data"`
-	InMem           AccountRepositoryInMemConfig  `yaml:"inmem"`
-	Sqlite          AccountRepositorySqliteConfig `yaml:"sqlite"`
-	MySQL           AccountRepositoryMySqlConfig  `yaml:"mysql"`
+	// MaxInitialEntries caps the number of initial_data users and groups together (0 = no limit),
+	// bigger sets bootstrap for minutes and belong in the API.
+	MaxInitialEntries int `yaml:"max_initial_entries" default:"10000"`
+	// StartupRetries retries connecting to the repository at startup instead of failing on a database
+	// still coming up, StartupBackoff apart at first then doubling (up to 30s). 0 fails fast.
+	StartupRetries int           `yaml:"startup_retries" default:"0"`
+	StartupBackoff time.Duration `yaml:"startup_backoff" default:"1s"`
+	// AutoMigrate creates and migrates the SQL schema on every start, not only with --bootstrap, so any
+	// instance may come up first against an empty database; the schema statements are idempotent.
+	AutoMigrate bool                          `yaml:"auto_migrate" default:"false"`
+	InMem       AccountRepositoryInMemConfig  `yaml:"inmem"`
+	Sqlite      AccountRepositorySqliteConfig `yaml:"sqlite"`
+	MySQL       AccountRepositoryMySqlConfig  `yaml:"mysql"`
 }
 
 type AccountRepositoryCommonConfig struct {
 	MinUID uint32 `yaml:"min_uid" default:"2000"`
 	MinGID uint32 `yaml:"min_gid" default:"2000"`
+	// AllowDuplicateUIDs creates the schema (at bootstrap) without the unique UID index (inmem skips
+	// its UID check), so legacy passwd imports where several usernames share a UID can be loaded.
+	// SQL backends create the UID index only when user_info has none, toggling the flag later keeps
+	// the existing index (drop it by hand to switch).
+	// Trade-off: a UID no longer identifies a single account, so ownership on disk
+	// and UID-based lookups become ambiguous; leave it off unless you need it.
+	AllowDuplicateUIDs bool `yaml:"allow_duplicate_uids" default:"false"`
+	// MaxDescriptionLength limits user/group descriptions (in characters), 0 means no limit.
+	MaxDescriptionLength int `yaml:"max_description_length" default:"4096"`
+	// UIDGIDPolicy is checked when users are created: none, or require_personal_group
+	// (the user's group must have GID == UID and no other members).
+	UIDGIDPolicy string `yaml:"uid_gid_policy" default:"none"`
+	// AutoCreatePersonalGroup lets EnsureUser create a missing group named after the user
+	// (GID = UID, home = username) when the user's groupname is its username; when the user then
+	// can't be added, the group and its (still empty) home are removed again.
+	AutoCreatePersonalGroup bool `yaml:"auto_create_personal_group" default:"false"`
+	// UniqueGroupDescriptions rejects (ErrConflict) a group description already used by another group,
+	// for sites using descriptions as display names or external ids. Groups without one never clash.
+	UniqueGroupDescriptions bool `yaml:"unique_group_descriptions" default:"false"`
+	// LogQueries logs every SQL statement template with its duration (debugging aid, the arguments are
+	// never logged), unlike a slow query log it logs all of them. The in-memory repository ignores it.
+	LogQueries bool `yaml:"log_queries" default:"false"`
+	// ExpirationGracePeriod keeps expired users active that much longer, so an expiration doesn't
+	// cut a transfer off; it applies to the authz auth and lookup.
+	ExpirationGracePeriod time.Duration `yaml:"expiration_grace_period" default:"0s"`
 }
 
+const (
+	UIDGIDPolicyNone                 = "none"
+	UIDGIDPolicyRequirePersonalGroup = "require_personal_group"
+)
+
 type AccountRepositoryInitialData struct {
 	Users  map[string]ports.UserInfo  `yaml:"users"`
 	Groups map[string]ports.GroupInfo `yaml:"groups"`
+	// Workers ensures that many users (and their homes) at once, the groups are all ensured first.
+	// Users without a uid are still ensured one by one since the next UID is allocated per user.
+	Workers int `yaml:"workers" default:"1"`
 }
 
 type AccountRepositoryInMemConfig struct {
 	EntitiesLimit int `yaml:"entities_limit" default:"1000"`
+	// WalPath enables an append-only journal of every mutation, replayed on startup.
+	WalPath      string `yaml:"wal_path"`
+	CreateWalDir bool   `yaml:"create_wal_dir" default:"false"`
+	// WalCompactBytes bounds the journal: it's rewritten as one record per entity on startup
+	// and before the next mutation once it has grown over this size.
+	WalCompactBytes int64 `yaml:"wal_compact_bytes" default:"16777216"`
 }
 
 type AccountRepositorySqliteConfig struct {
@@ -83,6 +448,12 @@ type AccountRepositorySqliteConfig struct {
 	CreateDbDir  bool          `yaml:"create_db_dir" default:"false"`
 	QueryTimeout time.Duration `yaml:"query_timeout" default:"5s"`
 	WriteTimeout time.Duration `yaml:"write_timeout" default:"5s"`
+	// MaxWriteRetries retries writes failing with SQLITE_BUSY, with exponential backoff and jitter
+	// starting at RetryBaseDelay; this complements the busy_timeout derived from WriteTimeout.
+	MaxWriteRetries int           `yaml:"max_write_retries" default:"3"`
+	RetryBaseDelay  time.Duration `yaml:"retry_base_delay" default:"20ms"`
+	// HealthCheckQuery is run by HealthCheck, empty falls back to a plain ping.
+	HealthCheckQuery string `yaml:"health_check_query" default:"SELECT 1"`
 }
 
 type AccountRepositoryMySqlConfig struct {
@@ -94,6 +465,14 @@ type AccountRepositoryMySqlConfig struct {
 	IgnoreSSL    bool          `yaml:"ignore_ssl"`
 	SSLCaPath    string        `yaml:"ssl_ca_path"`
 	QueryTimeout time.Duration `yaml:"query_timeout" default:"5s"`
+	// HealthCheckQuery is run by HealthCheck, e.g. "SELECT 1 FROM user_info LIMIT 1" to confirm
+	// the schema is readable where a ping alone succeeds; empty falls back to a plain ping.
+	HealthCheckQuery string `yaml:"health_check_query" default:"SELECT 1"`
+	// MaxOpenConns, MaxIdleConns and ConnMaxLifetime size the connection pool of every instance,
+	// lower them when many instances share a server with a tight max_connections. 0 keeps the default.
+	MaxOpenConns    int           `yaml:"max_open_conns" default:"20"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" default:"10"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" default:"30m"`
 }
 
 func LoadConfig(path string) (*ProgramConfig, error) {
@@ -112,9 +491,221 @@ func LoadConfigString(data string) (*ProgramConfig, error) {
 		return nil, err
 	}
 	defaults.SetDefaults(&config)
+	if config.Storage.DefaultUserTopDirs == nil { // go-defaults would also replace an explicit []
+		config.Storage.DefaultUserTopDirs = []TopDirConfig{{Name: "_test"}}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
 	return &config, nil
 }
 
+// Validate checks the values that defaults can't fix.
+func (c *ProgramConfig) Validate() error {
+	if c.HttpServer.RequestTimeout <= 0 {
+		return fmt.Errorf("http_server.request_timeout must be positive, got %s", c.HttpServer.RequestTimeout)
+	}
+	if c.HttpServer.RetryAfter <= 0 {
+		return fmt.Errorf("http_server.retry_after must be positive, got %s", c.HttpServer.RetryAfter)
+	}
+	if c.Storage.MaxPathLength <= 0 {
+		return fmt.Errorf("storage.max_path_length must be positive, got %d", c.Storage.MaxPathLength)
+	}
+	if c.AccountRepository.InitialData.Workers < 1 {
+		return fmt.Errorf("account_repository.initial_data.workers must be positive, got %d", c.AccountRepository.InitialData.Workers)
+	}
+	if m := c.AccountRepository.MySQL; m.MaxOpenConns < 0 || m.MaxIdleConns < 0 || m.ConnMaxLifetime < 0 {
+		return fmt.Errorf("account_repository.mysql.max_open_conns, max_idle_conns and conn_max_lifetime must not be negative, got %d, %d and %s", m.MaxOpenConns, m.MaxIdleConns, m.ConnMaxLifetime)
+	}
+	if c.AccountRepository.StartupRetries < 0 || c.AccountRepository.StartupBackoff < 0 {
+		return fmt.Errorf("account_repository.startup_retries and startup_backoff must not be negative, got %d and %s", c.AccountRepository.StartupRetries, c.AccountRepository.StartupBackoff)
+	}
+	if c.AccountRepository.MaxInitialEntries < 0 {
+		return fmt.Errorf("account_repository.max_initial_entries must not be negative, got %d", c.AccountRepository.MaxInitialEntries)
+	}
+	if n := len(c.AccountRepository.InitialData.Users) + len(c.AccountRepository.InitialData.Groups); c.AccountRepository.LoadInitialData &&
+		c.AccountRepository.MaxInitialEntries > 0 && n > c.AccountRepository.MaxInitialEntries {
+		return fmt.Errorf("account_repository.initial_data has %d entries, more than account_repository.max_initial_entries (%d), create the rest through the API instead",
+			n, c.AccountRepository.MaxInitialEntries)
+	}
+	if c.Storage.MaxHomeDepth < 0 {
+		return fmt.Errorf("storage.max_home_depth must not be negative, got %d", c.Storage.MaxHomeDepth)
+	}
+	if c.Storage.MaxDirEntries < 0 {
+		return fmt.Errorf("storage.max_dir_entries must not be negative, got %d", c.Storage.MaxDirEntries)
+	}
+	if c.Storage.PrepareHomeRetries < 0 || c.Storage.PrepareHomeRetryDelay < 0 {
+		return fmt.Errorf("storage.prepare_home_retries and prepare_home_retry_delay must not be negative, got %d and %s", c.Storage.PrepareHomeRetries, c.Storage.PrepareHomeRetryDelay)
+	}
+	if c.Security.RequireDbTLS && c.AccountRepository.Type == "mysql" && c.AccountRepository.MySQL.IgnoreSSL {
+		return fmt.Errorf("account_repository.mysql.ignore_ssl disables TLS, which security.require_db_tls forbids")
+	}
+	if c.AccountRepository.Common.ExpirationGracePeriod < 0 {
+		return fmt.Errorf("account_repository.common.expiration_grace_period must not be negative, got %s", c.AccountRepository.Common.ExpirationGracePeriod)
+	}
+	if c.Storage.HomeDriftCheck.Interval < 0 || c.Storage.HomeDriftCheck.UserPause < 0 {
+		return fmt.Errorf("storage.home_drift_check.interval and user_pause must not be negative, got %s and %s", c.Storage.HomeDriftCheck.Interval, c.Storage.HomeDriftCheck.UserPause)
+	}
+	if len(c.Metrics.ActionDurationBuckets) == 0 {
+		return fmt.Errorf("metrics.action_duration_buckets must not be empty")
+	}
+	for i := 1; i < len(c.Metrics.ActionDurationBuckets); i++ {
+		if c.Metrics.ActionDurationBuckets[i] <= c.Metrics.ActionDurationBuckets[i-1] {
+			return fmt.Errorf("metrics.action_duration_buckets must be sorted in increasing order, got %v", c.Metrics.ActionDurationBuckets)
+		}
+	}
+	topDirName, err := regexp.Compile(c.Storage.TopDirNamePattern)
+	if err != nil {
+		return fmt.Errorf("storage.top_dir_name_pattern: %w", err)
+	}
+	for i, dir := range c.Storage.DefaultUserTopDirs {
+		if dir.Name == "" || dir.Name == "." || dir.Name == ".." || strings.ContainsRune(dir.Name, '/') {
+			return fmt.Errorf("storage.default_user_top_dirs must be plain directory names, got %q", dir.Name)
+		}
+		if !topDirName.MatchString(dir.Name) {
+			return fmt.Errorf("storage.default_user_top_dirs: %q doesn't match storage.top_dir_name_pattern", dir.Name)
+		}
+		if slices.ContainsFunc(c.Storage.DefaultUserTopDirs[:i], func(d TopDirConfig) bool { return d.Name == dir.Name }) {
+			return fmt.Errorf("storage.default_user_top_dirs lists %q twice", dir.Name)
+		}
+		if dir.Mode != nil && *dir.Mode&^fs.ModePerm != 0 {
+			return fmt.Errorf("storage.default_user_top_dirs: mode of %q must only hold permission bits, got %#o", dir.Name, uint32(*dir.Mode))
+		}
+	}
+	if c.HttpServer.DisableTCP && c.HttpServer.UnixSocketPath == "" {
+		return fmt.Errorf("http_server.disable_tcp leaves no listener, set http_server.unix_socket_path")
+	}
+	if c.HttpServer.DefaultPageSize <= 0 || c.HttpServer.MaxPageSize <= 0 {
+		return fmt.Errorf("http_server.default_page_size and max_page_size must be positive, got %d and %d", c.HttpServer.DefaultPageSize, c.HttpServer.MaxPageSize)
+	}
+	if c.HttpServer.DefaultPageSize > c.HttpServer.MaxPageSize {
+		return fmt.Errorf("http_server.default_page_size (%d) must not exceed max_page_size (%d)", c.HttpServer.DefaultPageSize, c.HttpServer.MaxPageSize)
+	}
+	if c.HttpServer.MaxBatchSize <= 0 {
+		return fmt.Errorf("http_server.max_batch_size must be positive, got %d", c.HttpServer.MaxBatchSize)
+	}
+	if c.HttpServer.GetCacheTTL < 0 {
+		return fmt.Errorf("http_server.get_cache_ttl must not be negative, got %s", c.HttpServer.GetCacheTTL)
+	}
+	if bp := c.HttpServer.BasePath; bp != "" && (!strings.HasPrefix(bp, "/") || bp == "/" || path.Clean(bp) != bp || strings.ContainsAny(bp, "?#{}*")) {
+		return fmt.Errorf("http_server.base_path must be a clean absolute path without a trailing slash, e.g. /fsaa/v1, got %q", bp)
+	}
+	switch c.HttpServer.RootResponse {
+	case RootResponseHTML, RootResponseJSON, RootResponseRedirect, RootResponseNone:
+	default:
+		return fmt.Errorf("http_server.root_response must be one of html, json, redirect, none, got %q", c.HttpServer.RootResponse)
+	}
+	switch c.HttpServer.ErrorDetail {
+	case ErrorDetailMinimal, ErrorDetailFull:
+	default:
+		return fmt.Errorf("http_server.error_detail must be one of minimal, full, got %q", c.HttpServer.ErrorDetail)
+	}
+	switch c.HttpServer.AccessLog.Format {
+	case AccessLogFormatText, AccessLogFormatJSON:
+	default:
+		return fmt.Errorf("http_server.access_log.format must be one of text, json, got %q", c.HttpServer.AccessLog.Format)
+	}
+	if c.AccountRepository.Common.MaxDescriptionLength < 0 {
+		return fmt.Errorf("account_repository.common.max_description_length must not be negative, got %d", c.AccountRepository.Common.MaxDescriptionLength)
+	}
+	switch c.AccountRepository.Common.UIDGIDPolicy {
+	case UIDGIDPolicyNone, UIDGIDPolicyRequirePersonalGroup:
+	default:
+		return fmt.Errorf("account_repository.common.uid_gid_policy must be one of none, require_personal_group, got %q", c.AccountRepository.Common.UIDGIDPolicy)
+	}
+	if err := c.Security.Authenticator.validateAccessKeys(); err != nil {
+		return err
+	}
+	for keyID, key := range c.Security.Authenticator.AccessKeys {
+		for _, scope := range key.Scopes {
+			if !slices.Contains(ports.KnownScopes, scope) {
+				return fmt.Errorf("security.authenticator.access_keys.%s: unknown scope %q, known scopes: %v", keyID, scope, ports.KnownScopes)
+			}
+		}
+		if key.RateLimit.RequestsPerSecond < 0 || key.RateLimit.Burst < 0 {
+			return fmt.Errorf("security.authenticator.access_keys.%s: rate_limit must not be negative", keyID)
+		}
+	}
+	if c.Security.RateLimit.RequestsPerSecond < 0 || c.Security.RateLimit.Burst < 0 {
+		return fmt.Errorf("security.rate_limit must not be negative")
+	}
+	for _, keyID := range c.Security.ReadOnlyKeys {
+		if _, ok := c.Security.Authenticator.AccessKeys[keyID]; !ok {
+			return fmt.Errorf("security.read_only_keys: unknown access key %q", keyID)
+		}
+	}
+	if alg, err := ports.ParseHashAlgo(c.Security.Hasher.DefaultAlgorithm); err != nil {
+		return fmt.Errorf("security.hasher.default_algorithm: %w: %q", err, c.Security.Hasher.DefaultAlgorithm)
+	} else if !alg.IsSalted() {
+		return fmt.Errorf("security.hasher.default_algorithm must be a salted algorithm (crypt-md5, crypt-sha256, crypt-sha512, argon2id), got %q", c.Security.Hasher.DefaultAlgorithm)
+	}
+	if _, err := ports.ParseHashAlgo(c.Security.Hasher.AuditMinAlgorithm); err != nil {
+		return fmt.Errorf("security.hasher.audit_min_algorithm: %w: %q", err, c.Security.Hasher.AuditMinAlgorithm)
+	}
+	if c.Security.MaxFailedLogins < 0 {
+		return fmt.Errorf("security.max_failed_logins must not be negative, got %d", c.Security.MaxFailedLogins)
+	}
+	if c.Security.MaxFailedLogins > 0 && c.Security.LockoutDuration <= 0 {
+		return fmt.Errorf("security.lockout_duration must be positive, got %s", c.Security.LockoutDuration)
+	}
+	if c.Security.SignResponses && !slices.Contains(c.Security.Authenticator.EnabledAuthenticators, "hmac") {
+		return fmt.Errorf("security.sign_responses requires the hmac authenticator to be enabled")
+	}
+	switch c.Security.Authenticator.HmacHash {
+	case HmacHashSHA256, HmacHashSHA512:
+	default:
+		return fmt.Errorf("security.authenticator.hmac_hash must be one of sha256, sha512, got %q", c.Security.Authenticator.HmacHash)
+	}
+	for _, h := range c.Security.Authenticator.HmacAllowedHashes {
+		if h != HmacHashSHA256 && h != HmacHashSHA512 {
+			return fmt.Errorf("security.authenticator.hmac_allowed_hashes must only hold sha256, sha512, got %q", h)
+		}
+	}
+	return nil
+}
+
+// validateAccessKeys fails fast on keys the authenticators would reject or that are too weak:
+// every secret must be hex of at least min_secret_bytes, and keyed authenticators need a key.
+func (a AuthenticatorConfig) validateAccessKeys() error {
+	if a.MinSecretBytes <= 0 {
+		return fmt.Errorf("security.authenticator.min_secret_bytes must be positive, got %d", a.MinSecretBytes)
+	}
+	if a.MaxAccessKeys < 0 {
+		return fmt.Errorf("security.authenticator.max_access_keys must not be negative, got %d", a.MaxAccessKeys)
+	}
+	if a.MaxAccessKeys > 0 && len(a.AccessKeys) > a.MaxAccessKeys {
+		return fmt.Errorf("security.authenticator.access_keys: %d keys configured, max_access_keys is %d", len(a.AccessKeys), a.MaxAccessKeys)
+	}
+	if len(a.AccessKeys) == 0 {
+		for _, name := range a.EnabledAuthenticators {
+			if slices.Contains(keyedAuthenticators, name) {
+				return fmt.Errorf("security.authenticator.access_keys: at least one key is required by the %s authenticator", name)
+			}
+		}
+	}
+	keyIDs := make([]string, 0, len(a.AccessKeys))
+	for keyID := range a.AccessKeys {
+		keyIDs = append(keyIDs, keyID)
+	}
+	slices.Sort(keyIDs)
+	for _, keyID := range keyIDs {
+		secrets := a.AccessKeys[keyID].AllSecrets()
+		if len(secrets) == 0 {
+			return fmt.Errorf("security.authenticator.access_keys.%s: no secret configured", keyID)
+		}
+		for i, secret := range secrets {
+			raw, err := hex.DecodeString(strings.TrimSpace(secret))
+			if err != nil {
+				return fmt.Errorf("security.authenticator.access_keys.%s: secret #%d is not valid hex: %w", keyID, i+1, err)
+			}
+			if len(raw) < a.MinSecretBytes {
+				return fmt.Errorf("security.authenticator.access_keys.%s: secret #%d has %d bytes, min_secret_bytes is %d", keyID, i+1, len(raw), a.MinSecretBytes)
+			}
+		}
+	}
+	return nil
+}
+
 func (c *ProgramConfig) PrintHello(programName, programVersion string, pidFile string, bootstrap bool) {
 	pid := os.Getpid()
 	pidFileInfo := ""
@@ -128,12 +719,37 @@ func (c *ProgramConfig) GetSecretKey(key string) (string, error) {
 	if c.Security.Authenticator.AccessKeys == nil {
 		return "", fmt.Errorf("access key %q not found", key)
 	}
-	if val, ok := c.Security.Authenticator.AccessKeys[key]; ok {
-		return val, nil
+	if val, ok := c.Security.Authenticator.AccessKeys[key]; ok && len(val.AllSecrets()) > 0 {
+		return val.AllSecrets()[0], nil
 	}
 	return "", fmt.Errorf("access key %q not found", key)
 }
 
+// Fingerprint is the hex SHA-256 of the effective config without its secrets, equal for equal configs
+// whatever the order of their yaml keys: it's marshalled to JSON, which sorts the map keys.
+func (c *ProgramConfig) Fingerprint() (string, error) {
+	b, err := json.Marshal(c.redacted())
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal the config: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// redacted copies the config without the access key secrets and the database password,
+// the passwords of the initial users aren't marshalled to JSON.
+func (c *ProgramConfig) redacted() ProgramConfig {
+	out := *c
+	out.AccountRepository.MySQL.Password = ""
+	keys := make(map[string]AccessKey, len(c.Security.Authenticator.AccessKeys))
+	for id, k := range c.Security.Authenticator.AccessKeys {
+		k.Secret, k.Secrets = "", nil
+		keys[id] = k
+	}
+	out.Security.Authenticator.AccessKeys = keys
+	return out
+}
+
 func (c *ProgramConfig) GetInitialUsers() map[string]*ports.UserInfo {
 	out := make(map[string]*ports.UserInfo, len(c.AccountRepository.InitialData.Users))
 	if c.AccountRepository.InitialData.Users != nil {
@@ -1,7 +1,10 @@
 package config_test
 
 import (
+	"fmt"
+	iofs "io/fs"
 	"os"
+	"strings"
 	"time"
 
 	"fs-access-api/internal/app/config"
@@ -56,7 +59,7 @@ http_server: {}
 security:
   authenticator:
     access_keys:
-      api1: secret-1
+      api1: 5ec7e7015ec7e7015ec7e7015ec7e701
 account_repository:
   type: inmem
   common: {}
@@ -75,8 +78,8 @@ metrics: {}
 			Expect(cfg.HttpServer.ListenAddress).To(Equal(":8080"))
 			Expect(cfg.HttpServer.TelemetryPath).To(Equal("/metrics"))
 			Expect(cfg.Storage.Implementation).To(Equal("unix"))
-			// this one had default:"[_test]"
-			Expect(cfg.Storage.DefaultUserTopDirs).To(ConsistOf("_test"))
+			// [_test] when the key is absent
+			Expect(cfg.Storage.DefaultUserTopDirs).To(Equal([]config.TopDirConfig{{Name: "_test"}}))
 
 			// authenticator defaults
 			Expect(cfg.Security.Authenticator.WindowSeconds).To(Equal(60))
@@ -105,7 +108,7 @@ account_repository:
   inmem: {}
 http_server: {}
 security:
-  authenticator: {}
+  authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } }
 metrics: {}
 `
 			cfg, err := config.LoadConfigString(yamlStr)
@@ -136,7 +139,7 @@ metrics: {}
 security:
   authenticator:
     access_keys:
-      keyA: valA
+      keyA: aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa
 account_repository:
   type: inmem
   common: {}
@@ -147,18 +150,94 @@ account_repository:
 
 		secret, err := cfg.GetSecretKey("keyA")
 		Expect(err).ToNot(HaveOccurred())
-		Expect(secret).To(Equal("valA"))
+		Expect(secret).To(Equal("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"))
 
 		_, err = cfg.GetSecretKey("nope")
 		Expect(err).To(HaveOccurred())
 	})
 
+	It("accepts access keys as a bare secret or a mapping with scopes and rate limit", func() {
+		yamlStr := `
+security:
+  authenticator:
+    access_keys:
+      keyA: aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa
+      keyB:
+        secret: bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb
+        scopes: [ users:read, groups:read ]
+        rate_limit: { requests_per_second: 5, burst: 10 }
+account_repository:
+  type: inmem
+`
+		cfg, err := config.LoadConfigString(yamlStr)
+		Expect(err).ToNot(HaveOccurred())
+		keys := cfg.Security.Authenticator.AccessKeys
+		Expect(keys["keyA"]).To(Equal(config.AccessKey{Secret: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}))
+		Expect(keys["keyB"].Secret).To(Equal("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"))
+		Expect(keys["keyB"].Scopes).To(Equal([]string{"users:read", "groups:read"}))
+		Expect(keys["keyB"].RateLimit).To(Equal(config.RateLimitConfig{RequestsPerSecond: 5, Burst: 10}))
+	})
+
+	It("rejects a negative default rate limit", func() {
+		_, err := config.LoadConfigString(`
+security:
+  authenticator: { access_keys: { keyA: aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa } }
+  rate_limit: { requests_per_second: -1 }
+account_repository:
+  type: inmem
+`)
+		Expect(err).To(MatchError(ContainSubstring("security.rate_limit must not be negative")))
+	})
+
+	It("accepts several secrets per access key for a rotation", func() {
+		yamlStr := `
+security:
+  authenticator:
+    access_keys:
+      keyA: [ a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1, a2a2a2a2a2a2a2a2a2a2a2a2a2a2a2a2 ]
+      keyB:
+        secret: b1b1b1b1b1b1b1b1b1b1b1b1b1b1b1b1
+        secrets: [ b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2 ]
+        scopes: [ users:read ]
+account_repository:
+  type: inmem
+`
+		cfg, err := config.LoadConfigString(yamlStr)
+		Expect(err).ToNot(HaveOccurred())
+		keys := cfg.Security.Authenticator.AccessKeys
+		Expect(keys["keyA"]).To(Equal(config.AccessKey{Secrets: []string{"a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1", "a2a2a2a2a2a2a2a2a2a2a2a2a2a2a2a2"}}))
+		Expect(keys["keyA"].AllSecrets()).To(Equal([]string{"a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1", "a2a2a2a2a2a2a2a2a2a2a2a2a2a2a2a2"}))
+		Expect(keys["keyB"].AllSecrets()).To(Equal([]string{"b1b1b1b1b1b1b1b1b1b1b1b1b1b1b1b1", "b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2"}))
+		Expect(cfg.GetSecretKey("keyA")).To(Equal("a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1"))
+	})
+
+	It("rejects unknown access key scopes", func() {
+		_, err := config.LoadConfigString(`
+security:
+  authenticator:
+    access_keys:
+      keyA: { secret: aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa, scopes: [ users:admin ] }
+`)
+		Expect(err).To(MatchError(ContainSubstring(`unknown scope "users:admin"`)))
+	})
+
+	It("rejects read-only keys that aren't access keys", func() {
+		_, err := config.LoadConfigString(`
+security:
+  authenticator:
+    access_keys:
+      keyA: aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa
+  read_only_keys: [ keyA, keyB ]
+`)
+		Expect(err).To(MatchError(ContainSubstring(`security.read_only_keys: unknown access key "keyB"`)))
+	})
+
 	It("maps initial users from YAML keys into Username field", func() {
 		yamlStr := `
 storage: { implementation: unix }
 http_server: {}
 metrics: {}
-security: { authenticator: {} }
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
 account_repository:
   type: inmem
   common: {}
@@ -199,7 +278,7 @@ var _ = Describe("DB-related defaults", func() {
 storage: { implementation: unix }
 http_server: {}
 metrics: {}
-security: { authenticator: {} }
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
 account_repository:
   type: sqlite
   common: {}
@@ -238,3 +317,523 @@ var _ = Describe("ExpandEnvWithDefaults (unit)", func() {
 		Expect(out).To(Equal("value"))
 	})
 })
+
+var _ = Describe("Validate", func() {
+	const base = `
+storage: { implementation: unix }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository: { type: inmem }
+`
+	It("defaults the request timeout to 60s", func() {
+		cfg, err := config.LoadConfigString(base + "http_server: {}\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.HttpServer.RequestTimeout).To(Equal(60 * time.Second))
+	})
+
+	It("defaults retry_after to 5s and rejects a non-positive one", func() {
+		cfg, err := config.LoadConfigString(base + "http_server: {}\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.HttpServer.RetryAfter).To(Equal(5 * time.Second))
+
+		_, err = config.LoadConfigString(base + "http_server: { retry_after: -1s }\n")
+		Expect(err).To(MatchError(ContainSubstring("http_server.retry_after must be positive")))
+	})
+
+	It("defaults max_batch_size to 1000 and rejects a non-positive one", func() {
+		cfg, err := config.LoadConfigString(base + "http_server: {}\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.HttpServer.MaxBatchSize).To(Equal(1000))
+
+		_, err = config.LoadConfigString(base + "http_server: { max_batch_size: -1 }\n")
+		Expect(err).To(MatchError(ContainSubstring("http_server.max_batch_size must be positive")))
+	})
+
+	It("rejects an unknown root response", func() {
+		_, err := config.LoadConfigString(base + "http_server: { root_response: text }\n")
+		Expect(err).To(MatchError(ContainSubstring("root_response")))
+	})
+
+	It("defaults to minimal error detail and rejects an unknown one", func() {
+		cfg, err := config.LoadConfigString(base)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.HttpServer.ErrorDetail).To(Equal(config.ErrorDetailMinimal))
+
+		_, err = config.LoadConfigString(base + "http_server: { error_detail: verbose }\n")
+		Expect(err).To(MatchError(ContainSubstring("error_detail")))
+	})
+
+	It("rejects a negative request timeout", func() {
+		cfg, err := config.LoadConfigString(base + "http_server: { request_timeout: -1s }\n")
+		Expect(err).To(MatchError(ContainSubstring("request_timeout")))
+		Expect(cfg).To(BeNil())
+	})
+
+	It("rejects a default page size above the maximum", func() {
+		_, err := config.LoadConfigString(base + "http_server: { default_page_size: 500, max_page_size: 200 }\n")
+		Expect(err).To(MatchError(ContainSubstring("default_page_size (500) must not exceed max_page_size (200)")))
+	})
+
+	It("rejects a raw default hash algorithm at load", func() {
+		_, err := config.LoadConfigString(`
+storage: { implementation: unix }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } }, hasher: { default_algorithm: raw-sha256 } }
+account_repository: { type: inmem }
+http_server: {}
+`)
+		Expect(err).To(MatchError(ContainSubstring(`security.hasher.default_algorithm must be a salted algorithm (crypt-md5, crypt-sha256, crypt-sha512, argon2id), got "raw-sha256"`)))
+	})
+
+	It("accepts argon2id as the default hash algorithm", func() {
+		cfg, err := config.LoadConfigString(`
+storage: { implementation: unix }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } }, hasher: { default_algorithm: argon2id } }
+account_repository: { type: inmem }
+http_server: {}
+`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Security.Hasher.Argon2Memory).To(Equal(65536))
+		Expect(cfg.Security.Hasher.Argon2Time).To(Equal(3))
+		Expect(cfg.Security.Hasher.Argon2Parallelism).To(Equal(4))
+	})
+
+	It("rejects an unknown audit minimum algorithm", func() {
+		_, err := config.LoadConfigString(`
+storage: { implementation: unix }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } }, hasher: { audit_min_algorithm: bcrypt } }
+account_repository: { type: inmem }
+http_server: {}
+`)
+		Expect(err).To(MatchError(ContainSubstring("audit_min_algorithm")))
+	})
+
+	It("defaults the action duration buckets and applies custom ones", func() {
+		cfg, err := config.LoadConfigString(base + "http_server: {}\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Metrics.ActionDurationBuckets).To(Equal([]float64{0.010, 0.100, 0.500, 1.0, 3.0, 5.0, 10.0}))
+
+		cfg, err = config.LoadConfigString(`
+storage: { implementation: unix }
+metrics: { action_duration_buckets: [ 0.0005, 0.001, 0.005, 0.025 ] }
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository: { type: inmem }
+http_server: {}
+`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Metrics.ActionDurationBuckets).To(Equal([]float64{0.0005, 0.001, 0.005, 0.025}))
+	})
+
+	It("rejects unsorted action duration buckets", func() {
+		_, err := config.LoadConfigString(`
+storage: { implementation: unix }
+metrics: { action_duration_buckets: [ 1, 0.5, 3 ] }
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository: { type: inmem }
+http_server: {}
+`)
+		Expect(err).To(MatchError(ContainSubstring("metrics.action_duration_buckets must be sorted")))
+	})
+
+	It("defaults the access log format to text and rejects unknown ones", func() {
+		cfg, err := config.LoadConfigString(base + "http_server: { access_log: { enabled: true } }\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.HttpServer.AccessLog.Format).To(Equal(config.AccessLogFormatText))
+
+		_, err = config.LoadConfigString(base + "http_server: { access_log: { enabled: true, format: xml } }\n")
+		Expect(err).To(MatchError(ContainSubstring("http_server.access_log.format")))
+	})
+
+	It("checks storage.top_dir_name_pattern and the default top dirs against it", func() {
+		cfg, err := config.LoadConfigString(base + "http_server: {}\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Storage.TopDirNamePattern).To(Equal(`^[A-Za-z0-9_.-]{1,64}$`))
+
+		_, err = config.LoadConfigString(`
+storage: { implementation: unix, top_dir_name_pattern: "^[a-z" }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository: { type: inmem }
+http_server: {}
+`)
+		Expect(err).To(MatchError(ContainSubstring("storage.top_dir_name_pattern")))
+
+		_, err = config.LoadConfigString(`
+storage: { implementation: unix, top_dir_name_pattern: "^[a-z]+$", default_user_top_dirs: [ _test ] }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository: { type: inmem }
+http_server: {}
+`)
+		Expect(err).To(MatchError(ContainSubstring(`"_test" doesn't match storage.top_dir_name_pattern`)))
+	})
+
+	It("requires a unix socket when disable_tcp is set", func() {
+		_, err := config.LoadConfigString(base + "http_server: { disable_tcp: true }\n")
+		Expect(err).To(MatchError(ContainSubstring("http_server.disable_tcp leaves no listener")))
+
+		cfg, err := config.LoadConfigString(base + "http_server: { disable_tcp: true, unix_socket_path: /run/fsaa.sock }\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.HttpServer.ListenAddress).To(Equal(":8080"))
+	})
+
+	It("defaults max_path_length to 4096 and rejects a negative one", func() {
+		cfg, err := config.LoadConfigString(base + "http_server: {}\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Storage.MaxPathLength).To(Equal(4096))
+
+		_, err = config.LoadConfigString(`
+storage: { implementation: unix, max_path_length: -1 }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository: { type: inmem }
+http_server: {}
+`)
+		Expect(err).To(MatchError(ContainSubstring("storage.max_path_length must be positive")))
+	})
+
+	It("seeds with one worker by default and rejects a negative count", func() {
+		cfg, err := config.LoadConfigString(base + "http_server: {}\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.AccountRepository.InitialData.Workers).To(Equal(1))
+
+		_, err = config.LoadConfigString(`
+storage: { implementation: unix }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository: { type: inmem, initial_data: { workers: -2 } }
+http_server: {}
+`)
+		Expect(err).To(MatchError(ContainSubstring("account_repository.initial_data.workers must be positive")))
+	})
+
+	It("fails fast at startup by default and rejects negative startup retries", func() {
+		cfg, err := config.LoadConfigString(base + "http_server: {}\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.AccountRepository.StartupRetries).To(BeZero())
+		Expect(cfg.AccountRepository.StartupBackoff).To(Equal(time.Second))
+
+		_, err = config.LoadConfigString(`
+storage: { implementation: unix }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository: { type: inmem, startup_retries: -1 }
+http_server: {}
+`)
+		Expect(err).To(MatchError(ContainSubstring("account_repository.startup_retries and startup_backoff must not be negative")))
+	})
+
+	It("caps the initial_data entries with account_repository.max_initial_entries", func() {
+		const cfgTemplate = `
+storage: { implementation: unix }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository:
+  type: inmem
+  load_initial_data: %t
+  max_initial_entries: 2
+  initial_data:
+    groups: { g1: { gid: 3001 } }
+    users: { u1: { groupname: g1 }, u2: { groupname: g1 } }
+http_server: {}
+`
+		_, err := config.LoadConfigString(fmt.Sprintf(cfgTemplate, true))
+		Expect(err).To(MatchError(ContainSubstring("account_repository.initial_data has 3 entries, more than account_repository.max_initial_entries (2)")))
+
+		_, err = config.LoadConfigString(fmt.Sprintf(cfgTemplate, false))
+		Expect(err).ToNot(HaveOccurred())
+
+		cfg, err := config.LoadConfigString(base + "http_server: {}\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.AccountRepository.MaxInitialEntries).To(Equal(10000))
+	})
+
+	It("leaves max_home_depth unlimited by default and rejects a negative one", func() {
+		cfg, err := config.LoadConfigString(base + "http_server: {}\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Storage.MaxHomeDepth).To(BeZero())
+
+		_, err = config.LoadConfigString(`
+storage: { implementation: unix, max_home_depth: -1 }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository: { type: inmem }
+http_server: {}
+`)
+		Expect(err).To(MatchError(ContainSubstring("storage.max_home_depth must not be negative")))
+	})
+
+	It("refuses mysql.ignore_ssl with security.require_db_tls", func() {
+		const cfgTemplate = `
+storage: { implementation: unix }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } }, require_db_tls: %t }
+account_repository: { type: mysql, mysql: { host: db, port: 3306, database: fsaa, user: fsaa, ignore_ssl: true } }
+http_server: {}
+`
+		_, err := config.LoadConfigString(fmt.Sprintf(cfgTemplate, true))
+		Expect(err).To(MatchError(ContainSubstring("security.require_db_tls forbids")))
+
+		cfg, err := config.LoadConfigString(fmt.Sprintf(cfgTemplate, false))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.AccountRepository.MySQL.IgnoreSSL).To(BeTrue())
+	})
+
+	It("sizes the mysql connection pool like before unless configured", func() {
+		const cfgTemplate = `
+storage: { implementation: unix }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository: { type: mysql, mysql: { host: db, port: 3306, database: fsaa, user: fsaa%s } }
+http_server: {}
+`
+		cfg, err := config.LoadConfigString(fmt.Sprintf(cfgTemplate, ""))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.AccountRepository.MySQL.MaxOpenConns).To(Equal(20))
+		Expect(cfg.AccountRepository.MySQL.MaxIdleConns).To(Equal(10))
+		Expect(cfg.AccountRepository.MySQL.ConnMaxLifetime).To(Equal(30 * time.Minute))
+
+		cfg, err = config.LoadConfigString(fmt.Sprintf(cfgTemplate, ", max_open_conns: 4, max_idle_conns: 2, conn_max_lifetime: 5m"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.AccountRepository.MySQL.MaxOpenConns).To(Equal(4))
+		Expect(cfg.AccountRepository.MySQL.MaxIdleConns).To(Equal(2))
+		Expect(cfg.AccountRepository.MySQL.ConnMaxLifetime).To(Equal(5 * time.Minute))
+
+		_, err = config.LoadConfigString(fmt.Sprintf(cfgTemplate, ", max_open_conns: -1"))
+		Expect(err).To(MatchError(ContainSubstring("max_open_conns")))
+	})
+
+	It("rejects a negative expiration_grace_period", func() {
+		_, err := config.LoadConfigString(`
+storage: { implementation: unix }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository: { type: inmem, common: { expiration_grace_period: -1h } }
+http_server: {}
+`)
+		Expect(err).To(MatchError(ContainSubstring("expiration_grace_period must not be negative")))
+	})
+
+	It("disables the home drift check by default and rejects a negative interval", func() {
+		cfg, err := config.LoadConfigString(base + "http_server: {}\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Storage.HomeDriftCheck).To(Equal(config.HomeDriftCheckConfig{Interval: 0, UserPause: 10 * time.Millisecond}))
+
+		_, err = config.LoadConfigString(`
+storage: { implementation: unix, home_drift_check: { interval: -1m } }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository: { type: inmem }
+http_server: {}
+`)
+		Expect(err).To(MatchError(ContainSubstring("storage.home_drift_check.interval and user_pause must not be negative")))
+	})
+
+	It("keeps an explicitly empty default_user_top_dirs", func() {
+		cfg, err := config.LoadConfigString(`
+storage: { implementation: unix, default_user_top_dirs: [] }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository: { type: inmem }
+http_server: {}
+`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Storage.DefaultUserTopDirs).To(BeEmpty())
+	})
+
+	DescribeTable("validates default_user_top_dirs entries",
+		func(dirs string, msg string) {
+			_, err := config.LoadConfigString(`
+storage: { implementation: unix, default_user_top_dirs: ` + dirs + ` }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository: { type: inmem }
+http_server: {}
+`)
+			Expect(err).To(MatchError(ContainSubstring(msg)))
+		},
+		Entry("nested path", "[ a/b ]", "plain directory names"),
+		Entry("parent", "[ '..' ]", "plain directory names"),
+		Entry("empty name", "[ '' ]", "plain directory names"),
+		Entry("duplicate", "[ _test, data, _test ]", `lists "_test" twice`),
+		Entry("duplicate across forms", "[ _test, { name: _test, mode: 0750 } ]", `lists "_test" twice`),
+		Entry("mode beyond permissions", "[ { name: data, mode: 02770 } ]", "must only hold permission bits"),
+	)
+
+	It("parses default_user_top_dirs names and per-dir settings", func() {
+		cfg, err := config.LoadConfigString(`
+storage:
+  implementation: unix
+  default_user_top_dirs:
+    - _test
+    - { name: public, mode: 0o755, setgid: false }
+    - { name: drop, mode: 0730 }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository: { type: inmem }
+http_server: {}
+`)
+		Expect(err).ToNot(HaveOccurred())
+		dirs := cfg.Storage.DefaultUserTopDirs
+		Expect(dirs).To(HaveLen(3))
+		Expect(dirs[0]).To(Equal(config.TopDirConfig{Name: "_test"}))
+		Expect(dirs[0].EffectiveMode()).To(Equal(iofs.FileMode(0o770)))
+		Expect(dirs[0].EffectiveSetgid()).To(BeTrue())
+		Expect(dirs[1].Name).To(Equal("public"))
+		Expect(dirs[1].EffectiveMode()).To(Equal(iofs.FileMode(0o755)))
+		Expect(dirs[1].EffectiveSetgid()).To(BeFalse())
+		Expect(dirs[2].EffectiveMode()).To(Equal(iofs.FileMode(0o730)))
+		Expect(dirs[2].EffectiveSetgid()).To(BeTrue())
+	})
+
+	It("requires the hmac authenticator to sign responses", func() {
+		_, err := config.LoadConfigString(`
+storage: { implementation: unix }
+metrics: {}
+security: { authenticator: { enabled_authenticators: [ bearer ], access_keys: { key1: 00112233445566778899aabbccddeeff } }, sign_responses: true }
+account_repository: { type: inmem }
+http_server: {}
+`)
+		Expect(err).To(MatchError(ContainSubstring("sign_responses requires the hmac authenticator")))
+	})
+
+	It("defaults hmac_hash to sha256 and rejects unknown hashes", func() {
+		const hmacCfg = `
+storage: { implementation: unix }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff }%s } }
+account_repository: { type: inmem }
+http_server: {}
+`
+		cfg, err := config.LoadConfigString(fmt.Sprintf(hmacCfg, ""))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Security.Authenticator.HmacHash).To(Equal(config.HmacHashSHA256))
+
+		cfg, err = config.LoadConfigString(fmt.Sprintf(hmacCfg, ", hmac_hash: sha512"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Security.Authenticator.HmacHash).To(Equal(config.HmacHashSHA512))
+
+		_, err = config.LoadConfigString(fmt.Sprintf(hmacCfg, ", hmac_hash: md5"))
+		Expect(err).To(MatchError(ContainSubstring("hmac_hash must be one of sha256, sha512")))
+
+		cfg, err = config.LoadConfigString(fmt.Sprintf(hmacCfg, ", hmac_allowed_hashes: [sha512]"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cfg.Security.Authenticator.HmacAllowedHashes).To(Equal([]string{config.HmacHashSHA512}))
+
+		_, err = config.LoadConfigString(fmt.Sprintf(hmacCfg, ", hmac_allowed_hashes: [md5]"))
+		Expect(err).To(MatchError(ContainSubstring("hmac_allowed_hashes must only hold sha256, sha512")))
+	})
+
+	DescribeTable("validates the access keys at load",
+		func(authenticator, expected string) {
+			_, err := config.LoadConfigString(`
+storage: { implementation: unix }
+metrics: {}
+security: { authenticator: ` + authenticator + ` }
+account_repository: { type: inmem }
+http_server: {}
+`)
+			if expected == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expected)))
+			}
+		},
+		Entry("16-byte secret", `{ access_keys: { keyA: 00112233445566778899aabbccddeeff } }`, ""),
+		Entry("too short secret", `{ access_keys: { keyA: 00112233445566778899aabbccddee } }`,
+			"access_keys.keyA: secret #1 has 15 bytes, min_secret_bytes is 16"),
+		Entry("non-hex secret", `{ access_keys: { keyA: not-a-hex-secret-0123456789abcdef } }`,
+			"access_keys.keyA: secret #1 is not valid hex"),
+		Entry("bad rotated secret", `{ access_keys: { keyA: [ 00112233445566778899aabbccddeeff, "zz" ] } }`,
+			"access_keys.keyA: secret #2 is not valid hex"),
+		Entry("raised minimum", `{ min_secret_bytes: 32, access_keys: { keyA: 00112233445566778899aabbccddeeff } }`,
+			"access_keys.keyA: secret #1 has 16 bytes, min_secret_bytes is 32"),
+		Entry("key without a secret", `{ access_keys: { keyA: { scopes: [ users:read ] } } }`,
+			"access_keys.keyA: no secret configured"),
+		Entry("no keys", `{}`,
+			"access_keys: at least one key is required by the hmac authenticator"),
+		Entry("too many keys", `{ max_access_keys: 1, access_keys: { keyA: 00112233445566778899aabbccddeeff, keyB: 00112233445566778899aabbccddeeff } }`,
+			"2 keys configured, max_access_keys is 1"),
+		Entry("duplicate key ids", `{ access_keys: { keyA: 00112233445566778899aabbccddeeff, keyA: ffeeddccbbaa99887766554433221100 } }`,
+			`mapping key "keyA" already defined`),
+	)
+
+	DescribeTable("validates the base path",
+		func(basePath string, valid bool) {
+			_, err := config.LoadConfigString(base + "http_server: { base_path: \"" + basePath + "\" }\n")
+			if valid {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring("base_path")))
+			}
+		},
+		Entry("empty mounts at the root", "", true),
+		Entry("nested prefix", "/fsaa/v1", true),
+		Entry("relative", "fsaa/v1", false),
+		Entry("root only", "/", false),
+		Entry("trailing slash", "/fsaa/v1/", false),
+		Entry("unclean", "/fsaa/../v1", false),
+	)
+})
+
+var _ = Describe("HttpServerConfig.EffectivePageSize", func() {
+	cfg := config.HttpServerConfig{DefaultPageSize: 50, MaxPageSize: 200}
+
+	It("applies the default when the limit is absent", func() {
+		Expect(cfg.EffectivePageSize(nil)).To(Equal(50))
+		zero := 0
+		Expect(cfg.EffectivePageSize(&zero)).To(Equal(50))
+	})
+
+	It("clamps over-max limits", func() {
+		limit := 1_000_000
+		Expect(cfg.EffectivePageSize(&limit)).To(Equal(200))
+	})
+
+	It("keeps limits within range", func() {
+		limit := 120
+		Expect(cfg.EffectivePageSize(&limit)).To(Equal(120))
+	})
+})
+
+var _ = Describe("ProgramConfig.Fingerprint", func() {
+	fingerprint := func(data string) string {
+		cfg, err := config.LoadConfigString(data)
+		Expect(err).ToNot(HaveOccurred())
+		fp, err := cfg.Fingerprint()
+		Expect(err).ToNot(HaveOccurred())
+		return fp
+	}
+	const cfgA = `
+storage: { implementation: inmem, homes_base_dir: /tmp/homes }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff, key2: ffeeddccbbaa99887766554433221100 } } }
+account_repository: { type: inmem }
+http_server: {}
+`
+	// cfgB is cfgA with its keys in another order
+	const cfgB = `
+http_server: {}
+account_repository: { type: inmem }
+security: { authenticator: { access_keys: { key2: ffeeddccbbaa99887766554433221100, key1: 00112233445566778899aabbccddeeff } } }
+metrics: {}
+storage: { homes_base_dir: /tmp/homes, implementation: inmem }
+`
+
+	It("is the same for identical configs", func() {
+		Expect(fingerprint(cfgA)).To(MatchRegexp(`^[0-9a-f]{64}$`))
+		Expect(fingerprint(cfgA)).To(Equal(fingerprint(cfgB)))
+	})
+
+	It("differs when a setting changes", func() {
+		changed := strings.Replace(cfgA, "http_server: {}", "http_server: { read_only: true }", 1)
+		Expect(fingerprint(changed)).NotTo(Equal(fingerprint(cfgA)))
+	})
+
+	It("leaves the secrets out", func() {
+		rotated := strings.Replace(cfgA, "00112233445566778899aabbccddeeff", "0123456789abcdef0123456789abcdef", 1)
+		Expect(fingerprint(rotated)).To(Equal(fingerprint(cfgA)))
+	})
+})
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"log"
+	"sync"
+	"time"
+)
+
+// StartHomeDriftChecker checks the user homes every storage.home_drift_check.interval in the background,
+// the returned stop interrupts a running check and waits for the checker to exit.
+// With a zero interval nothing is started.
+func StartHomeDriftChecker(cfg config.HomeDriftCheckConfig, apis ports.ApiServer, metrics ports.HomeDriftMetrics) (stop func()) {
+	if cfg.Interval <= 0 {
+		return func() {}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			report, err := apis.CheckHomeDrift(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("Home drift check failed: %v", err)
+				}
+				continue
+			}
+			for _, drift := range report.Drifts {
+				metrics.OnHomeDrift(drift)
+			}
+			log.Printf("Home drift check: %d user homes checked, %d drifts, %d errors", report.UsersChecked, len(report.Drifts), report.Errors)
+		}
+	}()
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
@@ -0,0 +1,75 @@
+package app_test
+
+import (
+	"context"
+	"fs-access-api/internal/app"
+	"fs-access-api/internal/app/config"
+	"fs-access-api/internal/app/ports"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// driftingApiServer reports one mode drift on every check that isn't canceled.
+type driftingApiServer struct {
+	ports.ApiServer
+	mu     sync.Mutex
+	checks int
+}
+
+func (s *driftingApiServer) CheckHomeDrift(ctx context.Context) (ports.HomeDriftReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ctx.Err() != nil {
+		return ports.HomeDriftReport{}, ctx.Err()
+	}
+	s.checks++
+	return ports.HomeDriftReport{UsersChecked: 1, Drifts: []ports.HomeDrift{{Username: "alice", Kind: ports.HomeDriftMode}}}, nil
+}
+
+func (s *driftingApiServer) Checks() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checks
+}
+
+type countingDriftMetrics struct {
+	mu    sync.Mutex
+	kinds []ports.HomeDriftKind
+}
+
+func (m *countingDriftMetrics) OnHomeDrift(drift ports.HomeDrift) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.kinds = append(m.kinds, drift.Kind)
+}
+
+func (m *countingDriftMetrics) Kinds() []ports.HomeDriftKind {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]ports.HomeDriftKind{}, m.kinds...)
+}
+
+var _ = Describe("StartHomeDriftChecker", func() {
+	It("checks periodically, records the drifts and stops on request", func() {
+		apis := &driftingApiServer{}
+		metrics := &countingDriftMetrics{}
+		stop := app.StartHomeDriftChecker(config.HomeDriftCheckConfig{Interval: 5 * time.Millisecond}, apis, metrics)
+
+		Eventually(apis.Checks).Should(BeNumerically(">=", 2))
+		stop()
+		checks := apis.Checks()
+		Expect(metrics.Kinds()).To(HaveLen(checks))
+		Expect(metrics.Kinds()).To(HaveEach(ports.HomeDriftMode))
+		Consistently(apis.Checks, 30*time.Millisecond).Should(Equal(checks))
+	})
+
+	It("starts nothing with a zero interval", func() {
+		apis := &driftingApiServer{}
+		stop := app.StartHomeDriftChecker(config.HomeDriftCheckConfig{}, apis, &countingDriftMetrics{})
+		Consistently(apis.Checks, 30*time.Millisecond).Should(BeZero())
+		stop()
+	})
+})
@@ -30,7 +30,7 @@ func NewMultiHTTPServer(cfg config.HttpServerConfig, handler http.Handler) (*Mul
 		handler:  handler,
 		serveErr: make(chan error, 2),
 	}
-	if cfg.ListenAddress != "" {
+	if cfg.ListenAddress != "" && !cfg.DisableTCP {
 		s.initTCP()
 	}
 	if cfg.UnixSocketPath != "" {
@@ -44,13 +44,30 @@ func NewMultiHTTPServer(cfg config.HttpServerConfig, handler http.Handler) (*Mul
 	return s, nil
 }
 
+// Listeners names the configured listeners as tcp:<address> and unix:<socket path>.
+func (s *MultiHTTPServer) Listeners() []string {
+	var out []string
+	if s.tcp != nil {
+		out = append(out, "tcp:"+s.cfg.ListenAddress)
+	}
+	if s.unix != nil {
+		out = append(out, "unix:"+s.cfg.UnixSocketPath)
+	}
+	return out
+}
+
+// writeTimeout leaves a margin over the request timeout, so the timeout response can still be written.
+func (s *MultiHTTPServer) writeTimeout() time.Duration {
+	return s.cfg.RequestTimeout + 5*time.Second
+}
+
 func (s *MultiHTTPServer) initTCP() {
 	s.tcp = &http.Server{
 		Addr:              s.cfg.ListenAddress,
 		Handler:           s.handler,
 		ReadHeaderTimeout: 5 * time.Second,
 		ReadTimeout:       15 * time.Second,
-		WriteTimeout:      60 * time.Second,
+		WriteTimeout:      s.writeTimeout(),
 		IdleTimeout:       90 * time.Second,
 		MaxHeaderBytes:    1 << 16, // 64 KB
 	}
@@ -101,7 +118,7 @@ func (s *MultiHTTPServer) initUnix() error {
 		Handler:           s.handler,
 		ReadHeaderTimeout: 5 * time.Second,
 		ReadTimeout:       15 * time.Second,
-		WriteTimeout:      60 * time.Second,
+		WriteTimeout:      s.writeTimeout(),
 		IdleTimeout:       90 * time.Second,
 	}
 	return nil
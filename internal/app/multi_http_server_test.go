@@ -0,0 +1,41 @@
+package app_test
+
+import (
+	"fs-access-api/internal/app"
+	"fs-access-api/internal/app/config"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewMultiHTTPServer", func() {
+	var socketPath string
+
+	BeforeEach(func() {
+		// unix socket paths are limited to ~100 bytes, the Ginkgo temp dirs can be longer
+		dir, err := os.MkdirTemp("", "fsaa")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		socketPath = filepath.Join(dir, "api.sock")
+	})
+
+	It("serves both listeners by default", func() {
+		s, err := app.NewMultiHTTPServer(config.HttpServerConfig{ListenAddress: ":8080", UnixSocketPath: socketPath}, http.NotFoundHandler())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Listeners()).To(Equal([]string{"tcp::8080", "unix:" + socketPath}))
+	})
+
+	It("serves the unix socket only with disable_tcp, despite the default listen_address", func() {
+		s, err := app.NewMultiHTTPServer(config.HttpServerConfig{ListenAddress: ":8080", UnixSocketPath: socketPath, DisableTCP: true}, http.NotFoundHandler())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Listeners()).To(Equal([]string{"unix:" + socketPath}))
+	})
+
+	It("refuses disable_tcp without a unix socket", func() {
+		_, err := app.NewMultiHTTPServer(config.HttpServerConfig{ListenAddress: ":8080", DisableTCP: true}, http.NotFoundHandler())
+		Expect(err).To(MatchError("no listeners configured"))
+	})
+})
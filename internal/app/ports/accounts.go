@@ -1,35 +1,66 @@
 package ports
 
 import (
+	"io/fs"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 )
 
 type AccountRepository interface {
 	HealthCheck() error
 	GetInfo() (string, error)
+	Capabilities() RepoCapabilities
 
 	ListGroups() ([]GroupInfo, error)
+	// ListEmptyGroups returns the groups no user belongs to, ordered like ListGroups.
+	ListEmptyGroups() ([]GroupInfo, error)
 	GetGroup(name string) (GroupInfo, error)
 	AddGroup(group GroupInfo) (GroupInfo, error)
+	// UpdateGroup fails with ErrVersionMismatch unless group.Version is 0 or equals the stored version,
+	// the stored version is incremented.
 	UpdateGroup(group GroupInfo) (GroupInfo, error)
 	DeleteGroup(name string) error
 
 	GetNextUID() (uint32, error)
 	ListUsers() ([]UserInfo, error)
+	// ListUsersPage returns at most limit users after skipping offset of them in the order of the
+	// sort key (see ParseUserSort), and the total number of users.
+	ListUsersPage(offset, limit int, sort string) (page []UserInfo, total int, err error)
 	GetUser(name string) (UserInfo, error)
 	AddUser(user UserInfo) (UserInfo, error)
+	// UpdateUser fails with ErrVersionMismatch unless user.Version is 0 or equals the stored version,
+	// the stored version is incremented. An empty user.Password keeps the stored one.
 	UpdateUser(user UserInfo) (UserInfo, error)
 	DeleteUser(name string) error
+	// TouchUser sets the user's UpdatedAt to now, leaving its attributes and version unchanged.
+	TouchUser(name string) (UserInfo, error)
+	// DeleteUsers deletes all the named users or none and returns the number of users actually
+	// deleted, it fails with ErrConflict when some of them are already gone.
+	DeleteUsers(names []string) (affected int, err error)
 
 	GetUserAuthzInfo(name string) (UserAuthzInfo, error)
 }
 
+// RepoCapabilities tells which optional features an AccountRepository backend provides.
+type RepoCapabilities struct {
+	// SupportsTransactions means multi-row writes are committed atomically by the backend itself.
+	SupportsTransactions bool `json:"supports_transactions"`
+	SupportsRename       bool `json:"supports_rename"`
+	// ReadOnly backends reject every group and user mutation.
+	ReadOnly            bool `json:"read_only"`
+	SupportsMaintenance bool `json:"supports_maintenance"`
+}
+
 type GroupInfo struct {
 	Groupname   string  `yaml:"groupname"`
 	GID         uint32  `yaml:"gid"`
 	Description *string `yaml:"description" json:"description,omitempty"`
 	Home        string  `yaml:"home"  json:"home"`
+	// QuotaBytes is the total allowance shared by the group members, nil means unlimited.
+	QuotaBytes *uint64 `yaml:"quota_bytes" json:"quota_bytes,omitempty"`
+	Version    uint64  `yaml:"-" json:"version"`
 }
 
 func (g *GroupInfo) AbsoluteHomeDir(homesBaseDir string) string {
@@ -37,23 +68,75 @@ func (g *GroupInfo) AbsoluteHomeDir(homesBaseDir string) string {
 }
 
 type UserInfo struct {
-	Username       string     `yaml:"username" json:"username"`
-	UID            uint32     `yaml:"uid"   json:"uid"`
-	Groupname      string     `yaml:"groupname" json:"groupname"`
-	Password       string     `yaml:"password" json:"-"`
-	PasswordIsHash bool       `yaml:"password_is_hash" json:"-"`
-	Description    *string    `yaml:"description" json:"description,omitempty"`
-	Home           string     `yaml:"home"  json:"home"`
-	Expiration     *time.Time `yaml:"expiration,omitempty" json:"expiration,omitempty"`
-	Disabled       bool       `yaml:"disabled" json:"disabled"`
+	Username       string  `yaml:"username" json:"username"`
+	UID            uint32  `yaml:"uid"   json:"uid"`
+	Groupname      string  `yaml:"groupname" json:"groupname"`
+	Password       string  `yaml:"password" json:"-"`
+	PasswordIsHash bool    `yaml:"password_is_hash" json:"-"`
+	Description    *string `yaml:"description" json:"description,omitempty"`
+	// Email addresses the user's notifications, it's left out of the authz lookup.
+	Email      *string    `yaml:"email,omitempty" json:"email,omitempty"`
+	Home       string     `yaml:"home"  json:"home"`
+	Expiration *time.Time `yaml:"expiration,omitempty" json:"expiration,omitempty"`
+	Disabled   bool       `yaml:"disabled" json:"disabled"`
+	Version    uint64     `yaml:"-" json:"version"`
+	// UpdatedAt is set by the repository on every write, nil for users not written since it was introduced.
+	UpdatedAt *time.Time `yaml:"-" json:"updated_at,omitempty"`
+}
+
+// UserFilter selects users matching all of its set criteria.
+type UserFilter struct {
+	Groupname     *string
+	ExpiredBefore *time.Time
+	Usernames     []string
+}
+
+// IsEmpty tells no criterion is set, such a filter would match every user.
+func (f UserFilter) IsEmpty() bool {
+	return f.Groupname == nil && f.ExpiredBefore == nil && len(f.Usernames) == 0
+}
+
+func (f UserFilter) Matches(u UserInfo) bool {
+	if f.Groupname != nil && u.Groupname != *f.Groupname {
+		return false
+	}
+	if f.ExpiredBefore != nil && (u.Expiration == nil || !u.Expiration.Before(*f.ExpiredBefore)) {
+		return false
+	}
+	if len(f.Usernames) > 0 && !slices.Contains(f.Usernames, u.Username) {
+		return false
+	}
+	return true
+}
+
+// User sort keys of ListUsersPage, prefixed with '-' they sort descending.
+const (
+	UserSortUsername = "username"
+	UserSortUID      = "uid"
+)
+
+// ParseUserSort validates a ListUsersPage sort key, an empty one sorts by username like ListUsers.
+func ParseUserSort(sort string) (key string, desc bool, err error) {
+	key, desc = strings.CutPrefix(sort, "-")
+	switch key {
+	case "":
+		if desc {
+			break
+		}
+		return UserSortUsername, false, nil
+	case UserSortUsername, UserSortUID:
+		return key, desc, nil
+	}
+	return "", false, InvalidField("sort", "unknown sort %q, expected username or uid, prefixed with - to sort descending", sort)
 }
 
-func IsUserLocked(disabled bool, expiration *time.Time) bool {
-	return disabled || (expiration != nil && expiration.Before(time.Now()))
+// IsUserLocked reports whether the user is disabled or, at now, expired for longer than the grace period.
+func IsUserLocked(disabled bool, expiration *time.Time, grace time.Duration, now time.Time) bool {
+	return disabled || (expiration != nil && expiration.Add(grace).Before(now))
 }
 
-func (u *UserInfo) IsLocked() bool {
-	return IsUserLocked(u.Disabled, u.Expiration)
+func (u *UserInfo) IsLocked(grace time.Duration, now time.Time) bool {
+	return IsUserLocked(u.Disabled, u.Expiration, grace, now)
 }
 
 func (u *UserInfo) AbsoluteHomeDir(homesBaseDir, groupHome string) string {
@@ -67,10 +150,42 @@ type UserAuthzInfo struct {
 	GID       uint32 `yaml:"gid"`
 	UserHome  string `yaml:"user-home"  json:"user-home"`
 	GroupHome string `yaml:"group-home"  json:"group-home"`
-	Locked    bool   `yaml:"locked" json:"locked"`
-	Password  string `yaml:"password" json:"-"`
+	// Locked is set by the api server from Disabled and Expiration, the repositories leave it false.
+	Locked     bool       `yaml:"locked" json:"locked"`
+	Password   string     `yaml:"password" json:"-"`
+	Disabled   bool       `yaml:"-" json:"-"`
+	Expiration *time.Time `yaml:"-" json:"-"`
+}
+
+func (u *UserAuthzInfo) IsLocked(grace time.Duration, now time.Time) bool {
+	return IsUserLocked(u.Disabled, u.Expiration, grace, now)
 }
 
 func (u *UserAuthzInfo) AbsoluteHomeDir(homesBaseDir string) string {
 	return filepath.Clean(filepath.Join(homesBaseDir, u.GroupHome, u.UserHome))
 }
+
+// EffectiveUserPolicy is the settings resolved for one user from the configuration and its group.
+type EffectiveUserPolicy struct {
+	Username  string
+	Groupname string
+	// HomeDir is the absolute user home, resolved the way the storage service prepares it.
+	HomeDir string
+	// TopDirs are the default top dirs prepared in the home, Mode includes the setgid bit.
+	TopDirs []EffectiveTopDir
+	// GroupQuotaBytes is the quota shared by the group members, nil means unlimited.
+	GroupQuotaBytes    *uint64
+	GroupQuotaEnforced bool
+	// Locked tells whether the user is disabled or expired (grace period included) right now.
+	Locked                   bool
+	Expiration               *time.Time
+	ExpirationGracePeriod    time.Duration
+	ForbidUsernameInPassword bool
+	// PasswordAlgorithm hashes the plaintext passwords set for the user.
+	PasswordAlgorithm string
+}
+
+type EffectiveTopDir struct {
+	Name string
+	Mode fs.FileMode
+}
@@ -1,26 +1,47 @@
 package ports
 
+import "context"
+
 type ApiServer interface {
 	HealthCheck() error
+	// Close releases the account repository, once the server is done serving.
+	Close() error
+	RepositoryInfo() (info string, capabilities RepoCapabilities, err error)
+	Capabilities() RepoCapabilities
 	AuthzLookupUser(username string) (uai *UserAuthzInfo, baseDir string, err error)
 	AuthzAuthUser(username, password string) (err error)
 	GenerateSecret(requestedSize *int) (size int, secret []byte, err error)
 	ComputeHash(plaintext string, algorithm HashAlgo, rounds *int, saltLen *int) (hash string, err error)
 	VerifyHash(hash, plaintext string) (verified bool, algorithm HashAlgo, err error)
+	AuditPasswordHashes() (HashAudit, error)
+	CheckHomeDrift(ctx context.Context) (HomeDriftReport, error)
+	LastHomeDriftReport() HomeDriftReport
 
 	ListGroups() ([]GroupInfo, error)
+	ListEmptyGroups() ([]GroupInfo, error)
 	GetGroup(name string) (GroupInfo, error)
 	EnsureGroup(group GroupInfo) (gi GroupInfo, created bool, err error)
+	LintGroup(group GroupInfo) (warnings []string)
 	UpdateGroup(name string, mutate func(group GroupInfo) (GroupInfo, error)) error
-	DeleteGroup(name string) error
+	DeleteGroup(name string, purge bool) error
 
 	ListUsers() ([]UserInfo, error)
+	ListUsersPage(offset, limit int, sort string) (page []UserInfo, total int, err error)
 	GetUser(name string) (UserInfo, error)
 	EnsureUser(user UserInfo) (ui UserInfo, created bool, err error)
+	LintUser(user UserInfo) (warnings []string)
 	UpdateUser(name string, mutate func(user UserInfo) (UserInfo, error)) error
 	DeleteUser(name string) error
+	TouchUser(name string) (UserInfo, error)
+	ExpireUser(name string) error
+	// EffectiveUserPolicy resolves the settings applying to the user, read-only.
+	EffectiveUserPolicy(name string) (EffectiveUserPolicy, error)
+	DeleteUsers(filter UserFilter, purge bool) (deleted []string, affected int, purgeFailed []string, err error)
 
-	ListUserDirs(username string) (dirs []string, err error)
+	ListUserDirs(username string) (dirs []string, truncated bool, err error)
+	ListUserDirsDetailed(username string) (dirs []DirInfo, truncated bool, err error)
 	DeleteUserDir(username string, dirname string) error
 	EnsureUserDir(username string, dirname string) (created bool, err error)
+
+	ResolveHomePath(homesBaseDir *string, groupHome, userHome string, topDir *string) (path string, err error)
 }
@@ -4,8 +4,35 @@ import (
 	"net/http"
 )
 
+// Scopes an api key can be granted, each secured API operation requires one of them.
+// The public operations (health, status, secret and crypto) need no api key, hence no scope.
+const (
+	ScopeUsersRead   = "users:read"
+	ScopeUsersWrite  = "users:write"
+	ScopeGroupsRead  = "groups:read"
+	ScopeGroupsWrite = "groups:write"
+	ScopeAuthz       = "authz"
+	ScopeStorage     = "storage"
+	// ScopeAdmin covers the operator routes: /api/admin/*, /api/info and /api/config/*.
+	ScopeAdmin = "admin"
+)
+
+var KnownScopes = []string{ScopeUsersRead, ScopeUsersWrite, ScopeGroupsRead, ScopeGroupsWrite, ScopeAuthz, ScopeStorage, ScopeAdmin}
+
+// AccessPolicy decides whether an authenticated api key may perform an operation requiring the scope,
+// it returns ErrInsufficientScope or a *RateLimitedError.
+type AccessPolicy interface {
+	Authorize(apiKey, scope string) error
+}
+
 type Authenticator interface {
 	WithAuthChi(handler http.Handler) http.Handler
 	Verify(request *http.Request) error
 	Supports(request *http.Request) bool
 }
+
+// ResponseSigner signs the responses to requests authenticated with a shared secret,
+// ok is false when the request's scheme has no secret to sign with.
+type ResponseSigner interface {
+	SignResponse(request *http.Request, timestamp string, body []byte) (signature string, ok bool)
+}
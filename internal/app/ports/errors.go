@@ -1,16 +1,69 @@
 package ports
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
 	ErrNotFound      = errors.New("not found")
 	ErrConflict      = errors.New("conflict")
 	ErrAlreadyExists = errors.New("already exists")
+	// ErrUIDTaken is a user UID already used by another user (account_repository.common.allow_duplicate_uids off).
+	ErrUIDTaken = fmt.Errorf("%w: UID used by another user", ErrAlreadyExists)
+	// ErrVersionMismatch is the optimistic concurrency failure: the stored version differs from the expected one.
+	ErrVersionMismatch = fmt.Errorf("%w: version mismatch", ErrConflict)
+	// ErrGroupNotEmpty refuses to delete a group that is still the primary group of some users.
+	ErrGroupNotEmpty = fmt.Errorf("%w: group not empty", ErrConflict)
+	// ErrHomeTaken is a user home resolving to the home of another user (storage.enforce_globally_unique_home).
+	ErrHomeTaken = fmt.Errorf("%w: home used by another user", ErrConflict)
 
-	ErrInvalidInput       = errors.New("invalid input")
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrWeakPassword is a plaintext password rejected by the password policy.
+	ErrWeakPassword = fmt.Errorf("%w: weak password", ErrInvalidInput)
+	// ErrPlaintextPassword is a plaintext password refused by security.require_prehashed_passwords.
+	ErrPlaintextPassword  = errors.New("plaintext password")
 	ErrLockedUser         = errors.New("user is locked")
 	ErrInvalidCredentials = errors.New("invalid credentials")
 
 	ErrUnsupportedAlgorithm = errors.New("unsupported algorithm")
 	ErrUnsupportedAction    = errors.New("unsupported action")
+
+	ErrInsufficientScope = errors.New("insufficient scope")
+	ErrRateLimited       = errors.New("rate limit exceeded")
+	ErrQuotaExceeded     = errors.New("quota exceeded")
+	// ErrInsufficientStorage is the filesystem running below storage.min_free_bytes or min_free_inodes.
+	ErrInsufficientStorage = errors.New("insufficient storage")
 )
+
+// FieldError is an ErrInvalidInput (or an error wrapping it, e.g. ErrWeakPassword) about one field
+// of the request, the REST layer lists the field in its validation errors.
+type FieldError struct {
+	Field string
+	// Reason reads on its own, it names the field itself
+	Reason string
+	Err    error
+}
+
+// InvalidField returns the ErrInvalidInput about field.
+func InvalidField(field, format string, args ...any) error {
+	return &FieldError{Field: field, Reason: fmt.Sprintf(format, args...), Err: ErrInvalidInput}
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Err, e.Reason)
+}
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// RateLimitedError is ErrRateLimited carrying the time after which the request may be retried.
+type RateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("%v, retry after %s", ErrRateLimited, e.RetryAfter)
+}
+
+func (e *RateLimitedError) Unwrap() error { return ErrRateLimited }
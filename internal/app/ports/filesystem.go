@@ -11,6 +11,17 @@ type FilesystemService interface {
 	Chown(path string, uid, gid uint32) error
 	Chmod(path string, perm fs.FileMode) error
 	ReadDir(path string) ([]fs.DirEntry, error)
+	// ReadDirN reads at most n entries of the directory (all of them when n <= 0) in directory order,
+	// more tells some were left unread.
+	ReadDirN(path string, n int) (entries []fs.DirEntry, more bool, err error)
 	Remove(path string) error
 	RemoveAll(path string) error
+	// StatFS reports the free space and inodes of the filesystem holding path.
+	StatFS(path string) (FSStats, error)
+}
+
+// FSStats is the free capacity available to unprivileged users of a filesystem.
+type FSStats struct {
+	FreeBytes  uint64
+	FreeInodes uint64
 }
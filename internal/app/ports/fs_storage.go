@@ -1,9 +1,186 @@
 package ports
 
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
 type FsStorageService interface {
 	PrepareGroupHome(group GroupInfo) error
 	PrepareUserHome(user UserInfo, group GroupInfo) error
+	// PrepareUserHomeDetailed prepares the user home like PrepareUserHome and tells which directories it created.
+	PrepareUserHomeDetailed(user UserInfo, group GroupInfo) (HomePrepResult, error)
 	CreateUserTopDir(user UserInfo, group GroupInfo, topDir string) error
-	ListUserTopDirs(user UserInfo, group GroupInfo) ([]string, error)
+	// ListUserTopDirs lists the sorted user top dirs, truncated when the home has more than storage.max_dir_entries entries.
+	ListUserTopDirs(user UserInfo, group GroupInfo) (dirs []string, truncated bool, err error)
+	ListUserTopDirsDetailed(user UserInfo, group GroupInfo) (dirs []DirInfo, truncated bool, err error)
 	DeleteUserTopDir(user UserInfo, group GroupInfo, topDir string) error
+	// PurgeUserHome removes the user home with its content, refusing a home shared with the group.
+	PurgeUserHome(user UserInfo, group GroupInfo) error
+	// RemoveGroupHome removes the group home, refusing (ErrConflict) one that isn't empty.
+	RemoveGroupHome(group GroupInfo) error
+	// GroupUsageBytes sums the apparent size of the regular files stored under the group home.
+	GroupUsageBytes(group GroupInfo) (uint64, error)
+	// CheckUserHome compares the user home with the owner and mode PrepareUserHome gives it.
+	CheckUserHome(user UserInfo, group GroupInfo) ([]HomeDrift, error)
+}
+
+// HomePrepResult lists the absolute paths of the user home and its default top dirs,
+// split into those a preparation created and those already present.
+type HomePrepResult struct {
+	CreatedDirs  []string
+	ExistingDirs []string
+}
+
+type HomeDriftKind string
+
+const (
+	HomeDriftMissing HomeDriftKind = "missing"
+	HomeDriftOwner   HomeDriftKind = "owner"
+	HomeDriftMode    HomeDriftKind = "mode"
+)
+
+// HomeDrift is a user home differing from its prepared state, owners are formatted uid:gid and modes in octal.
+type HomeDrift struct {
+	Username string
+	Path     string
+	Kind     HomeDriftKind
+	Expected string
+	Actual   string
+}
+
+// HomeDriftReport is the result of a check of all the user homes, CheckedAt is nil until a check completed.
+type HomeDriftReport struct {
+	CheckedAt    *time.Time
+	UsersChecked int
+	// Errors counts the users whose home couldn't be checked (see the log).
+	Errors int
+	Drifts []HomeDrift
+}
+
+// DirInfo describes a user top-level directory, ModTime is zero when the filesystem doesn't track it.
+type DirInfo struct {
+	Name    string
+	Mode    fs.FileMode
+	UID     uint32
+	GID     uint32
+	ModTime time.Time
+}
+
+// CheckTopDirName refuses the top dir names "." and ".." and, when a pattern is given, the names not matching it.
+func CheckTopDirName(name string, pattern *regexp.Regexp) error {
+	if name == "." || name == ".." {
+		return InvalidField("dirname", "top dir name %q", name)
+	}
+	if pattern != nil && !pattern.MatchString(name) {
+		return InvalidField("dirname", "top dir name %q doesn't match %s", name, pattern)
+	}
+	return nil
+}
+
+// HomePaths resolves the group homes, user homes and user top dirs under HomesBaseDir the way the
+// storage service lays them out, applying every storage policy on the way: the storage operations and
+// the resolve endpoint all go through it, so a path one of them accepts is accepted by all.
+// The path is returned even when it's rejected, together with an ErrInvalidInput.
+type HomePaths struct {
+	HomesBaseDir string
+	// RequireUserHomeSubdir rejects user homes resolving to the group home itself.
+	RequireUserHomeSubdir bool
+	// MaxHomeDepth bounds the segments of a group home (under the base dir) and of a user home
+	// (under its group home), zero means unlimited.
+	MaxHomeDepth int
+	// MaxPathLength bounds the resolved paths (PATH_MAX counts the NUL), zero means unlimited.
+	MaxPathLength int
+	// TopDirName restricts the top dir names, nil accepts any but "." and "..".
+	TopDirName *regexp.Regexp
+}
+
+// GroupHome resolves the absolute group home.
+func (p HomePaths) GroupHome(groupHome string) (string, error) {
+	sep := string(filepath.Separator)
+	base := filepath.Clean(p.HomesBaseDir)
+	path := filepath.Clean(filepath.Join(base, groupHome))
+	if strings.HasPrefix(filepath.Clean(groupHome), sep) {
+		return path, fmt.Errorf("%w: absolute group home: %q", ErrInvalidInput, groupHome)
+	}
+	if !strings.HasPrefix(path+sep, base+sep) {
+		return path, fmt.Errorf("%w: group home %q escapes root %q", ErrInvalidInput, path, base)
+	}
+	if err := p.checkDepth("group", groupHome, base, path); err != nil {
+		return path, err
+	}
+	return path, p.checkLength(path)
+}
+
+// UserHome resolves the absolute user home, its group home must be valid too.
+func (p HomePaths) UserHome(groupHome, userHome string) (string, error) {
+	sep := string(filepath.Separator)
+	absGroupHome, groupErr := p.GroupHome(groupHome)
+	path := filepath.Clean(filepath.Join(absGroupHome, userHome))
+	if groupErr != nil {
+		return path, groupErr
+	}
+	if strings.HasPrefix(filepath.Clean(userHome), sep) {
+		return path, fmt.Errorf("%w: absolute user home: %q", ErrInvalidInput, userHome)
+	}
+	if !strings.HasPrefix(path+sep, absGroupHome+sep) {
+		return path, fmt.Errorf("%w: user home %q escapes group %q", ErrInvalidInput, path, absGroupHome)
+	}
+	if p.RequireUserHomeSubdir && path == absGroupHome {
+		return path, fmt.Errorf("%w: user home %q resolves to the group home", ErrInvalidInput, userHome)
+	}
+	if err := p.checkDepth("user", userHome, absGroupHome, path); err != nil {
+		return path, err
+	}
+	return path, p.checkLength(path)
+}
+
+// TopDir resolves a user top dir, a single name directly under the (valid) user home.
+func (p HomePaths) TopDir(groupHome, userHome, topDir string) (string, error) {
+	sep := string(filepath.Separator)
+	absUserHome, userErr := p.UserHome(groupHome, userHome)
+	path := filepath.Clean(filepath.Join(absUserHome, topDir))
+	if userErr != nil {
+		return path, userErr
+	}
+	if strings.HasPrefix(filepath.Clean(topDir), sep) {
+		return path, fmt.Errorf("%w: absolute top dir: %q", ErrInvalidInput, topDir)
+	}
+	if !strings.HasPrefix(path+sep, absUserHome+sep) {
+		return path, fmt.Errorf("%w: top dir %q escapes user home %q", ErrInvalidInput, path, absUserHome)
+	}
+	if filepath.Dir(path) != absUserHome {
+		return path, fmt.Errorf("%w: refusing non-top-level directory: %q", ErrInvalidInput, path)
+	}
+	if err := CheckTopDirName(topDir, p.TopDirName); err != nil {
+		return path, err
+	}
+	return path, p.checkLength(path)
+}
+
+// checkDepth counts the segments of path below parent, so "a/../b" is one segment deep.
+func (p HomePaths) checkDepth(kind, home, parent, path string) error {
+	if p.MaxHomeDepth <= 0 || path == parent {
+		return nil
+	}
+	rel, err := filepath.Rel(parent, path)
+	if err != nil {
+		return fmt.Errorf("%w: %s home %q: %v", ErrInvalidInput, kind, home, err)
+	}
+	if depth := len(strings.Split(rel, string(filepath.Separator))); depth > p.MaxHomeDepth {
+		return fmt.Errorf("%w: %s home %q has %d path segments, storage.max_home_depth is %d", ErrInvalidInput, kind, home, depth, p.MaxHomeDepth)
+	}
+	return nil
+}
+
+// checkLength rejects paths the syscalls would fail with ENAMETOOLONG.
+func (p HomePaths) checkLength(path string) error {
+	if p.MaxPathLength > 0 && len(path) >= p.MaxPathLength {
+		return fmt.Errorf("%w: resolved path has %d bytes, storage.max_path_length is %d", ErrInvalidInput, len(path), p.MaxPathLength)
+	}
+	return nil
 }
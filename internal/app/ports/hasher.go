@@ -1,6 +1,8 @@
 package ports
 
 import (
+	"encoding/base64"
+	"strconv"
 	"strings"
 )
 
@@ -10,16 +12,62 @@ func (a HashAlgo) IsCrypt() bool {
 	return strings.HasPrefix(string(a), "crypt-")
 }
 
+// IsSalted tells the algorithm salts its hashes: the crypt(3) ones and argon2id.
+func (a HashAlgo) IsSalted() bool {
+	return a.IsCrypt() || a == AlgoArgon2id
+}
+
+// IsLegacy tells the hash was verified by a LegacyVerifier and should be rehashed.
+func (a HashAlgo) IsLegacy() bool {
+	return strings.HasPrefix(string(a), "legacy-")
+}
+
+// LegacyHashAlgo is the algorithm reported for hashes verified by the named LegacyVerifier.
+func LegacyHashAlgo(name string) HashAlgo {
+	return HashAlgo("legacy-" + name)
+}
+
 const (
 	AlgoCryptMD5    HashAlgo = "crypt-md5"    // $1$
 	AlgoCryptSHA256 HashAlgo = "crypt-sha256" // $5$
 	AlgoCryptSHA512 HashAlgo = "crypt-sha512" // $6$
+	AlgoArgon2id    HashAlgo = "argon2id"     // $argon2id$ (PHC)
 	AlgoRawMD5      HashAlgo = "raw-md5"      // 32 hex
 	AlgoRawSHA1     HashAlgo = "raw-sha1"     // 40 hex
 	AlgoRawSHA256   HashAlgo = "raw-sha256"   // 64 hex
 	AlgoRawSHA512   HashAlgo = "raw-sha512"   // 128 hex
 )
 
+// AlgoUnknown is reported for stored passwords DetectHashAlgo can't classify (plaintext, legacy formats).
+const AlgoUnknown HashAlgo = "unknown"
+
+// hashAlgoStrength orders the algorithms from the weakest, salted crypt(3) formats rank above raw digests
+// and the memory-hard argon2id above them.
+var hashAlgoStrength = map[HashAlgo]int{
+	AlgoRawMD5:      1,
+	AlgoRawSHA1:     2,
+	AlgoRawSHA256:   3,
+	AlgoRawSHA512:   4,
+	AlgoCryptMD5:    5,
+	AlgoCryptSHA256: 6,
+	AlgoCryptSHA512: 7,
+	AlgoArgon2id:    8,
+}
+
+// WeakerThan tells a ranks below b, unknown algorithms are weaker than any known one.
+func (a HashAlgo) WeakerThan(b HashAlgo) bool {
+	return hashAlgoStrength[a] < hashAlgoStrength[b]
+}
+
+// HashAudit summarizes the stored password hashes, it never carries the hashes themselves.
+type HashAudit struct {
+	Total        int
+	ByAlgorithm  map[HashAlgo]int
+	MinAlgorithm HashAlgo
+	// WeakUsers lists (sorted) the users whose hash is weaker than MinAlgorithm.
+	WeakUsers []string
+}
+
 type Hasher interface {
 	DefaultHash(plain string) (hash string, err error)
 	Hash(plain string, alg HashAlgo, rounds *int, saltLen *int) (hash string, err error)
@@ -27,6 +75,14 @@ type Hasher interface {
 	SupportedAlgorithms() []HashAlgo
 }
 
+// LegacyVerifier verifies a legacy (e.g. site-specific) hash format, it's consulted when the
+// Hasher can't verify a stored hash, so migrated users can log in and get rehashed.
+type LegacyVerifier interface {
+	Name() string
+	Matches(hashed string) bool
+	Verify(hashed, plain string) (verified bool, err error)
+}
+
 func ParseHashAlgo(s string) (HashAlgo, error) {
 	s = strings.ToLower(strings.TrimSpace(s))
 	switch s {
@@ -36,6 +92,8 @@ func ParseHashAlgo(s string) (HashAlgo, error) {
 		return AlgoCryptSHA256, nil
 	case "crypt-sha512":
 		return AlgoCryptSHA512, nil
+	case "argon2id":
+		return AlgoArgon2id, nil
 	case "raw-md5":
 		return AlgoRawMD5, nil
 	case "raw-sha1":
@@ -62,6 +120,8 @@ func DetectHashAlgo(hashed string) (HashAlgo, error) {
 		return AlgoCryptSHA256, nil
 	case strings.HasPrefix(s, "$1$"):
 		return AlgoCryptMD5, nil
+	case strings.HasPrefix(s, "$argon2id$"):
+		return AlgoArgon2id, nil
 	}
 
 	// raw hex digests (lowercase normalize for the check)
@@ -79,6 +139,31 @@ func DetectHashAlgo(hashed string) (HashAlgo, error) {
 	}
 }
 
+// CryptHashParams extracts the effective rounds and salt length from a crypt(3) hash,
+// both are nil for raw digests and rounds is nil for crypt-md5 (which has no rounds)
+// and argon2id (whose salt length is in bytes).
+func CryptHashParams(hashed string) (rounds *int, saltLen *int) {
+	parts := strings.Split(hashed, "$")
+	if len(parts) == 6 && parts[0] == "" && parts[1] == "argon2id" {
+		// "", argon2id, v=19, m=..,t=..,p=.., salt, digest
+		n := base64.RawStdEncoding.DecodedLen(len(parts[4]))
+		return nil, &n
+	}
+	// "", id, [rounds=N,] salt, digest
+	if len(parts) < 4 || parts[0] != "" {
+		return nil, nil
+	}
+	salt := parts[2]
+	if r, ok := strings.CutPrefix(parts[2], "rounds="); ok && len(parts) >= 5 {
+		if n, err := strconv.Atoi(r); err == nil {
+			rounds = &n
+		}
+		salt = parts[3]
+	}
+	n := len(salt)
+	return rounds, &n
+}
+
 // isHexLen returns true if s is exactly n hex chars (0-9a-f).
 func isHexLen(s string, n int) bool {
 	if len(s) != n {
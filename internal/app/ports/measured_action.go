@@ -19,4 +19,6 @@ type MeasuredAction interface {
 	Done(result MeasuredActionResult) MeasuredAction
 	Duration() float64
 	Labels() map[MeasuredActionLabel]string
+	// TraceID is the trace the action ran in, "" outside a trace.
+	TraceID() string
 }
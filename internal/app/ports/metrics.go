@@ -3,3 +3,7 @@ package ports
 type ActionMetrics interface {
 	OnActionDone(ma MeasuredAction)
 }
+
+type HomeDriftMetrics interface {
+	OnHomeDrift(drift HomeDrift)
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"fs-access-api/internal/adapters/in/rest"
+	"fs-access-api/internal/app/config"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const maxStartupBackoff = 30 * time.Second
+
+// retryStartup calls connect until it succeeds, at most retries more times after the first failure,
+// sleeping backoff (doubled after every attempt, up to maxStartupBackoff) in between; it gives up
+// early when ctx is done.
+func retryStartup(ctx context.Context, retries int, backoff time.Duration, what string, connect func() error) error {
+	err := connect()
+	for attempt := 1; err != nil && attempt <= retries; attempt++ {
+		log.Printf("%s not available, retry %d/%d in %s: %v", what, attempt, retries, backoff, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (stopped retrying: %v)", err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxStartupBackoff)
+		err = connect()
+	}
+	return err
+}
+
+// StartupGate is served while the application is starting: /healthz answers 200 so the process
+// isn't restarted, /readyz and everything else 503 until Ready hands the requests over.
+type StartupGate struct {
+	cfg     config.HttpServerConfig
+	handler atomic.Pointer[http.Handler]
+}
+
+func NewStartupGate(cfg config.HttpServerConfig) *StartupGate {
+	return &StartupGate{cfg: cfg}
+}
+
+// Ready passes every following request to h.
+func (g *StartupGate) Ready(h http.Handler) {
+	g.handler.Store(&h)
+}
+
+func (g *StartupGate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h := g.handler.Load(); h != nil {
+		(*h).ServeHTTP(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if r.URL.Path == g.cfg.ProbePath("/healthz") {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+		return
+	}
+	rest.SetRetryAfter(w, g.cfg.RetryAfter)
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_, _ = w.Write([]byte("starting"))
+}
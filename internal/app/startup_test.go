@@ -0,0 +1,130 @@
+package app_test
+
+import (
+	"context"
+	"fmt"
+	"fs-access-api/internal/adapters/in/rest/openapi"
+	"fs-access-api/internal/app"
+	"fs-access-api/internal/app/config"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Startup gate", func() {
+	serve := func(h http.Handler, path string) int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	It("is live but not ready until the api is handed over", func() {
+		gate := app.NewStartupGate(config.HttpServerConfig{})
+		Expect(serve(gate, "/healthz")).To(Equal(http.StatusOK))
+		Expect(serve(gate, "/readyz")).To(Equal(http.StatusServiceUnavailable))
+		Expect(serve(gate, "/api/users")).To(Equal(http.StatusServiceUnavailable))
+
+		gate.Ready(app.BuildRouter(config.HttpServerConfig{RequestTimeout: time.Second}, openapi.Unimplemented{}))
+		Expect(serve(gate, "/readyz")).To(Equal(http.StatusOK))
+	})
+
+	It("asks to retry after http_server.retry_after", func() {
+		rec := httptest.NewRecorder()
+		app.NewStartupGate(config.HttpServerConfig{RetryAfter: 7 * time.Second}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/users", nil))
+		Expect(rec.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(rec.Header().Get("Retry-After")).To(Equal("7"))
+	})
+
+	It("answers the prefixed liveness probe with prefix_probes", func() {
+		gate := app.NewStartupGate(config.HttpServerConfig{BasePath: "/fsaa", PrefixProbes: true})
+		Expect(serve(gate, "/fsaa/healthz")).To(Equal(http.StatusOK))
+		Expect(serve(gate, "/healthz")).To(Equal(http.StatusServiceUnavailable))
+	})
+})
+
+var _ = Describe("BuildApiServer startup retries", func() {
+	// sqliteConfig points at a database whose directory is missing, the repository can't open it until it's created
+	sqliteConfig := func(retries int) (*config.ProgramConfig, string) {
+		tmp := GinkgoT().TempDir()
+		cfg, err := config.LoadConfigString(fmt.Sprintf(`
+storage: { implementation: inmem, homes_base_dir: %[1]s/homes, create_homes_base_dir: true }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository:
+  type: sqlite
+  sqlite: { db_file_path: %[1]s/db/test.db, create_db_dir: false }
+  startup_retries: %[2]d
+  startup_backoff: 20ms
+http_server: {}
+`, tmp, retries))
+		Expect(err).NotTo(HaveOccurred())
+		return cfg, filepath.Join(tmp, "db")
+	}
+
+	It("fails fast without retries", func() {
+		cfg, _ := sqliteConfig(0)
+		_, err := app.BuildApiServer(context.Background(), cfg, true)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("retries until the database becomes available", func() {
+		cfg, dbDir := sqliteConfig(5)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			_ = os.MkdirAll(dbDir, 0o750)
+		}()
+		apis, err := app.BuildApiServer(context.Background(), cfg, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apis.HealthCheck()).To(Succeed())
+	})
+
+	It("stops retrying when the context is done", func() {
+		cfg, _ := sqliteConfig(100)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := app.BuildApiServer(ctx, cfg, true)
+		Expect(err).To(MatchError(ContainSubstring("stopped retrying")))
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+	})
+})
+
+var _ = Describe("BuildApiServer auto_migrate", func() {
+	sqliteConfig := func(autoMigrate bool) *config.ProgramConfig {
+		tmp := GinkgoT().TempDir()
+		// without bootstrap the homes base dir must exist already
+		Expect(os.Mkdir(filepath.Join(tmp, "homes"), 0o750)).To(Succeed())
+		cfg, err := config.LoadConfigString(fmt.Sprintf(`
+storage: { implementation: unix, homes_base_dir: %[1]s/homes }
+metrics: {}
+security: { authenticator: { access_keys: { key1: 00112233445566778899aabbccddeeff } } }
+account_repository:
+  type: sqlite
+  sqlite: { db_file_path: %[1]s/test.db }
+  auto_migrate: %[2]t
+http_server: {}
+`, tmp, autoMigrate))
+		Expect(err).NotTo(HaveOccurred())
+		return cfg
+	}
+
+	It("creates the schema of an empty database without bootstrap", func() {
+		apis, err := app.BuildApiServer(context.Background(), sqliteConfig(true), false)
+		Expect(err).NotTo(HaveOccurred())
+		users, err := apis.ListUsers()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(users).To(BeEmpty())
+	})
+
+	It("leaves the schema to the bootstrap by default", func() {
+		apis, err := app.BuildApiServer(context.Background(), sqliteConfig(false), false)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = apis.ListUsers()
+		Expect(err).To(MatchError(ContainSubstring("no such table")))
+	})
+})
@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"fs-access-api/internal/adapters/in/rest"
 	"fs-access-api/internal/adapters/out/metrics"
 	"fs-access-api/internal/app"
 	"fs-access-api/internal/app/config"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -55,24 +60,49 @@ func main() {
 		panic(err)
 	}
 
-	restServer, err := app.BuildRestServer(cfg, *bootstrapFlag, actionMetrics)
+	// listen right away: /healthz answers while the api server is built, /readyz only once it's done
+	gate := app.NewStartupGate(cfg.HttpServer)
+	mux := http.NewServeMux()
+	mux.Handle(cfg.HttpServer.ProbePath(cfg.HttpServer.TelemetryPath), promhttp.HandlerFor(reg, promhttp.HandlerOpts{EnableOpenMetrics: true}))
+	mux.Handle("/", gate)
+
+	servers, err := app.NewMultiHTTPServer(cfg.HttpServer, mux)
 	if err != nil {
-		panic(fmt.Errorf("cannot build rest server: %v", err))
+		panic(err)
 	}
+	servers.Start()
 
-	router := app.BuildRouter(restServer)
+	// an interrupt stops a long initial data load (or the startup retries) instead of waiting for it
+	bootstrapCtx, stopBootstrap := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	apiServer, err := app.BuildApiServer(bootstrapCtx, cfg, *bootstrapFlag)
+	stopBootstrap()
+	if err != nil {
+		panic(fmt.Errorf("cannot build api server: %v", err))
+	}
 
-	// Wrap router to expose /metrics alongside all existing routes.
-	mux := http.NewServeMux()
-	mux.Handle(cfg.HttpServer.TelemetryPath, promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	restServer, err := app.BuildRestServerFor(cfg, apiServer, actionMetrics)
+	if err != nil {
+		panic(fmt.Errorf("cannot build rest server: %v", err))
+	}
 
-	// / is the root of the API
-	mux.Handle("/", router)
+	stopHomeDriftChecker := app.StartHomeDriftChecker(cfg.Storage.HomeDriftCheck, apiServer, metrics.NewHomeDriftMetrics(cfg.Metrics, reg))
 
-	servers, err := app.NewMultiHTTPServer(cfg.HttpServer, mux)
+	responseSigner, err := app.BuildResponseSigner(cfg)
 	if err != nil {
 		panic(err)
 	}
-	servers.Start()
+
+	// the generated handlers apply the first middleware innermost
+	router := app.BuildRouter(cfg.HttpServer, restServer, restServer.MaintenanceMiddleware,
+		rest.ReadOnlyKeysMiddleware(cfg.Security.ReadOnlyKeys), rest.ResponseSigningMiddleware(responseSigner),
+		restServer.AccessMiddleware, restServer.CacheMiddleware)
+
+	// / is the root of the API, the router mounts it under http_server.base_path
+	gate.Ready(router)
+
 	servers.WaitAndShutdown()
+	stopHomeDriftChecker()
+	if err := apiServer.Close(); err != nil {
+		log.Printf("cannot close the account repository: %v", err)
+	}
 }